@@ -14,10 +14,32 @@
  * along with this program.  If not, see <https://www.gnu.org/licenses/>.
  */
 
-// Package main load the Cryptography CLI
+// Package main loads the offline Cryptography CLI
 package main
 
-func main() {
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	_ "modernc.org/sqlite"
+	"scanoss.com/cryptography/pkg/cmd"
+)
 
-	// TODO Add CLI entry point...
+// main runs the Cryptography CLI against the KB.
+func main() {
+	var stdin io.Reader
+	if info, err := os.Stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice == 0 {
+		stdin = os.Stdin // Only read purls from stdin if they are piped in
+	}
+	if err := cmd.RunCli(os.Args[1:], stdin, os.Stdout, os.Stderr); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+		var exitErr *cmd.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
+		os.Exit(1)
+	}
+	os.Exit(0)
 }
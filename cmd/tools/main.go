@@ -17,15 +17,48 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"slices"
 
 	_ "modernc.org/sqlite"
 	"scanoss.com/cryptography/pkg/cmd"
 )
 
 // main provides several tools to manage information for Cryptography service.
+// The validate tool checks a detection definitions file, exiting with a non-zero code if it is not valid.
+// The export tool copies the KB rows of a list of purls into a new SQLite file.
+// The audit tool checks the KB for data quality issues, exiting with a non-zero code if any are found.
+// The diff tool reports the changes of the algorithms and hints of a list of purls between two KBs.
+// The generate and replay tools build a synthetic KB and measure the latencies of the service against it.
 func main() {
+	if len(os.Args) > 1 && slices.Contains([]string{"validate", "export", "audit", "diff", "generate", "replay"}, os.Args[1]) {
+		var err error
+		switch os.Args[1] {
+		case "validate":
+			err = cmd.RunValidate(os.Args[2:], os.Stdout, os.Stderr)
+		case "export":
+			err = cmd.RunExport(os.Args[2:], os.Stdin, os.Stdout, os.Stderr)
+		case "audit":
+			err = cmd.RunAudit(os.Args[2:], os.Stdout, os.Stderr)
+		case "diff":
+			err = cmd.RunDiff(os.Args[2:], os.Stdin, os.Stdout, os.Stderr)
+		case "generate":
+			err = cmd.RunGenerate(os.Args[2:], os.Stdout, os.Stderr)
+		default:
+			err = cmd.RunReplay(os.Args[2:], os.Stdout, os.Stderr)
+		}
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+			var exitErr *cmd.ExitError
+			if errors.As(err, &exitErr) {
+				os.Exit(exitErr.Code)
+			}
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
 	if err := cmd.SupportTools(); err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "ERROR: Server launch error: %v\n", err)
 		os.Exit(1)
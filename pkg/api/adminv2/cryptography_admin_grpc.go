@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package adminv2 defines the administration gRPC service of the Cryptography server.
+// The service only uses the protobuf well-known types (Empty, Struct and StringValue),
+// so the descriptor is maintained by hand here instead of being generated from a PAPI protobuf.
+package adminv2
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	ServiceName = "scanoss.api.cryptography.v2.CryptographyAdmin"
+
+	GetKBStatsFullMethodName    = "/" + ServiceName + "/GetKBStats"
+	FlushCacheFullMethodName    = "/" + ServiceName + "/FlushCache"
+	ReloadFiltersFullMethodName = "/" + ServiceName + "/ReloadFilters"
+	SetLogLevelFullMethodName   = "/" + ServiceName + "/SetLogLevel"
+	GetConfigFullMethodName     = "/" + ServiceName + "/GetConfig"
+)
+
+// CryptographyAdminServer is the server API for the CryptographyAdmin service.
+//
+// GetKBStats returns the row counts of the KB tables and the date of the latest version loaded.
+// FlushCache drops the KB lookup cache and returns its usage metrics before the flush.
+// ReloadFilters re-reads the allow/deny IP lists used to filter incoming gRPC connections.
+// SetLogLevel changes the logging level (debug, info, warn, error) and returns the level applied.
+// GetConfig returns the running configuration, with secrets redacted.
+type CryptographyAdminServer interface {
+	GetKBStats(context.Context, *emptypb.Empty) (*structpb.Struct, error)
+	FlushCache(context.Context, *emptypb.Empty) (*structpb.Struct, error)
+	ReloadFilters(context.Context, *emptypb.Empty) (*structpb.Struct, error)
+	SetLogLevel(context.Context, *wrapperspb.StringValue) (*wrapperspb.StringValue, error)
+	GetConfig(context.Context, *emptypb.Empty) (*structpb.Struct, error)
+}
+
+// RegisterCryptographyAdminServer registers the admin service implementation with the given gRPC server.
+func RegisterCryptographyAdminServer(s grpc.ServiceRegistrar, srv CryptographyAdminServer) {
+	s.RegisterService(&CryptographyAdminServiceDesc, srv)
+}
+
+// unaryHandler builds a handler for a unary method, running it through the server interceptors (if any).
+func unaryHandler[Req, Res any](method string, call func(CryptographyAdminServer, context.Context, *Req) (*Res, error)) grpc.MethodHandler {
+	return func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+		in := new(Req)
+		if err := dec(in); err != nil {
+			return nil, err
+		}
+		if interceptor == nil {
+			return call(srv.(CryptographyAdminServer), ctx, in)
+		}
+		info := &grpc.UnaryServerInfo{Server: srv, FullMethod: method}
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return call(srv.(CryptographyAdminServer), ctx, req.(*Req))
+		}
+		return interceptor(ctx, in, info, handler)
+	}
+}
+
+// CryptographyAdminServiceDesc is the grpc.ServiceDesc for the CryptographyAdmin service.
+var CryptographyAdminServiceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*CryptographyAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetKBStats",
+			Handler:    unaryHandler(GetKBStatsFullMethodName, CryptographyAdminServer.GetKBStats),
+		},
+		{
+			MethodName: "FlushCache",
+			Handler:    unaryHandler(FlushCacheFullMethodName, CryptographyAdminServer.FlushCache),
+		},
+		{
+			MethodName: "ReloadFilters",
+			Handler:    unaryHandler(ReloadFiltersFullMethodName, CryptographyAdminServer.ReloadFilters),
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    unaryHandler(SetLogLevelFullMethodName, CryptographyAdminServer.SetLogLevel),
+		},
+		{
+			MethodName: "GetConfig",
+			Handler:    unaryHandler(GetConfigFullMethodName, CryptographyAdminServer.GetConfig),
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "scanoss/api/cryptography/v2/scanoss-cryptography-admin.proto",
+}
+
+// CryptographyAdminClient is the client API for the CryptographyAdmin service.
+type CryptographyAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewCryptographyAdminClient creates a new client for the CryptographyAdmin service.
+func NewCryptographyAdminClient(cc grpc.ClientConnInterface) *CryptographyAdminClient {
+	return &CryptographyAdminClient{cc: cc}
+}
+
+// invoke runs a unary call against the admin service.
+func invoke[Res any](ctx context.Context, cc grpc.ClientConnInterface, method string, in any, opts ...grpc.CallOption) (*Res, error) {
+	out := new(Res)
+	if err := cc.Invoke(ctx, method, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *CryptographyAdminClient) GetKBStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	return invoke[structpb.Struct](ctx, c.cc, GetKBStatsFullMethodName, in, opts...)
+}
+
+func (c *CryptographyAdminClient) FlushCache(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	return invoke[structpb.Struct](ctx, c.cc, FlushCacheFullMethodName, in, opts...)
+}
+
+func (c *CryptographyAdminClient) ReloadFilters(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	return invoke[structpb.Struct](ctx, c.cc, ReloadFiltersFullMethodName, in, opts...)
+}
+
+func (c *CryptographyAdminClient) SetLogLevel(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*wrapperspb.StringValue, error) {
+	return invoke[wrapperspb.StringValue](ctx, c.cc, SetLogLevelFullMethodName, in, opts...)
+}
+
+func (c *CryptographyAdminClient) GetConfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	return invoke[structpb.Struct](ctx, c.cc, GetConfigFullMethodName, in, opts...)
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package packagesv2 defines the gRPC service looking up the Cryptography KB by package hash.
+// The messages are generated from protobuf/scanoss/api/cryptography/v2/scanoss-cryptography-packages.proto
+// until they are added to the PAPI protobuf, and the service descriptor is maintained by hand here.
+package packagesv2
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	ServiceName = "scanoss.api.cryptography.v2.CryptographyPackages"
+
+	GetPackagesCryptographyFullMethodName = "/" + ServiceName + "/GetPackagesCryptography"
+)
+
+// CryptographyPackagesServer is the server API for the CryptographyPackages service.
+//
+// GetPackagesCryptography takes a list of package hashes (the MD5 of the downloaded archives), and returns
+// the component version, algorithms and hints of each package found, along with the overall status.
+type CryptographyPackagesServer interface {
+	GetPackagesCryptography(context.Context, *PackagesRequest) (*PackagesResponse, error)
+}
+
+// RegisterCryptographyPackagesServer registers the packages service implementation with the given gRPC server.
+func RegisterCryptographyPackagesServer(s grpc.ServiceRegistrar, srv CryptographyPackagesServer) {
+	s.RegisterService(&CryptographyPackagesServiceDesc, srv)
+}
+
+func getPackagesCryptographyHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PackagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptographyPackagesServer).GetPackagesCryptography(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: GetPackagesCryptographyFullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptographyPackagesServer).GetPackagesCryptography(ctx, req.(*PackagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CryptographyPackagesServiceDesc is the grpc.ServiceDesc for the CryptographyPackages service.
+var CryptographyPackagesServiceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*CryptographyPackagesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPackagesCryptography",
+			Handler:    getPackagesCryptographyHandler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "scanoss/api/cryptography/v2/scanoss-cryptography-packages.proto",
+}
+
+// CryptographyPackagesClient is the client API for the CryptographyPackages service.
+type CryptographyPackagesClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewCryptographyPackagesClient creates a new client for the CryptographyPackages service.
+func NewCryptographyPackagesClient(cc grpc.ClientConnInterface) *CryptographyPackagesClient {
+	return &CryptographyPackagesClient{cc: cc}
+}
+
+func (c *CryptographyPackagesClient) GetPackagesCryptography(ctx context.Context, in *PackagesRequest, opts ...grpc.CallOption) (*PackagesResponse, error) {
+	out := new(PackagesResponse)
+	if err := c.cc.Invoke(ctx, GetPackagesCryptographyFullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
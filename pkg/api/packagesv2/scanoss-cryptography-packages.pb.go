@@ -0,0 +1,413 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+//
+// Copyright (C) 2025 SCANOSS.COM
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 2 of the License, or
+// (at your option) any later version.
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Cryptography lookup of package archives (i.e. vendored tarballs) by their hash.
+// The service is not part of the PAPI protobuf yet, so its messages are generated in this repository.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.31.1
+// source: scanoss/api/cryptography/v2/scanoss-cryptography-packages.proto
+
+package packagesv2
+
+import (
+	commonv2 "github.com/scanoss/papi/api/commonv2"
+	cryptographyv2 "github.com/scanoss/papi/api/cryptographyv2"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Package hashes to look up.
+type PackagesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// MD5 hashes of the package archives (32 hexadecimal characters)
+	Hashes        []string `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackagesRequest) Reset() {
+	*x = PackagesRequest{}
+	mi := &file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackagesRequest) ProtoMessage() {}
+
+func (x *PackagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackagesRequest.ProtoReflect.Descriptor instead.
+func (*PackagesRequest) Descriptor() ([]byte, []int) {
+	return file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PackagesRequest) GetHashes() []string {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+// Detection hint found in a package.
+type PackageHint struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Crypto library definition ID
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Name of the crypto library
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Description of the crypto library
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Hint category (i.e. library or protocol)
+	Category string `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	// URL of the crypto library
+	Url string `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	// Purl of the crypto library
+	Purl string `protobuf:"bytes,6,opt,name=purl,proto3" json:"purl,omitempty"`
+	// Tags of the crypto library definition (i.e. fips, tls or pqc)
+	Tags          []string `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageHint) Reset() {
+	*x = PackageHint{}
+	mi := &file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageHint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageHint) ProtoMessage() {}
+
+func (x *PackageHint) ProtoReflect() protoreflect.Message {
+	mi := &file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageHint.ProtoReflect.Descriptor instead.
+func (*PackageHint) Descriptor() ([]byte, []int) {
+	return file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PackageHint) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PackageHint) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PackageHint) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PackageHint) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *PackageHint) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *PackageHint) GetPurl() string {
+	if x != nil {
+		return x.Purl
+	}
+	return ""
+}
+
+func (x *PackageHint) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// Cryptography of a package found in the KB.
+type PackageCryptography struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// MD5 hash of the package archive
+	PackageHash string `protobuf:"bytes,1,opt,name=package_hash,json=packageHash,proto3" json:"package_hash,omitempty"`
+	// Purl of the component matching the package
+	Purl string `protobuf:"bytes,2,opt,name=purl,proto3" json:"purl,omitempty"`
+	// Version of the component matching the package
+	Version string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	// Distinct algorithms found in the package
+	Algorithms []*cryptographyv2.Algorithm `protobuf:"bytes,4,rep,name=algorithms,proto3" json:"algorithms,omitempty"`
+	// Detection hints found in the package
+	Hints         []*PackageHint `protobuf:"bytes,5,rep,name=hints,proto3" json:"hints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageCryptography) Reset() {
+	*x = PackageCryptography{}
+	mi := &file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageCryptography) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageCryptography) ProtoMessage() {}
+
+func (x *PackageCryptography) ProtoReflect() protoreflect.Message {
+	mi := &file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageCryptography.ProtoReflect.Descriptor instead.
+func (*PackageCryptography) Descriptor() ([]byte, []int) {
+	return file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PackageCryptography) GetPackageHash() string {
+	if x != nil {
+		return x.PackageHash
+	}
+	return ""
+}
+
+func (x *PackageCryptography) GetPurl() string {
+	if x != nil {
+		return x.Purl
+	}
+	return ""
+}
+
+func (x *PackageCryptography) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *PackageCryptography) GetAlgorithms() []*cryptographyv2.Algorithm {
+	if x != nil {
+		return x.Algorithms
+	}
+	return nil
+}
+
+func (x *PackageCryptography) GetHints() []*PackageHint {
+	if x != nil {
+		return x.Hints
+	}
+	return nil
+}
+
+// Cryptography of the packages found, and the status of the request.
+type PackagesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Packages found in the KB
+	Packages []*PackageCryptography `protobuf:"bytes,1,rep,name=packages,proto3" json:"packages,omitempty"`
+	// Response status
+	Status        *commonv2.StatusResponse `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackagesResponse) Reset() {
+	*x = PackagesResponse{}
+	mi := &file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackagesResponse) ProtoMessage() {}
+
+func (x *PackagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackagesResponse.ProtoReflect.Descriptor instead.
+func (*PackagesResponse) Descriptor() ([]byte, []int) {
+	return file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PackagesResponse) GetPackages() []*PackageCryptography {
+	if x != nil {
+		return x.Packages
+	}
+	return nil
+}
+
+func (x *PackagesResponse) GetStatus() *commonv2.StatusResponse {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+var File_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto protoreflect.FileDescriptor
+
+const file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_rawDesc = "" +
+	"\n" +
+	"?scanoss/api/cryptography/v2/scanoss-cryptography-packages.proto\x12\x1bscanoss.api.cryptography.v2\x1a*scanoss/api/common/v2/scanoss-common.proto\x1a6scanoss/api/cryptography/v2/scanoss-cryptography.proto\")\n" +
+	"\x0fPackagesRequest\x12\x16\n" +
+	"\x06hashes\x18\x01 \x03(\tR\x06hashes\"\xa9\x01\n" +
+	"\vPackageHint\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x10\n" +
+	"\x03url\x18\x05 \x01(\tR\x03url\x12\x12\n" +
+	"\x04purl\x18\x06 \x01(\tR\x04purl\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\"\xee\x01\n" +
+	"\x13PackageCryptography\x12!\n" +
+	"\fpackage_hash\x18\x01 \x01(\tR\vpackageHash\x12\x12\n" +
+	"\x04purl\x18\x02 \x01(\tR\x04purl\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12F\n" +
+	"\n" +
+	"algorithms\x18\x04 \x03(\v2&.scanoss.api.cryptography.v2.AlgorithmR\n" +
+	"algorithms\x12>\n" +
+	"\x05hints\x18\x05 \x03(\v2(.scanoss.api.cryptography.v2.PackageHintR\x05hints\"\x9f\x01\n" +
+	"\x10PackagesResponse\x12L\n" +
+	"\bpackages\x18\x01 \x03(\v20.scanoss.api.cryptography.v2.PackageCryptographyR\bpackages\x12=\n" +
+	"\x06status\x18\x02 \x01(\v2%.scanoss.api.common.v2.StatusResponseR\x06status2\x8e\x01\n" +
+	"\x14CryptographyPackages\x12v\n" +
+	"\x17GetPackagesCryptography\x12,.scanoss.api.cryptography.v2.PackagesRequest\x1a-.scanoss.api.cryptography.v2.PackagesResponseB8Z6scanoss.com/cryptography/pkg/api/packagesv2;packagesv2b\x06proto3"
+
+var (
+	file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_rawDescOnce sync.Once
+	file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_rawDescData []byte
+)
+
+func file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_rawDescGZIP() []byte {
+	file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_rawDescOnce.Do(func() {
+		file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_rawDesc), len(file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_rawDesc)))
+	})
+	return file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_rawDescData
+}
+
+var file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_goTypes = []any{
+	(*PackagesRequest)(nil),          // 0: scanoss.api.cryptography.v2.PackagesRequest
+	(*PackageHint)(nil),              // 1: scanoss.api.cryptography.v2.PackageHint
+	(*PackageCryptography)(nil),      // 2: scanoss.api.cryptography.v2.PackageCryptography
+	(*PackagesResponse)(nil),         // 3: scanoss.api.cryptography.v2.PackagesResponse
+	(*cryptographyv2.Algorithm)(nil), // 4: scanoss.api.cryptography.v2.Algorithm
+	(*commonv2.StatusResponse)(nil),  // 5: scanoss.api.common.v2.StatusResponse
+}
+var file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_depIdxs = []int32{
+	4, // 0: scanoss.api.cryptography.v2.PackageCryptography.algorithms:type_name -> scanoss.api.cryptography.v2.Algorithm
+	1, // 1: scanoss.api.cryptography.v2.PackageCryptography.hints:type_name -> scanoss.api.cryptography.v2.PackageHint
+	2, // 2: scanoss.api.cryptography.v2.PackagesResponse.packages:type_name -> scanoss.api.cryptography.v2.PackageCryptography
+	5, // 3: scanoss.api.cryptography.v2.PackagesResponse.status:type_name -> scanoss.api.common.v2.StatusResponse
+	0, // 4: scanoss.api.cryptography.v2.CryptographyPackages.GetPackagesCryptography:input_type -> scanoss.api.cryptography.v2.PackagesRequest
+	3, // 5: scanoss.api.cryptography.v2.CryptographyPackages.GetPackagesCryptography:output_type -> scanoss.api.cryptography.v2.PackagesResponse
+	5, // [5:6] is the sub-list for method output_type
+	4, // [4:5] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_init() }
+func file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_init() {
+	if File_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_rawDesc), len(file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_goTypes,
+		DependencyIndexes: file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_depIdxs,
+		MessageInfos:      file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_msgTypes,
+	}.Build()
+	File_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto = out.File
+	file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_goTypes = nil
+	file_scanoss_api_cryptography_v2_scanoss_cryptography_packages_proto_depIdxs = nil
+}
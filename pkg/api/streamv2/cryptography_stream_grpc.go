@@ -0,0 +1,237 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package streamv2 defines the streaming variants of the Cryptography gRPC service.
+// The service reuses the request/response messages published in the SCANOSS PAPI,
+// so the descriptor is maintained by hand here until the RPCs are added to the PAPI protobuf.
+package streamv2
+
+import (
+	"context"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	pb "github.com/scanoss/papi/api/cryptographyv2"
+	"google.golang.org/grpc"
+)
+
+const (
+	ServiceName = "scanoss.api.cryptography.v2.CryptographyStream"
+
+	GetComponentsAlgorithmsStreamFullMethodName        = "/" + ServiceName + "/GetComponentsAlgorithmsStream"
+	GetComponentsAlgorithmsInRangeStreamFullMethodName = "/" + ServiceName + "/GetComponentsAlgorithmsInRangeStream"
+	GetComponentsVersionsInRangeStreamFullMethodName   = "/" + ServiceName + "/GetComponentsVersionsInRangeStream"
+	GetComponentsHintsInRangeStreamFullMethodName      = "/" + ServiceName + "/GetComponentsHintsInRangeStream"
+	GetComponentsEncryptionHintsStreamFullMethodName   = "/" + ServiceName + "/GetComponentsEncryptionHintsStream"
+	StreamComponentsAlgorithmsFullMethodName           = "/" + ServiceName + "/StreamComponentsAlgorithms"
+	StreamComponentsAlgorithmsInRangeFullMethodName    = "/" + ServiceName + "/StreamComponentsAlgorithmsInRange"
+	StreamComponentsVersionsInRangeFullMethodName      = "/" + ServiceName + "/StreamComponentsVersionsInRange"
+	StreamComponentsHintsInRangeFullMethodName         = "/" + ServiceName + "/StreamComponentsHintsInRange"
+	StreamComponentsEncryptionHintsFullMethodName      = "/" + ServiceName + "/StreamComponentsEncryptionHints"
+)
+
+// CryptographyStreamServer is the server API for the CryptographyStream service.
+//
+// The Get*Stream methods take a single ComponentsRequest and stream back one response per component
+// as soon as it has been resolved. The Stream* methods accept any number of ComponentsRequest chunks
+// and stream back results as each chunk is processed.
+// In both cases the final message carries no component and only the overall status of the request.
+type CryptographyStreamServer interface {
+	GetComponentsAlgorithmsStream(*common.ComponentsRequest, grpc.ServerStreamingServer[pb.ComponentAlgorithmsResponse]) error
+	GetComponentsAlgorithmsInRangeStream(*common.ComponentsRequest, grpc.ServerStreamingServer[pb.ComponentAlgorithmsInRangeResponse]) error
+	GetComponentsVersionsInRangeStream(*common.ComponentsRequest, grpc.ServerStreamingServer[pb.ComponentVersionsInRangeResponse]) error
+	GetComponentsHintsInRangeStream(*common.ComponentsRequest, grpc.ServerStreamingServer[pb.ComponentHintsInRangeResponse]) error
+	GetComponentsEncryptionHintsStream(*common.ComponentsRequest, grpc.ServerStreamingServer[pb.ComponentEncryptionHintsResponse]) error
+	StreamComponentsAlgorithms(grpc.BidiStreamingServer[common.ComponentsRequest, pb.ComponentAlgorithmsResponse]) error
+	StreamComponentsAlgorithmsInRange(grpc.BidiStreamingServer[common.ComponentsRequest, pb.ComponentAlgorithmsInRangeResponse]) error
+	StreamComponentsVersionsInRange(grpc.BidiStreamingServer[common.ComponentsRequest, pb.ComponentVersionsInRangeResponse]) error
+	StreamComponentsHintsInRange(grpc.BidiStreamingServer[common.ComponentsRequest, pb.ComponentHintsInRangeResponse]) error
+	StreamComponentsEncryptionHints(grpc.BidiStreamingServer[common.ComponentsRequest, pb.ComponentEncryptionHintsResponse]) error
+}
+
+// RegisterCryptographyStreamServer registers the streaming service implementation with the given gRPC server.
+func RegisterCryptographyStreamServer(s grpc.ServiceRegistrar, srv CryptographyStreamServer) {
+	s.RegisterService(&CryptographyStreamServiceDesc, srv)
+}
+
+// serverStreamHandler builds a handler for a server streaming method taking a single ComponentsRequest.
+func serverStreamHandler[Res any](call func(CryptographyStreamServer, *common.ComponentsRequest, grpc.ServerStreamingServer[Res]) error) grpc.StreamHandler {
+	return func(srv interface{}, stream grpc.ServerStream) error {
+		m := new(common.ComponentsRequest)
+		if err := stream.RecvMsg(m); err != nil {
+			return err
+		}
+		return call(srv.(CryptographyStreamServer), m, &grpc.GenericServerStream[common.ComponentsRequest, Res]{ServerStream: stream})
+	}
+}
+
+// bidiStreamHandler builds a handler for a bidirectional streaming method.
+func bidiStreamHandler[Res any](call func(CryptographyStreamServer, grpc.BidiStreamingServer[common.ComponentsRequest, Res]) error) grpc.StreamHandler {
+	return func(srv interface{}, stream grpc.ServerStream) error {
+		return call(srv.(CryptographyStreamServer), &grpc.GenericServerStream[common.ComponentsRequest, Res]{ServerStream: stream})
+	}
+}
+
+// CryptographyStreamServiceDesc is the grpc.ServiceDesc for the CryptographyStream service.
+var CryptographyStreamServiceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*CryptographyStreamServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetComponentsAlgorithmsStream",
+			Handler:       serverStreamHandler(CryptographyStreamServer.GetComponentsAlgorithmsStream),
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetComponentsAlgorithmsInRangeStream",
+			Handler:       serverStreamHandler(CryptographyStreamServer.GetComponentsAlgorithmsInRangeStream),
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetComponentsVersionsInRangeStream",
+			Handler:       serverStreamHandler(CryptographyStreamServer.GetComponentsVersionsInRangeStream),
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetComponentsHintsInRangeStream",
+			Handler:       serverStreamHandler(CryptographyStreamServer.GetComponentsHintsInRangeStream),
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetComponentsEncryptionHintsStream",
+			Handler:       serverStreamHandler(CryptographyStreamServer.GetComponentsEncryptionHintsStream),
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamComponentsAlgorithms",
+			Handler:       bidiStreamHandler(CryptographyStreamServer.StreamComponentsAlgorithms),
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "StreamComponentsAlgorithmsInRange",
+			Handler:       bidiStreamHandler(CryptographyStreamServer.StreamComponentsAlgorithmsInRange),
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "StreamComponentsVersionsInRange",
+			Handler:       bidiStreamHandler(CryptographyStreamServer.StreamComponentsVersionsInRange),
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "StreamComponentsHintsInRange",
+			Handler:       bidiStreamHandler(CryptographyStreamServer.StreamComponentsHintsInRange),
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "StreamComponentsEncryptionHints",
+			Handler:       bidiStreamHandler(CryptographyStreamServer.StreamComponentsEncryptionHints),
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "scanoss/api/cryptography/v2/scanoss-cryptography-stream.proto",
+}
+
+// CryptographyStreamClient is the client API for the CryptographyStream service.
+type CryptographyStreamClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewCryptographyStreamClient creates a new client for the CryptographyStream service.
+func NewCryptographyStreamClient(cc grpc.ClientConnInterface) *CryptographyStreamClient {
+	return &CryptographyStreamClient{cc: cc}
+}
+
+// openServerStream opens a server streaming call and sends the single request message.
+func openServerStream[Res any](ctx context.Context, cc grpc.ClientConnInterface, index int, method string,
+	in *common.ComponentsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Res], error) {
+	stream, err := cc.NewStream(ctx, &CryptographyStreamServiceDesc.Streams[index], method, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[common.ComponentsRequest, Res]{ClientStream: stream}
+	if err := x.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// openBidiStream opens a bidirectional streaming call.
+func openBidiStream[Res any](ctx context.Context, cc grpc.ClientConnInterface, index int, method string,
+	opts ...grpc.CallOption) (grpc.BidiStreamingClient[common.ComponentsRequest, Res], error) {
+	stream, err := cc.NewStream(ctx, &CryptographyStreamServiceDesc.Streams[index], method, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &grpc.GenericClientStream[common.ComponentsRequest, Res]{ClientStream: stream}, nil
+}
+
+func (c *CryptographyStreamClient) GetComponentsAlgorithmsStream(ctx context.Context, in *common.ComponentsRequest,
+	opts ...grpc.CallOption) (grpc.ServerStreamingClient[pb.ComponentAlgorithmsResponse], error) {
+	return openServerStream[pb.ComponentAlgorithmsResponse](ctx, c.cc, 0, GetComponentsAlgorithmsStreamFullMethodName, in, opts...)
+}
+
+func (c *CryptographyStreamClient) GetComponentsAlgorithmsInRangeStream(ctx context.Context, in *common.ComponentsRequest,
+	opts ...grpc.CallOption) (grpc.ServerStreamingClient[pb.ComponentAlgorithmsInRangeResponse], error) {
+	return openServerStream[pb.ComponentAlgorithmsInRangeResponse](ctx, c.cc, 1, GetComponentsAlgorithmsInRangeStreamFullMethodName, in, opts...)
+}
+
+func (c *CryptographyStreamClient) GetComponentsVersionsInRangeStream(ctx context.Context, in *common.ComponentsRequest,
+	opts ...grpc.CallOption) (grpc.ServerStreamingClient[pb.ComponentVersionsInRangeResponse], error) {
+	return openServerStream[pb.ComponentVersionsInRangeResponse](ctx, c.cc, 2, GetComponentsVersionsInRangeStreamFullMethodName, in, opts...)
+}
+
+func (c *CryptographyStreamClient) GetComponentsHintsInRangeStream(ctx context.Context, in *common.ComponentsRequest,
+	opts ...grpc.CallOption) (grpc.ServerStreamingClient[pb.ComponentHintsInRangeResponse], error) {
+	return openServerStream[pb.ComponentHintsInRangeResponse](ctx, c.cc, 3, GetComponentsHintsInRangeStreamFullMethodName, in, opts...)
+}
+
+func (c *CryptographyStreamClient) GetComponentsEncryptionHintsStream(ctx context.Context, in *common.ComponentsRequest,
+	opts ...grpc.CallOption) (grpc.ServerStreamingClient[pb.ComponentEncryptionHintsResponse], error) {
+	return openServerStream[pb.ComponentEncryptionHintsResponse](ctx, c.cc, 4, GetComponentsEncryptionHintsStreamFullMethodName, in, opts...)
+}
+
+func (c *CryptographyStreamClient) StreamComponentsAlgorithms(ctx context.Context,
+	opts ...grpc.CallOption) (grpc.BidiStreamingClient[common.ComponentsRequest, pb.ComponentAlgorithmsResponse], error) {
+	return openBidiStream[pb.ComponentAlgorithmsResponse](ctx, c.cc, 5, StreamComponentsAlgorithmsFullMethodName, opts...)
+}
+
+func (c *CryptographyStreamClient) StreamComponentsAlgorithmsInRange(ctx context.Context,
+	opts ...grpc.CallOption) (grpc.BidiStreamingClient[common.ComponentsRequest, pb.ComponentAlgorithmsInRangeResponse], error) {
+	return openBidiStream[pb.ComponentAlgorithmsInRangeResponse](ctx, c.cc, 6, StreamComponentsAlgorithmsInRangeFullMethodName, opts...)
+}
+
+func (c *CryptographyStreamClient) StreamComponentsVersionsInRange(ctx context.Context,
+	opts ...grpc.CallOption) (grpc.BidiStreamingClient[common.ComponentsRequest, pb.ComponentVersionsInRangeResponse], error) {
+	return openBidiStream[pb.ComponentVersionsInRangeResponse](ctx, c.cc, 7, StreamComponentsVersionsInRangeFullMethodName, opts...)
+}
+
+func (c *CryptographyStreamClient) StreamComponentsHintsInRange(ctx context.Context,
+	opts ...grpc.CallOption) (grpc.BidiStreamingClient[common.ComponentsRequest, pb.ComponentHintsInRangeResponse], error) {
+	return openBidiStream[pb.ComponentHintsInRangeResponse](ctx, c.cc, 8, StreamComponentsHintsInRangeFullMethodName, opts...)
+}
+
+func (c *CryptographyStreamClient) StreamComponentsEncryptionHints(ctx context.Context,
+	opts ...grpc.CallOption) (grpc.BidiStreamingClient[common.ComponentsRequest, pb.ComponentEncryptionHintsResponse], error) {
+	return openBidiStream[pb.ComponentEncryptionHintsResponse](ctx, c.cc, 9, StreamComponentsEncryptionHintsFullMethodName, opts...)
+}
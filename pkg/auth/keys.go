@@ -0,0 +1,201 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package auth authenticates the API keys sent by the callers of the service, and authorizes the RPCs each key
+// is allowed to call. Keys are only ever stored as their SHA-256 hash, in a JSON file or the api_keys table.
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+// APIKeyMetadataKey is the gRPC metadata key carrying the API key (Grpc-Metadata-X-Api-Key over REST).
+// An "authorization: Bearer <key>" header (the HTTP Authorization header over REST) is also accepted.
+const APIKeyMetadataKey = "x-api-key"
+
+// keysFile is the layout of the API keys JSON file.
+type keysFile struct {
+	Keys []models.APIKey `json:"keys"`
+}
+
+// KeyStore holds the API keys accepted by the service.
+// The keys can be reloaded from their source while the server is running.
+type KeyStore struct {
+	mu       sync.Mutex // Serializes the reloads, and guards the keys file
+	keysFile string     // The api_keys table is used if empty
+	db       *sqlx.DB
+	keys     atomic.Pointer[map[string]models.APIKey] // Keyed by hash
+}
+
+// NewKeyStore creates a key store loaded from the configured keys file, or the api_keys table if there is none.
+func NewKeyStore(config *myconfig.ServerConfig, db *sqlx.DB) (*KeyStore, error) {
+	k := &KeyStore{keysFile: config.Auth.KeysFile, db: db}
+	if _, err := k.Reload(); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Reload re-reads the API keys from their source and replaces the active keys, returning the number loaded.
+// The current keys are kept if the new ones cannot be loaded.
+func (k *KeyStore) Reload() (int, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.load(k.keysFile)
+}
+
+// ReloadFrom loads the API keys from the given keys file (or the api_keys table if empty), and makes it their source.
+// The current keys (and source) are kept if the new ones cannot be loaded.
+func (k *KeyStore) ReloadFrom(keysFile string) (int, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	n, err := k.load(keysFile)
+	if err == nil {
+		k.keysFile = keysFile
+	}
+	return n, err
+}
+
+// load reads the API keys from the given keys file (or the api_keys table if empty) and replaces the active keys.
+func (k *KeyStore) load(keysFile string) (int, error) {
+	var keys []models.APIKey
+	var err error
+	if len(keysFile) > 0 {
+		keys, err = LoadKeysFile(keysFile)
+	} else {
+		keys, err = models.NewAPIKeysModel(context.Background(), zlog.S, k.db).GetAPIKeys()
+	}
+	if err != nil {
+		zlog.S.Errorf("Failed to load the API keys: %v", err)
+		return 0, err
+	}
+	byHash, err := indexKeys(keys)
+	if err != nil {
+		zlog.S.Errorf("Failed to load the API keys: %v", err)
+		return 0, err
+	}
+	k.keys.Store(&byHash)
+	zlog.S.Infof("Loaded %d API keys", len(byHash))
+	return len(byHash), nil
+}
+
+// Authenticate returns the API key matching the key given, if any.
+func (k *KeyStore) Authenticate(key string) (models.APIKey, bool) {
+	keys := k.keys.Load()
+	if keys == nil || len(key) == 0 {
+		return models.APIKey{}, false
+	}
+	found, ok := (*keys)[HashKey(key)]
+	return found, ok
+}
+
+// LoadKeysFile reads the API keys of a JSON file, i.e. {"keys": [{"id": "team-a", "hash": "<sha256>", "scopes": ["*"]}]}.
+func LoadKeysFile(file string) ([]models.APIKey, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the api keys file: %v", err)
+	}
+	var keys keysFile
+	if err = json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("failed to parse the api keys file %v: %v", file, err)
+	}
+	return keys.Keys, nil
+}
+
+// indexKeys validates the API keys, indexing them by hash.
+func indexKeys(keys []models.APIKey) (map[string]models.APIKey, error) {
+	byHash := make(map[string]models.APIKey, len(keys))
+	ids := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if len(key.ID) == 0 {
+			return nil, errors.New("api key without an id")
+		}
+		if ids[key.ID] {
+			return nil, fmt.Errorf("duplicate api key id: %v", key.ID)
+		}
+		ids[key.ID] = true
+		key.Hash = strings.ToLower(strings.TrimSpace(key.Hash))
+		if decoded, err := hex.DecodeString(key.Hash); err != nil || len(decoded) != sha256.Size {
+			return nil, fmt.Errorf("api key %v does not have a valid sha256 hash", key.ID)
+		}
+		if _, found := byHash[key.Hash]; found {
+			return nil, fmt.Errorf("api key %v has the same hash as another key", key.ID)
+		}
+		if len(key.Scopes) == 0 {
+			return nil, fmt.Errorf("api key %v has no scopes", key.ID)
+		}
+		for _, scope := range key.Scopes {
+			if _, err := path.Match(scope, ""); err != nil {
+				return nil, fmt.Errorf("api key %v has an invalid scope %q: %v", key.ID, scope, err)
+			}
+		}
+		byHash[key.Hash] = key
+	}
+	return byHash, nil
+}
+
+// HashKey returns the hex encoded SHA-256 hash of an API key, as it is stored.
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Allowed reports whether the scopes of an API key allow calling the given gRPC method (i.e. /package.Service/Method).
+// A scope is either "*" (every method), a method name pattern (i.e. "GetComponents*") or a service/method
+// pattern (i.e. "scanoss.api.cryptography.v2.Cryptography/*"), using path.Match syntax.
+func Allowed(key models.APIKey, fullMethod string) bool {
+	name := strings.TrimPrefix(fullMethod, "/")
+	_, method, _ := strings.Cut(name, "/")
+	for _, scope := range key.Scopes {
+		target := method
+		if strings.Contains(scope, "/") {
+			target = name
+		}
+		if matched, _ := path.Match(scope, target); matched || scope == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// keyIDContextKey is the context key of the API key identity.
+type keyIDContextKey struct{}
+
+// NewContext returns a copy of the context carrying the ID of the API key a call was authenticated with.
+func NewContext(ctx context.Context, keyID string) context.Context {
+	return context.WithValue(ctx, keyIDContextKey{}, keyID)
+}
+
+// KeyIDFromContext returns the ID of the API key a call was authenticated with, or an empty string.
+func KeyIDFromContext(ctx context.Context) string {
+	keyID, _ := ctx.Value(keyIDContextKey{}).(string)
+	return keyID
+}
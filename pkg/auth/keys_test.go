@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+func TestAllowed(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes []string
+		method string
+		want   bool
+	}{
+		{name: "all", scopes: []string{"*"}, method: "/scanoss.api.cryptography.v2.Cryptography/Echo", want: true},
+		{name: "method", scopes: []string{"Echo"}, method: "/scanoss.api.cryptography.v2.Cryptography/Echo", want: true},
+		{name: "method pattern", scopes: []string{"*Hints*"}, method: "/scanoss.api.cryptography.v2.Cryptography/GetComponentsHintsInRange", want: true},
+		{name: "method not in scope", scopes: []string{"*Hints*"}, method: "/scanoss.api.cryptography.v2.Cryptography/GetComponentsAlgorithms"},
+		{name: "service", scopes: []string{"scanoss.api.cryptography.v2.CryptographyStream/*"},
+			method: "/scanoss.api.cryptography.v2.CryptographyStream/StreamComponentsAlgorithms", want: true},
+		{name: "other service", scopes: []string{"scanoss.api.cryptography.v2.CryptographyStream/*"},
+			method: "/scanoss.api.cryptography.v2.Cryptography/GetComponentsAlgorithms"},
+		{name: "no scopes", method: "/scanoss.api.cryptography.v2.Cryptography/Echo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Allowed(models.APIKey{ID: "test", Scopes: tt.scopes}, tt.method); got != tt.want {
+				t.Errorf("Allowed(%v, %v) = %v, want %v", tt.scopes, tt.method, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyStoreFile(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	cfg, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	cfg.Auth.KeysFile = filepath.Join(t.TempDir(), "api_keys.json")
+	if _, err = NewKeyStore(cfg, nil); err == nil {
+		t.Errorf("NewKeyStore() expected an error for a missing keys file")
+	}
+	writeKeys := func(content string) {
+		if err := os.WriteFile(cfg.Auth.KeysFile, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write the keys file: %v", err)
+		}
+	}
+	writeKeys(`{"keys": [{"id": "team-a", "hash": "` + HashKey("secret-a") + `", "scopes": ["*"]}]}`)
+	store, err := NewKeyStore(cfg, nil)
+	if err != nil {
+		t.Fatalf("NewKeyStore() error = %v", err)
+	}
+	if key, found := store.Authenticate("secret-a"); !found || key.ID != "team-a" {
+		t.Errorf("Authenticate() = %v, %v", key, found)
+	}
+	for _, key := range []string{"", "secret-b", HashKey("secret-a")} {
+		if _, found := store.Authenticate(key); found {
+			t.Errorf("Authenticate(%q) expected no key", key)
+		}
+	}
+	for _, invalid := range []string{
+		`{"keys": [{"hash": "` + HashKey("x") + `", "scopes": ["*"]}]}`,
+		`{"keys": [{"id": "a", "hash": "1234", "scopes": ["*"]}]}`,
+		`{"keys": [{"id": "a", "hash": "` + HashKey("x") + `"}]}`,
+		`{"keys": [{"id": "a", "hash": "` + HashKey("x") + `", "scopes": ["[a"]}]}`,
+		`{"keys": [{"id": "a", "hash": "` + HashKey("x") + `", "scopes": ["*"]}, {"id": "a", "hash": "` + HashKey("y") + `", "scopes": ["*"]}]}`,
+		`{"keys": [{"id": "a", "hash": "` + HashKey("x") + `", "scopes": ["*"]}, {"id": "b", "hash": "` + HashKey("x") + `", "scopes": ["*"]}]}`,
+		`{"keys": `,
+	} {
+		writeKeys(invalid)
+		if _, err = store.Reload(); err == nil {
+			t.Errorf("Reload() expected an error for %v", invalid)
+		}
+	}
+	if _, found := store.Authenticate("secret-a"); !found {
+		t.Errorf("Reload() failures should keep the current keys")
+	}
+	writeKeys(`{"keys": [{"id": "team-b", "hash": "` + HashKey("secret-b") + `", "scopes": ["Echo"]}]}`)
+	if n, err := store.Reload(); err != nil || n != 1 {
+		t.Fatalf("Reload() = %v, %v", n, err)
+	}
+	if _, found := store.Authenticate("secret-a"); found {
+		t.Errorf("Reload() should replace the current keys")
+	}
+}
+
+func TestKeyStoreDB(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	db.SetMaxOpenConns(1)
+	if err = models.LoadTestAPIKeys(db, context.Background(), nil); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	cfg, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	store, err := NewKeyStore(cfg, db)
+	if err != nil {
+		t.Fatalf("NewKeyStore() error = %v", err)
+	}
+	key, found := store.Authenticate("scanoss-test-key-hints")
+	if !found || key.ID != "team-hints" || !Allowed(key, "/scanoss.api.cryptography.v2.Cryptography/GetComponentsEncryptionHints") {
+		t.Errorf("Authenticate() = %+v, %v", key, found)
+	}
+	if ctx := NewContext(context.Background(), key.ID); KeyIDFromContext(ctx) != "team-hints" || KeyIDFromContext(context.Background()) != "" {
+		t.Errorf("KeyIDFromContext() did not return the key identity")
+	}
+}
+
+func TestLoadKeysFileExample(t *testing.T) {
+	keys, err := LoadKeysFile("../../config/api_keys.json")
+	if err != nil {
+		t.Fatalf("LoadKeysFile() error = %v", err)
+	}
+	if _, err = indexKeys(keys); err != nil || len(keys) != 2 {
+		t.Errorf("LoadKeysFile() = %v, %v", keys, err)
+	}
+}
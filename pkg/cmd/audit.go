@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	gd "github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+const auditUsage = `Usage: scanoss-cryptography-tools audit [options]
+
+Audits the configured KB for data quality issues: orphaned rows, broken crypto library references,
+implausible algorithm strengths, duplicates and versions without a name or semver.
+
+Exits with %d if any issues are found.
+
+Options:
+`
+
+// RunAudit checks the configured KB for data quality issues, printing a report with the counts and samples of each check.
+// The SQL fixing the issues (where there is a safe fix) can be written to a file for review.
+func RunAudit(args []string, stdout, stderr io.Writer) error {
+	var format, fixSQL, jsonConfig, envConfig, dbDriver, dbDsn string
+	var samples int
+	var debug bool
+	fs := flag.NewFlagSet("scanoss-cryptography-tools audit", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&format, "format", "json", "Output format (json or table)")
+	fs.IntVar(&samples, "samples", 5, "Number of samples to report per check")
+	fs.StringVar(&fixSQL, "fix-sql", "", "Write the SQL fixing the issues found to this file")
+	fs.StringVar(&jsonConfig, "json-config", "", "Application JSON config")
+	fs.StringVar(&envConfig, "env-config", "", "Application dot-ENV config")
+	fs.StringVar(&dbDriver, "db-driver", "", "Database driver (sqlite or postgres). Overrides the config")
+	fs.StringVar(&dbDsn, "db-dsn", "", "Database DSN (i.e. SQLite file or Postgres URL). Overrides the config")
+	fs.BoolVar(&debug, "debug", false, "Enable debug")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, auditUsage, ExitViolations)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if format != "json" && format != "table" {
+		return fmt.Errorf("unsupported output format: %v", format)
+	}
+	if err := setupCliLogger(debug); err != nil {
+		return err
+	}
+	defer zlog.SyncZap()
+	cfg, err := loadConfig(jsonConfig, envConfig, debug)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %v", err)
+	}
+	db, err := openKB(cfg, dbDriver, dbDsn)
+	if err != nil {
+		return err
+	}
+	defer gd.CloseDBConnection(db)
+	ctx := context.Background()
+	conn, err := db.Connx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get a database connection: %v", err)
+	}
+	defer gd.CloseSQLConnection(conn)
+	report := models.NewKBAuditModel(ctx, zlog.S, gd.NewDBSelectContext(zlog.S, nil, conn, cfg.Database.Trace), samples).Audit()
+	if len(fixSQL) > 0 {
+		if err = os.WriteFile(fixSQL, []byte(auditFixes(report)), 0o600); err != nil {
+			return fmt.Errorf("failed to write the fix SQL: %v", err)
+		}
+	}
+	if format == "table" {
+		tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+		printAuditReport(tw, report)
+		err = tw.Flush()
+	} else {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(report)
+	}
+	if err != nil {
+		return err
+	}
+	if report.Issues > 0 {
+		return &ExitError{Code: ExitViolations, Err: fmt.Errorf("%d KB issues found", report.Issues)}
+	}
+	return nil
+}
+
+// auditFixes returns a SQL script, run in a single transaction, applying the fixes of the audit findings.
+// Findings without a safe fix are listed as comments, so they can be reviewed by hand.
+func auditFixes(report models.KBAuditReport) string {
+	var sb strings.Builder
+	sb.WriteString("-- SCANOSS Cryptography KB audit fixes. Please review them before applying.\nBEGIN;\n")
+	for _, f := range report.Findings {
+		if f.Count == 0 {
+			continue
+		}
+		if len(f.Fix) == 0 {
+			_, _ = fmt.Fprintf(&sb, "\n-- %v: %d rows in %v (no automatic fix)\n", f.Check, f.Count, f.Table)
+			continue
+		}
+		_, _ = fmt.Fprintf(&sb, "\n-- %v: %d rows in %v\n%v\n", f.Check, f.Count, f.Table, f.Fix)
+	}
+	sb.WriteString("\nCOMMIT;\n")
+	return sb.String()
+}
+
+// printAuditReport prints the number of rows found by each audit check, along with their samples.
+func printAuditReport(w io.Writer, report models.KBAuditReport) {
+	_, _ = fmt.Fprintln(w, "CHECK\tTABLE\tROWS\tSAMPLES")
+	for _, f := range report.Findings {
+		rows := fmt.Sprint(f.Count)
+		if len(f.Error) > 0 {
+			rows = "error"
+		}
+		_, _ = fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", f.Check, f.Table, rows, orNone(strings.Join(f.Samples, ", ")))
+	}
+	_, _ = fmt.Fprintf(w, "\nIssues: %d\n", report.Issues)
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+
+	"scanoss.com/cryptography/pkg/models"
+)
+
+func TestRunAudit(t *testing.T) {
+	dbFile := setupCliDB(t)
+	fixFile := filepath.Join(t.TempDir(), "fixes.sql")
+	var stdout, stderr bytes.Buffer
+	err := RunAudit([]string{"-db-driver", "sqlite", "-db-dsn", dbFile, "-samples", "2", "-fix-sql", fixFile}, &stdout, &stderr)
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != ExitViolations {
+		t.Fatalf("RunAudit() error = %v, expected exit code %v", err, ExitViolations)
+	}
+	var report models.KBAuditReport
+	if err = json.Unmarshal(stdout.Bytes(), &report); err != nil || report.Issues == 0 || len(report.Findings) == 0 {
+		t.Errorf("RunAudit() report = %+v, %v", report, err)
+	}
+	fixes, err := os.ReadFile(fixFile)
+	if err != nil {
+		t.Fatalf("RunAudit() did not write the fix SQL: %v", err)
+	}
+	for _, want := range []string{"BEGIN;", "-- orphan-algorithm: 48 rows in component_crypto\nDELETE FROM component_crypto",
+		"-- missing-semver:", "(no automatic fix)", "COMMIT;"} {
+		if !strings.Contains(string(fixes), want) {
+			t.Errorf("RunAudit() fix SQL missing %q:\n%s", want, fixes)
+		}
+	}
+
+	stdout.Reset()
+	_ = RunAudit([]string{"-db-driver", "sqlite", "-db-dsn", dbFile, "-format", "table"}, &stdout, &stderr)
+	for _, want := range []string{"CHECK", "implausible-strength", "rc4 strength 2048 (2 rows)", "Issues:"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("RunAudit() table missing %q:\n%v", want, stdout.String())
+		}
+	}
+	if err = RunAudit([]string{"-format", "xml"}, &stdout, &stderr); err == nil {
+		t.Errorf("RunAudit() expected an error for an unsupported format")
+	}
+
+	// The fix script runs as written, leaving only the issues without an automatic fix
+	db, err := sqlx.Connect("sqlite", dbFile)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening the KB", err)
+	}
+	_, err = db.Exec(string(fixes))
+	models.CloseDB(db)
+	if err != nil {
+		t.Fatalf("failed to run the fix SQL: %v", err)
+	}
+	stdout.Reset()
+	_ = RunAudit([]string{"-db-driver", "sqlite", "-db-dsn", dbFile}, &stdout, &stderr)
+	var fixed models.KBAuditReport
+	if err = json.Unmarshal(stdout.Bytes(), &fixed); err != nil {
+		t.Fatalf("RunAudit() report error: %v", err)
+	}
+	for _, f := range fixed.Findings {
+		if len(f.Fix) > 0 || len(f.Error) > 0 {
+			t.Errorf("RunAudit() check %v still has issues after the fixes: %+v", f.Check, f)
+		}
+	}
+}
@@ -16,4 +16,530 @@
 
 package cmd
 
-// TODO Add CLI code processing here
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	gd "github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/models"
+	"scanoss.com/cryptography/pkg/usecase"
+)
+
+const cliUsage = `Usage: scanoss-cryptography-cli [options] <command> [options] [purl[@requirement] ...]
+
+Queries the Cryptography KB directly, without running the server, or through a remote Cryptography
+service when -server (gRPC) or -rest (REST gateway) is given.
+
+Commands:
+%s
+Purls can be supplied as arguments, in a file (-input), or on stdin (one per line, a JSON request
+in the same format as the REST API: {"purls": [{"purl": "...", "requirement": "..."}]}, or a CycloneDX/SPDX JSON SBOM).
+The packages command takes package archives (files) or their MD5 hashes instead of purls.
+
+The check command exits with %d if the policy is violated, and %d if the KB lookup fails.
+
+Options:
+`
+
+// Exit codes of the CLI, besides 0 (success) and 1 (invalid usage or unexpected errors).
+const (
+	ExitViolations    = 2 // The check policy was violated
+	ExitLookupFailure = 3 // The KB (or remote service) could not be queried
+)
+
+// ExitError is an error requiring the CLI to exit with a specific code.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// cliOptions holds the command line options of the CLI.
+type cliOptions struct {
+	jsonConfig  string
+	envConfig   string
+	dbDriver    string
+	dbDsn       string
+	input       string
+	requirement string
+	format      string
+	debug       bool
+	version     bool
+	server      string        // gRPC address (host:port) of a remote Cryptography service
+	rest        string        // Base URL of a remote Cryptography REST gateway
+	tls         bool          // Connect to the remote gRPC service over TLS
+	caFile      string        // CA certificate used to verify the remote service
+	authority   string        // Server name/authority expected by the remote service (i.e. TLS.CN)
+	apiKey      string        // API key sent to the remote service
+	batchSize   int           // Maximum number of components sent in each remote request
+	timeout     time.Duration // Timeout of each remote request
+	policy      checkOptions  // Policy thresholds of the check command
+	tags        string        // Comma separated tags to filter the hints by
+}
+
+// cliQuery holds everything a CLI command needs to query the KB.
+type cliQuery struct {
+	ctx    context.Context
+	s      *zap.SugaredLogger
+	db     *sqlx.DB
+	conn   *sqlx.Conn
+	config *myconfig.ServerConfig
+	opts   cliOptions
+}
+
+// cliCommand describes a CLI command, mirroring one of the use cases.
+type cliCommand struct {
+	description string
+	run         func(q cliQuery, components []dtos.ComponentDTO) (any, models.QuerySummary, error)
+	printTable  func(w io.Writer, output any)
+	format      string // Default output format (json if empty)
+	rawInput    bool   // Inputs are taken as given (i.e. files or hashes) instead of purl[@requirement]
+}
+
+// cliResult is implemented by command outputs that determine the exit status of the CLI.
+type cliResult interface {
+	exitError() error
+}
+
+// newCLICommand builds a CLI command from a use case query and a table printer for its output.
+func newCLICommand[T any](description string, run func(q cliQuery, components []dtos.ComponentDTO) (T, models.QuerySummary, error),
+	printTable func(w io.Writer, output T)) cliCommand {
+	return cliCommand{
+		description: description,
+		run: func(q cliQuery, components []dtos.ComponentDTO) (any, models.QuerySummary, error) {
+			return run(q, components)
+		},
+		printTable: func(w io.Writer, output any) { printTable(w, output.(T)) },
+	}
+}
+
+// cliCommands lists the commands supported by the CLI.
+var cliCommands = map[string]cliCommand{
+	"algorithms": newCLICommand("Cryptographic algorithms used by specific component versions",
+		func(q cliQuery, components []dtos.ComponentDTO) (dtos.CryptoOutput, models.QuerySummary, error) {
+			return usecase.NewCrypto(q.ctx, q.s, q.conn, q.config).GetComponentsAlgorithms(components)
+		}, printAlgorithmsTable),
+	"algorithms-in-range": newCLICommand("Cryptographic algorithms used by the component versions inside a range",
+		func(q cliQuery, components []dtos.ComponentDTO) (dtos.CryptoInRangeOutput, models.QuerySummary, error) {
+			return usecase.NewCryptoMajor(q.ctx, q.s, q.db, q.conn, q.config).GetCryptoInRange(components)
+		}, printAlgorithmsInRangeTable),
+	"versions-in-range": newCLICommand("Component versions inside a range with and without cryptography",
+		func(q cliQuery, components []dtos.ComponentDTO) (dtos.VersionsInRangeOutput, models.QuerySummary, error) {
+			return usecase.NewVersionsUsingCrypto(q.ctx, q.s, q.db, q.conn, q.config).GetVersionsInRangeUsingCrypto(components)
+		}, printVersionsInRangeTable),
+	"hints": newCLICommand("Crypto libraries, SDKs and frameworks (hints) detected in specific component versions",
+		func(q cliQuery, components []dtos.ComponentDTO) (dtos.HintsOutput, models.QuerySummary, error) {
+			return usecase.NewECDetection(q.ctx, q.s, q.db, q.conn, q.config).FilterByTags(splitList(q.opts.tags)).GetDetections(components)
+		}, printHintsTable),
+	"hints-in-range": newCLICommand("Crypto libraries, SDKs and frameworks (hints) detected in the component versions inside a range",
+		func(q cliQuery, components []dtos.ComponentDTO) (dtos.ECOutput, models.QuerySummary, error) {
+			return usecase.NewECDetection(q.ctx, q.s, q.db, q.conn, q.config).FilterByTags(splitList(q.opts.tags)).GetDetectionsInRange(components)
+		}, printHintsInRangeTable),
+	"check":    checkCommand(),
+	"packages": packagesCommand(),
+}
+
+// newCLIFlags declares the CLI options on a new flag set.
+func newCLIFlags(opts *cliOptions, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("scanoss-cryptography-cli", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&opts.jsonConfig, "json-config", "", "Application JSON config")
+	fs.StringVar(&opts.envConfig, "env-config", "", "Application dot-ENV config")
+	fs.StringVar(&opts.dbDriver, "db-driver", "", "Database driver (sqlite or postgres). Overrides the config")
+	fs.StringVar(&opts.dbDsn, "db-dsn", "", "Database DSN (i.e. SQLite file or Postgres URL). Overrides the config")
+	fs.StringVar(&opts.input, "input", "", "File containing the purls to query ('-' for stdin)")
+	fs.StringVar(&opts.requirement, "requirement", "", "Default version requirement/range for purls without one")
+	fs.StringVar(&opts.format, "format", "", "Output format (json or table). Defaults to json, or table for check")
+	fs.BoolVar(&opts.debug, "debug", false, "Enable debug")
+	fs.BoolVar(&opts.version, "version", false, "Display current version")
+	fs.StringVar(&opts.server, "server", "", "Query a remote gRPC Cryptography service (host:port) instead of the KB")
+	fs.StringVar(&opts.rest, "rest", "", "Query a remote Cryptography REST gateway (base URL) instead of the KB")
+	fs.BoolVar(&opts.tls, "tls", false, "Use TLS to connect to the remote gRPC service (implied by an https REST URL)")
+	fs.StringVar(&opts.caFile, "ca-file", "", "CA certificate to verify the remote service with. Defaults to the configured TLS certificate")
+	fs.StringVar(&opts.authority, "authority", "", "Server name/authority of the remote service. Defaults to the configured TLS CN")
+	fs.StringVar(&opts.apiKey, "api-key", "", "API key to call the remote service with. Defaults to $"+apiKeyEnv)
+	fs.IntVar(&opts.batchSize, "batch-size", defaultRemoteBatchSize, "Maximum number of purls sent in each remote request")
+	fs.DurationVar(&opts.timeout, "timeout", defaultRemoteTimeout, "Timeout of each remote request")
+	fs.StringVar(&opts.tags, "tags", "", "Comma separated tags to filter the hints by (i.e. fips,tls,pqc)")
+	addCheckFlags(fs, &opts.policy)
+	fs.Usage = func() {
+		names := make([]string, 0, len(cliCommands))
+		for name := range cliCommands {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		var commands strings.Builder
+		for _, name := range names {
+			commands.WriteString(fmt.Sprintf("  %-20s %s\n", name, cliCommands[name].description))
+		}
+		_, _ = fmt.Fprintf(stderr, cliUsage, commands.String(), ExitViolations, ExitLookupFailure)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseInterspersed parses the options found anywhere in the arguments, as the flag package stops at the first
+// positional one, and returns the positional arguments. Arguments after a "--" are all positional.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if consumed := args[:len(args)-len(rest)]; len(consumed) > 0 && consumed[len(consumed)-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// RunCli runs the offline Cryptography CLI with the given arguments.
+// Options can be supplied before or after the command, including among the purls. If no purls are given as arguments or in an
+// input file, they are read from stdin (if supplied).
+func RunCli(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	var opts cliOptions
+	fs := newCLIFlags(&opts, stderr)
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if opts.version {
+		_, _ = fmt.Fprintf(stdout, "Version: %v\n", strings.TrimSpace(version))
+		return nil
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errors.New("no command specified")
+	}
+	name := fs.Arg(0)
+	command, ok := cliCommands[name]
+	if !ok {
+		fs.Usage()
+		return fmt.Errorf("unknown command: %v", name)
+	}
+	purls, err := parseInterspersed(fs, fs.Args()[1:]) // Options are also accepted after the command, and among the purls
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if len(opts.format) == 0 {
+		opts.format = cmp.Or(command.format, "json")
+	}
+	if opts.format != "json" && opts.format != "table" {
+		return fmt.Errorf("unsupported output format: %v", opts.format)
+	}
+	if err := opts.policy.load(); err != nil {
+		return err
+	}
+	components, err := readComponents(purls, opts, command.rawInput, stdin)
+	if err != nil {
+		return err
+	}
+	if err = setupCliLogger(opts.debug); err != nil {
+		return err
+	}
+	defer zlog.SyncZap()
+	cfg, err := loadConfig(opts.jsonConfig, opts.envConfig, opts.debug)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %v", err)
+	}
+	if opts.isRemote() {
+		return runRemote(cfg, opts, name, command, components, stdout, stderr)
+	}
+	output, summary, err := queryKB(cfg, opts, command, components)
+	if err != nil {
+		return &ExitError{Code: ExitLookupFailure, Err: err}
+	}
+	if err = writeOutput(stdout, opts.format, command, output); err != nil {
+		return err
+	}
+	printSummary(stderr, summary)
+	if result, ok := output.(cliResult); ok {
+		return result.exitError()
+	}
+	return nil
+}
+
+// setupCliLogger sends the logs to stderr, so they do not get mixed with the results.
+func setupCliLogger(debug bool) error {
+	level := zapcore.WarnLevel
+	if debug {
+		level = zapcore.DebugLevel
+	}
+	if err := zlog.NewDevLoggerLevel(level, "stderr"); err != nil {
+		return err
+	}
+	zlog.S = zlog.L.Sugar()
+	return nil
+}
+
+// openKB opens the KB configured, optionally overriding the database driver and DSN.
+func openKB(cfg *myconfig.ServerConfig, dbDriver, dbDsn string) (*sqlx.DB, error) {
+	if len(dbDriver) > 0 {
+		cfg.Database.Driver = dbDriver
+	}
+	if len(dbDsn) > 0 {
+		cfg.Database.Dsn = dbDsn
+	}
+	db, err := gd.OpenDBConnection(cfg.Database.Dsn, cfg.Database.Driver, cfg.Database.User, cfg.Database.Passwd,
+		cfg.Database.Host, cfg.Database.Schema, cfg.Database.SslMode)
+	if err != nil {
+		return nil, err
+	}
+	if err = gd.SetDBOptionsAndPing(db); err != nil {
+		gd.CloseDBConnection(db)
+		return nil, err
+	}
+	return db, nil
+}
+
+// queryKB opens the KB configured and runs the command against it.
+func queryKB(cfg *myconfig.ServerConfig, opts cliOptions, command cliCommand, components []dtos.ComponentDTO) (any, models.QuerySummary, error) {
+	db, err := openKB(cfg, opts.dbDriver, opts.dbDsn)
+	if err != nil {
+		return nil, models.QuerySummary{}, err
+	}
+	defer gd.CloseDBConnection(db)
+	ctx := context.Background()
+	conn, err := db.Connx(ctx)
+	if err != nil {
+		return nil, models.QuerySummary{}, fmt.Errorf("failed to get a database connection: %v", err)
+	}
+	defer gd.CloseSQLConnection(conn)
+	return command.run(cliQuery{ctx: ctx, s: zlog.S, db: db, conn: conn, config: cfg, opts: opts}, components)
+}
+
+// readComponents collects the components to query from the arguments, the input file or stdin.
+// Raw inputs are kept as given in the component purl, one per argument or line.
+func readComponents(args []string, opts cliOptions, raw bool, stdin io.Reader) ([]dtos.ComponentDTO, error) {
+	var components []dtos.ComponentDTO
+	for _, arg := range args {
+		if raw {
+			components = append(components, dtos.ComponentDTO{Purl: arg})
+		} else {
+			components = append(components, parseComponent(arg, opts.requirement))
+		}
+	}
+	var input io.Reader
+	switch {
+	case opts.input == "-":
+		input = stdin
+	case len(opts.input) > 0:
+		f, err := os.Open(opts.input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open input file: %v", err)
+		}
+		defer f.Close()
+		input = f
+	case len(components) == 0:
+		input = stdin
+	}
+	if input != nil {
+		parse := parseComponents
+		if raw {
+			parse = parseRawInputs
+		}
+		parsed, err := parse(input, opts.requirement)
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, parsed...)
+	}
+	if len(components) == 0 {
+		return nil, errors.New("no purls (or packages) supplied")
+	}
+	return components, nil
+}
+
+// parseComponents reads a list of components, either as a JSON request, a JSON SBOM or one purl per line.
+// Blank lines and lines starting with '#' are ignored.
+func parseComponents(r io.Reader, requirement string) ([]dtos.ComponentDTO, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read purls: %v", err)
+	}
+	var components []dtos.ComponentDTO
+	if trimmed := bytes.TrimSpace(data); bytes.HasPrefix(trimmed, []byte("{")) {
+		if isSBOM(trimmed) {
+			return parseSBOM(trimmed, requirement)
+		}
+		request, err := dtos.ParseCryptoInput(zap.NewNop().Sugar(), trimmed)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range request.Purls {
+			c := parseComponent(p.Purl, requirement)
+			if len(p.Requirement) > 0 {
+				c.Requirement, c.Version = p.Requirement, p.Requirement
+			}
+			components = append(components, c)
+		}
+		return components, nil
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) > 0 && !strings.HasPrefix(line, "#") {
+			components = append(components, parseComponent(line, requirement))
+		}
+	}
+	return components, scanner.Err()
+}
+
+// parseRawInputs reads one raw input per line, ignoring blank lines and lines starting with '#'.
+func parseRawInputs(r io.Reader, _ string) ([]dtos.ComponentDTO, error) {
+	var components []dtos.ComponentDTO
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) > 0 && !strings.HasPrefix(line, "#") {
+			components = append(components, dtos.ComponentDTO{Purl: line})
+		}
+	}
+	return components, scanner.Err()
+}
+
+// parseComponent converts a purl[@requirement] string into a component, using the default requirement if none is given.
+func parseComponent(purl, requirement string) dtos.ComponentDTO {
+	if p, req, found := cutPurlVersion(purl); found {
+		purl, requirement = p, req
+	}
+	return dtos.ComponentDTO{Purl: purl, Requirement: requirement, Version: requirement}
+}
+
+// cutPurlVersion splits a purl on the '@' separating its version, which follows the name (after the last '/').
+// Any earlier '@' is part of the namespace (i.e. pkg:npm/@angular/core@16.0.0).
+func cutPurlVersion(purl string) (string, string, bool) {
+	if i := strings.LastIndex(purl, "@"); i > strings.LastIndex(purl, "/") {
+		return purl[:i], purl[i+1:], true
+	}
+	return purl, "", false
+}
+
+// writeOutput prints the results of a command in the requested format.
+func writeOutput(w io.Writer, format string, command cliCommand, output any) error {
+	if format == "table" {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		command.printTable(tw, output)
+		return tw.Flush()
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+// printSummary reports any components that could not be fully resolved.
+func printSummary(w io.Writer, summary models.QuerySummary) {
+	report := func(label string, purls []string) {
+		if len(purls) > 0 {
+			_, _ = fmt.Fprintf(w, "%s (%d): %s\n", label, len(purls), strings.Join(purls, ", "))
+		}
+	}
+	report("Failed to parse", summary.PurlsFailedToParse)
+	report("Not found", summary.PurlsNotFound)
+	report("No cryptographic information", summary.PurlsWOInfo)
+	for _, p := range summary.PurlsWOSemver {
+		_, _ = fmt.Fprintf(w, "No semver versions for %s: %s\n", p.Purl, strings.Join(p.Versions, ", "))
+	}
+}
+
+// orNone returns the given value, or a dash if it is empty.
+func orNone(value string) string {
+	if len(value) == 0 {
+		return "-"
+	}
+	return value
+}
+
+func printAlgorithmsTable(w io.Writer, output dtos.CryptoOutput) {
+	_, _ = fmt.Fprintln(w, "PURL\tVERSION\tALGORITHM\tSTRENGTH")
+	for _, item := range output.Cryptography {
+		if len(item.Algorithms) == 0 {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t-\t-\n", item.Purl, orNone(item.Version))
+		}
+		for _, a := range item.Algorithms {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", item.Purl, orNone(item.Version), a.Algorithm, orNone(a.Strength))
+		}
+	}
+}
+
+func printAlgorithmsInRangeTable(w io.Writer, output dtos.CryptoInRangeOutput) {
+	_, _ = fmt.Fprintln(w, "PURL\tVERSIONS\tALGORITHM\tSTRENGTH")
+	for _, item := range output.Cryptography {
+		versions := orNone(strings.Join(item.Versions, ","))
+		if len(item.Algorithms) == 0 {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t-\t-\n", item.Purl, versions)
+		}
+		for _, a := range item.Algorithms {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", item.Purl, versions, a.Algorithm, orNone(a.Strength))
+		}
+	}
+}
+
+func printVersionsInRangeTable(w io.Writer, output dtos.VersionsInRangeOutput) {
+	_, _ = fmt.Fprintln(w, "PURL\tVERSIONS WITH\tVERSIONS WITHOUT")
+	for _, item := range output.Versions {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", item.Purl, orNone(strings.Join(item.VersionsWith, ",")), orNone(strings.Join(item.VersionsWithout, ",")))
+	}
+}
+
+func printHintsTable(w io.Writer, output dtos.HintsOutput) {
+	_, _ = fmt.Fprintln(w, "PURL\tVERSION\tHINT\tNAME\tCATEGORY\tTAGS")
+	for _, item := range output.Hints {
+		if len(item.Detections) == 0 {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t-\t-\t-\t-\n", item.Purl, orNone(item.Version))
+		}
+		for _, d := range item.Detections {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", item.Purl, orNone(item.Version), d.ID, d.Name, orNone(d.Category),
+				orNone(strings.Join(d.Tags, ",")))
+		}
+	}
+}
+
+func printHintsInRangeTable(w io.Writer, output dtos.ECOutput) {
+	_, _ = fmt.Fprintln(w, "PURL\tVERSIONS\tHINT\tNAME\tCATEGORY\tTAGS")
+	for _, item := range output.Hints {
+		versions := orNone(strings.Join(item.Versions, ","))
+		if len(item.Detections) == 0 {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t-\t-\t-\t-\n", item.Purl, versions)
+		}
+		for _, d := range item.Detections {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", item.Purl, versions, d.ID, d.Name, orNone(d.Category),
+				orNone(strings.Join(d.Tags, ",")))
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/models"
+	"scanoss.com/cryptography/pkg/usecase"
+)
+
+// Rules reported by the check command.
+const (
+	ruleDisallowedAlgorithm = "disallowed-algorithm"
+	ruleMinStrength         = "min-strength"
+	ruleForbiddenCategory   = "forbidden-hint-category"
+	ruleMaxNotFound         = "max-not-found"
+)
+
+// anyAlgorithm is the min_strength key applied to the algorithms without a specific minimum.
+const anyAlgorithm = "*"
+
+// checkPolicy holds the thresholds evaluated by the check command.
+type checkPolicy struct {
+	DisallowedAlgorithms    []string       `json:"disallowed_algorithms"`
+	MinStrength             map[string]int `json:"min_strength"` // Minimum strength per algorithm ("*" for any other)
+	ForbiddenHintCategories []string       `json:"forbidden_hint_categories"`
+	MaxNotFoundRatio        *float64       `json:"max_not_found_ratio"` // From 0 to 1, no limit if unset
+}
+
+// checkOptions holds the check command line options, and the policy resolved from them.
+type checkOptions struct {
+	file        string
+	disallowed  string
+	minStrength int
+	forbidden   string
+	maxNotFound float64
+	policy      checkPolicy
+}
+
+// addCheckFlags declares the check command options on the given flag set.
+func addCheckFlags(fs *flag.FlagSet, opts *checkOptions) {
+	fs.StringVar(&opts.file, "policy", "", "JSON policy file used by check")
+	fs.StringVar(&opts.disallowed, "disallow", "", "Comma separated algorithms not allowed by check (i.e. md5,des)")
+	fs.IntVar(&opts.minStrength, "min-strength", 0, "Minimum strength required by check for any algorithm (0 for none)")
+	fs.StringVar(&opts.forbidden, "forbid-categories", "", "Comma separated hint categories not allowed by check")
+	fs.Float64Var(&opts.maxNotFound, "max-not-found", -1, "Maximum ratio (0-1) of purls not found allowed by check (negative for no limit)")
+}
+
+// load resolves the check policy from the policy file (if any), overridden by the command line options.
+func (o *checkOptions) load() error {
+	if len(o.file) > 0 {
+		data, err := os.ReadFile(o.file)
+		if err != nil {
+			return fmt.Errorf("failed to read policy file: %v", err)
+		}
+		if err = json.Unmarshal(data, &o.policy); err != nil {
+			return fmt.Errorf("failed to parse policy file: %v", err)
+		}
+	}
+	o.policy.DisallowedAlgorithms = append(o.policy.DisallowedAlgorithms, splitList(o.disallowed)...)
+	o.policy.ForbiddenHintCategories = append(o.policy.ForbiddenHintCategories, splitList(o.forbidden)...)
+	minStrength := make(map[string]int, len(o.policy.MinStrength)+1)
+	for algorithm, strength := range o.policy.MinStrength {
+		minStrength[strings.ToLower(algorithm)] = strength
+	}
+	if o.minStrength > 0 {
+		minStrength[anyAlgorithm] = o.minStrength
+	}
+	o.policy.MinStrength = minStrength
+	if o.maxNotFound >= 0 {
+		o.policy.MaxNotFoundRatio = &o.maxNotFound
+	}
+	if ratio := o.policy.MaxNotFoundRatio; ratio != nil && (*ratio < 0 || *ratio > 1) {
+		return fmt.Errorf("invalid max not found ratio: %v", *ratio)
+	}
+	return nil
+}
+
+// splitList splits a comma separated list, dropping empty values.
+func splitList(list string) []string {
+	var values []string
+	for _, v := range strings.Split(list, ",") {
+		if v = strings.TrimSpace(v); len(v) > 0 {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// checkViolation is a single breach of the check policy.
+type checkViolation struct {
+	Rule    string `json:"rule"`
+	Purl    string `json:"purl,omitempty"`
+	Version string `json:"version,omitempty"`
+	Detail  string `json:"detail"`
+}
+
+// checkReport is the output of the check command.
+type checkReport struct {
+	Passed        bool             `json:"passed"`
+	Components    int              `json:"components"`
+	NotFound      int              `json:"not_found"`
+	NotFoundRatio float64          `json:"not_found_ratio"`
+	Violations    []checkViolation `json:"violations"`
+}
+
+// exitError fails the CLI with ExitViolations if the policy was violated.
+func (r checkReport) exitError() error {
+	if r.Passed {
+		return nil
+	}
+	return &ExitError{Code: ExitViolations, Err: fmt.Errorf("policy check failed with %d violation(s)", len(r.Violations))}
+}
+
+// checkCommand builds the check command, which reports as a table by default.
+func checkCommand() cliCommand {
+	command := newCLICommand("Checks the cryptography of the components against a policy, for CI gating", runCheck, printCheckReport)
+	command.format = "table"
+	return command
+}
+
+// runCheck looks up the algorithms and hints of the components and evaluates the check policy against them.
+func runCheck(q cliQuery, components []dtos.ComponentDTO) (checkReport, models.QuerySummary, error) {
+	algorithms, summary, err := usecase.NewCrypto(q.ctx, q.s, q.conn, q.config).GetComponentsAlgorithms(components)
+	if err != nil {
+		return checkReport{}, models.QuerySummary{}, fmt.Errorf("failed to get algorithms: %v", err)
+	}
+	hints, _, err := usecase.NewECDetection(q.ctx, q.s, q.db, q.conn, q.config).GetDetections(components)
+	if err != nil {
+		return checkReport{}, models.QuerySummary{}, fmt.Errorf("failed to get hints: %v", err)
+	}
+	return q.opts.policy.policy.evaluate(algorithms, hints, summary), summary, nil
+}
+
+// evaluate checks the algorithms and hints found, and the purls not found (or failed to parse), against the policy.
+func (p checkPolicy) evaluate(algorithms dtos.CryptoOutput, hints dtos.HintsOutput, summary models.QuerySummary) checkReport {
+	report := checkReport{Components: summary.TotalPurls, Violations: []checkViolation{}}
+	for _, item := range algorithms.Cryptography {
+		for _, a := range item.Algorithms {
+			if containsFold(p.DisallowedAlgorithms, a.Algorithm) {
+				report.Violations = append(report.Violations, checkViolation{Rule: ruleDisallowedAlgorithm, Purl: item.Purl,
+					Version: item.Version, Detail: fmt.Sprintf("%s is not allowed", a.Algorithm)})
+			}
+			if minimum := p.minStrength(a.Algorithm); minimum > 0 {
+				if strength, err := strconv.Atoi(a.Strength); err == nil && strength < minimum {
+					report.Violations = append(report.Violations, checkViolation{Rule: ruleMinStrength, Purl: item.Purl,
+						Version: item.Version, Detail: fmt.Sprintf("%s strength %d is below %d", a.Algorithm, strength, minimum)})
+				}
+			}
+		}
+	}
+	for _, item := range hints.Hints {
+		for _, d := range item.Detections {
+			if containsFold(p.ForbiddenHintCategories, d.Category) {
+				report.Violations = append(report.Violations, checkViolation{Rule: ruleForbiddenCategory, Purl: item.Purl,
+					Version: item.Version, Detail: fmt.Sprintf("%s (%s) is not allowed", d.Name, d.Category)})
+			}
+		}
+	}
+	report.NotFound = len(summary.PurlsNotFound) + len(summary.PurlsFailedToParse)
+	if report.Components > 0 {
+		report.NotFoundRatio = float64(report.NotFound) / float64(report.Components)
+	}
+	if p.MaxNotFoundRatio != nil && report.NotFoundRatio > *p.MaxNotFoundRatio {
+		report.Violations = append(report.Violations, checkViolation{Rule: ruleMaxNotFound,
+			Detail: fmt.Sprintf("%d of %d purls not found (%.2f > %.2f)", report.NotFound, report.Components, report.NotFoundRatio, *p.MaxNotFoundRatio)})
+	}
+	report.Passed = len(report.Violations) == 0
+	return report
+}
+
+// minStrength returns the minimum strength required for the algorithm, or zero if there is none.
+func (p checkPolicy) minStrength(algorithm string) int {
+	if minimum, ok := p.MinStrength[strings.ToLower(algorithm)]; ok {
+		return minimum
+	}
+	return p.MinStrength[anyAlgorithm]
+}
+
+// containsFold reports whether the value is in the list, ignoring case.
+func containsFold(list []string, value string) bool {
+	return len(value) > 0 && slices.ContainsFunc(list, func(v string) bool { return strings.EqualFold(v, value) })
+}
+
+func printCheckReport(w io.Writer, report checkReport) {
+	if len(report.Violations) > 0 {
+		_, _ = fmt.Fprintln(w, "RULE\tPURL\tVERSION\tDETAIL")
+		for _, v := range report.Violations {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", v.Rule, orNone(v.Purl), orNone(v.Version), v.Detail)
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+	result := "PASSED"
+	if !report.Passed {
+		result = fmt.Sprintf("FAILED with %d violation(s)", len(report.Violations))
+	}
+	_, _ = fmt.Fprintf(w, "Check %s: %d purls checked, %d not found\n", result, report.Components, report.NotFound)
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+func TestCheckPolicyEvaluate(t *testing.T) {
+	opts := checkOptions{disallowed: "MD5, sha1", minStrength: 112, forbidden: "protocol", maxNotFound: 0.25,
+		policy: checkPolicy{MinStrength: map[string]int{"RSA": 2048}}}
+	if err := opts.load(); err != nil {
+		t.Fatalf("checkOptions.load() unexpected error: %v", err)
+	}
+	algorithms := dtos.CryptoOutput{Cryptography: []dtos.CryptoOutputItem{{Purl: "pkg:npm/a", Version: "1.0.0",
+		Algorithms: []dtos.CryptoUsageItem{{Algorithm: "md5", Strength: "128"}, {Algorithm: "rsa", Strength: "1024"}, {Algorithm: "aes", Strength: "256"}}}}}
+	hints := dtos.HintsOutput{Hints: []dtos.HintsOutputItem{{Purl: "pkg:npm/a", Version: "1.0.0",
+		Detections: []dtos.ECDetectedItem{{Name: "openssl", Category: "library"}, {Name: "tls", Category: "Protocol"}}}}}
+	summary := models.QuerySummary{TotalPurls: 2, PurlsNotFound: []string{"npm/b"}}
+	report := opts.policy.evaluate(algorithms, hints, summary)
+	var rules []string
+	for _, v := range report.Violations {
+		rules = append(rules, v.Rule)
+	}
+	want := []string{ruleDisallowedAlgorithm, ruleMinStrength, ruleForbiddenCategory, ruleMaxNotFound}
+	if !slices.Equal(rules, want) || report.Passed || report.NotFoundRatio != 0.5 {
+		t.Errorf("checkPolicy.evaluate() = %+v, want rules %v", report, want)
+	}
+	var exitErr *ExitError
+	if err := report.exitError(); !errors.As(err, &exitErr) || exitErr.Code != ExitViolations {
+		t.Errorf("checkReport.exitError() = %v, want exit code %d", err, ExitViolations)
+	}
+	if report = (checkPolicy{}).evaluate(algorithms, hints, summary); !report.Passed || report.exitError() != nil {
+		t.Errorf("checkPolicy.evaluate() expected an empty policy to pass: %+v", report)
+	}
+	invalid := checkOptions{maxNotFound: 2}
+	if err := invalid.load(); err == nil {
+		t.Errorf("checkOptions.load() expected an error for an invalid ratio")
+	}
+}
+
+func TestRunCliCheck(t *testing.T) {
+	dbFile := setupCliDB(t)
+	policyFile := filepath.Join(t.TempDir(), "policy.json")
+	err := os.WriteFile(policyFile, []byte(`{"disallowed_algorithms": ["des"], "min_strength": {"crc32": 16}}`), 0o600)
+	if err != nil {
+		t.Fatalf("failed to write policy file: %v", err)
+	}
+	sbom := `{"bomFormat": "CycloneDX", "components": [{"purl": "pkg:github/scanoss/engine", "version": "v5.4.5"}]}`
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantOutput string
+	}{
+		{name: "passed", args: []string{"-max-not-found", "0"}, wantOutput: "Check PASSED"},
+		{name: "violations", args: []string{"-policy", policyFile}, wantCode: ExitViolations, wantOutput: "des is not allowed"},
+		{name: "lookup failure", args: []string{"-db-dsn", filepath.Join(t.TempDir(), "missing", "kb.sqlite")}, wantCode: ExitLookupFailure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			args := append([]string{"-db-driver", "sqlite", "-db-dsn", dbFile, "check"}, tt.args...)
+			err := RunCli(args, strings.NewReader(sbom), &stdout, &stderr)
+			code := 0
+			var exitErr *ExitError
+			if errors.As(err, &exitErr) {
+				code = exitErr.Code
+			} else if err != nil {
+				code = 1
+			}
+			if code != tt.wantCode {
+				t.Fatalf("RunCli() error = %v, want exit code %d (stderr: %s)", err, tt.wantCode, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), tt.wantOutput) {
+				t.Errorf("RunCli() output missing %q:\n%s", tt.wantOutput, stdout.String())
+			}
+		})
+	}
+}
+
+func TestParseSBOM(t *testing.T) {
+	tests := []struct {
+		name string
+		sbom string
+		want []dtos.ComponentDTO
+	}{
+		{
+			name: "cyclonedx",
+			sbom: `{"bomFormat": "CycloneDX", "components": [{"purl": "pkg:npm/a@1.0.0?type=tgz", "components": [{"purl": "pkg:npm/b", "version": "2.0.0"}]}, {"purl": "pkg:npm/@scope/d", "version": "4.0.0"}]}`,
+			want: []dtos.ComponentDTO{{Purl: "pkg:npm/a", Requirement: "1.0.0", Version: "1.0.0"}, {Purl: "pkg:npm/b", Requirement: "2.0.0", Version: "2.0.0"},
+				{Purl: "pkg:npm/@scope/d", Requirement: "4.0.0", Version: "4.0.0"}},
+		},
+		{
+			name: "spdx",
+			sbom: `{"spdxVersion": "SPDX-2.3", "packages": [{"versionInfo": "3.0.0", "externalRefs": [{"referenceType": "purl", "referenceLocator": "pkg:npm/c"}]}, {"name": "no purl"}]}`,
+			want: []dtos.ComponentDTO{{Purl: "pkg:npm/c", Requirement: "3.0.0", Version: "3.0.0"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseComponents(strings.NewReader(tt.sbom), "")
+			if err != nil || !slices.Equal(got, tt.want) {
+				t.Errorf("parseComponents() = %+v, %v, want %+v", got, err, tt.want)
+			}
+		})
+	}
+	if isSBOM([]byte(`{"purls": [{"purl": "pkg:npm/a"}]}`)) {
+		t.Errorf("isSBOM() expected a purls request not to be an SBOM")
+	}
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/models"
+	"scanoss.com/cryptography/pkg/usecase"
+)
+
+// packagesCommand builds the command looking up package archives (or their MD5 hashes) in the KB.
+func packagesCommand() cliCommand {
+	command := newCLICommand("Cryptography of package archives (i.e. vendored tarballs), matched by their MD5 hash", runPackages, printPackagesTable)
+	command.rawInput = true
+	return command
+}
+
+// runPackages hashes the package archives supplied and looks up the cryptography of the matching component versions.
+func runPackages(q cliQuery, components []dtos.ComponentDTO) (dtos.PackagesOutput, models.QuerySummary, error) {
+	hashes := make([]string, 0, len(components))
+	for _, c := range components {
+		hash, err := packageHash(c.Purl)
+		if err != nil {
+			return dtos.PackagesOutput{}, models.QuerySummary{}, err
+		}
+		q.s.Debugf("Package %v has hash %v", c.Purl, hash)
+		hashes = append(hashes, hash)
+	}
+	return usecase.NewPackages(q.ctx, q.s, q.conn, q.config).GetPackagesCryptography(hashes)
+}
+
+// packageHash returns the MD5 of the package archive, unless the input is already a hash.
+// Inputs that are neither a hash nor an existing file are returned as given, to be reported as failed to parse.
+func packageHash(input string) (string, error) {
+	if usecase.IsPackageHash(input) {
+		return input, nil
+	}
+	f, err := os.Open(input)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return input, nil
+		}
+		return "", fmt.Errorf("failed to open package %v: %v", input, err)
+	}
+	defer f.Close()
+	h := md5.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to hash package %v: %v", input, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func printPackagesTable(w io.Writer, output dtos.PackagesOutput) {
+	_, _ = fmt.Fprintln(w, "PACKAGE HASH\tPURL\tVERSION\tALGORITHMS\tHINTS")
+	for _, item := range output.Packages {
+		algorithms := make([]string, 0, len(item.Algorithms))
+		for _, a := range item.Algorithms {
+			algorithms = append(algorithms, fmt.Sprintf("%s (%s)", a.Algorithm, a.Strength))
+		}
+		hints := make([]string, 0, len(item.Hints))
+		for _, h := range item.Hints {
+			hints = append(hints, h.ID)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", item.PackageHash, item.Purl, orNone(item.Version),
+			orNone(strings.Join(algorithms, ", ")), orNone(strings.Join(hints, ", ")))
+	}
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPackageHash(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "package-1.0.tar.gz")
+	if err := os.WriteFile(archive, []byte("hello world\n"), 0o600); err != nil {
+		t.Fatalf("failed to write the test package: %v", err)
+	}
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: archive, want: "6f5902ac237024bdd0c176cb93063dc4"},
+		{input: "2C2AE45C192DF28DCFD1CAAB7E2B12DB", want: "2C2AE45C192DF28DCFD1CAAB7E2B12DB"},
+		{input: filepath.Join(dir, "missing.tgz"), want: filepath.Join(dir, "missing.tgz")},
+	}
+	for _, tt := range tests {
+		got, err := packageHash(tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("packageHash(%q) = %v, %v, want %v", tt.input, got, err, tt.want)
+		}
+	}
+}
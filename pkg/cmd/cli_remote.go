@@ -0,0 +1,424 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	common "github.com/scanoss/papi/api/commonv2"
+	pb "github.com/scanoss/papi/api/cryptographyv2"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"scanoss.com/cryptography/pkg/auth"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/service"
+)
+
+const (
+	defaultRemoteBatchSize = 500
+	defaultRemoteTimeout   = 2 * time.Minute
+	apiKeyEnv              = "CRYPTO_API_KEY" // Environment variable holding the default API key
+)
+
+// isRemote reports whether the CLI should query a remote Cryptography service instead of the KB.
+func (o cliOptions) isRemote() bool {
+	return len(o.server) > 0 || len(o.rest) > 0
+}
+
+// remoteMethod identifies an endpoint of the Cryptography service, both on gRPC and the REST gateway.
+type remoteMethod struct {
+	grpc string // Full gRPC method name
+	rest string // REST gateway path
+}
+
+// remoteClient sends component requests to a remote Cryptography service.
+type remoteClient interface {
+	call(ctx context.Context, method remoteMethod, request *common.ComponentsRequest, response proto.Message) error
+	close() error
+}
+
+// grpcRemoteClient calls the Cryptography gRPC service.
+type grpcRemoteClient struct {
+	conn *grpc.ClientConn
+}
+
+func (c *grpcRemoteClient) call(ctx context.Context, method remoteMethod, request *common.ComponentsRequest, response proto.Message) error {
+	return c.conn.Invoke(ctx, method.grpc, request, response)
+}
+
+func (c *grpcRemoteClient) close() error {
+	return c.conn.Close()
+}
+
+// restRemoteClient calls the Cryptography REST gateway.
+type restRemoteClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+func (c *restRemoteClient) call(ctx context.Context, method remoteMethod, request *common.ComponentsRequest, response proto.Message) error {
+	body, err := protojson.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the request: %v", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+method.rest, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	md, _ := metadata.FromOutgoingContext(ctx)
+	for key, values := range md { // Forwarded by the gateway as gRPC metadata
+		for _, v := range values {
+			req.Header.Add(runtime.MetadataHeaderPrefix+key, v)
+		}
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read the response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request to %v failed with %v: %s", method.rest, resp.Status, bytes.TrimSpace(data))
+	}
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, response)
+}
+
+func (c *restRemoteClient) close() error {
+	c.client.CloseIdleConnections()
+	return nil
+}
+
+// newRemoteTLSConfig builds the TLS settings used to verify the remote service.
+// The system CAs are used if no CA file is given.
+func newRemoteTLSConfig(caFile, authority string) (*tls.Config, error) {
+	config := &tls.Config{ServerName: authority, MinVersion: tls.VersionTLS12}
+	if len(caFile) > 0 {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file: %v", caFile)
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
+
+// newRemoteClient connects to the remote service requested, using the configured TLS certificate and CN by default.
+func newRemoteClient(cfg *myconfig.ServerConfig, opts cliOptions) (remoteClient, error) {
+	caFile, authority := opts.caFile, opts.authority
+	if len(caFile) == 0 {
+		caFile = cfg.TLS.CertFile
+	}
+	if len(authority) == 0 {
+		authority = cfg.TLS.CN
+	}
+	useTLS := opts.tls || strings.HasPrefix(opts.rest, "https://")
+	var tlsConfig *tls.Config
+	if useTLS {
+		var err error
+		if tlsConfig, err = newRemoteTLSConfig(caFile, authority); err != nil {
+			return nil, err
+		}
+	}
+	if len(opts.rest) > 0 {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		return &restRemoteClient{baseURL: strings.TrimSuffix(opts.rest, "/"), client: &http.Client{Transport: transport}}, nil
+	}
+	creds := insecure.NewCredentials()
+	if useTLS {
+		creds = credentials.NewTLS(tlsConfig)
+	}
+	dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if len(authority) > 0 {
+		dialOpts = append(dialOpts, grpc.WithAuthority(authority))
+	}
+	conn, err := grpc.NewClient(opts.server, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %v: %v", opts.server, err)
+	}
+	return &grpcRemoteClient{conn: conn}, nil
+}
+
+// remoteResponse is implemented by all the Cryptography batch (components) responses.
+type remoteResponse interface {
+	proto.Message
+	GetStatus() *common.StatusResponse
+}
+
+// cliRemoteCommand describes how a CLI command is run against a remote Cryptography service.
+type cliRemoteCommand struct {
+	query func(ctx context.Context, client remoteClient, request *common.ComponentsRequest) (any, *common.StatusResponse, error)
+	merge func(a, b any) any
+}
+
+// newCLIRemoteCommand builds a remote CLI command from a service endpoint, a converter of its responses into the
+// output of the matching use case, and a function to merge the outputs of several requests.
+func newCLIRemoteCommand[R remoteResponse, T any](method remoteMethod, newResponse func() R, convert func(R) T, merge func(a, b T) T) cliRemoteCommand {
+	return cliRemoteCommand{
+		query: func(ctx context.Context, client remoteClient, request *common.ComponentsRequest) (any, *common.StatusResponse, error) {
+			response := newResponse()
+			if err := client.call(ctx, method, request, response); err != nil {
+				return nil, nil, err
+			}
+			return convert(response), response.GetStatus(), nil
+		},
+		merge: func(a, b any) any {
+			if a == nil {
+				return b
+			}
+			return merge(a.(T), b.(T))
+		},
+	}
+}
+
+// cliRemoteCommands lists the remote endpoint used by each of the CLI commands.
+var cliRemoteCommands = map[string]cliRemoteCommand{
+	"algorithms": newCLIRemoteCommand(
+		remoteMethod{grpc: pb.Cryptography_GetComponentsAlgorithms_FullMethodName, rest: "/v2/cryptography/algorithms/components"},
+		func() *pb.ComponentsAlgorithmsResponse { return &pb.ComponentsAlgorithmsResponse{} },
+		convertRemoteAlgorithms,
+		func(a, b dtos.CryptoOutput) dtos.CryptoOutput {
+			a.Cryptography = append(a.Cryptography, b.Cryptography...)
+			return a
+		}),
+	"algorithms-in-range": newCLIRemoteCommand(
+		remoteMethod{grpc: pb.Cryptography_GetComponentsAlgorithmsInRange_FullMethodName, rest: "/v2/cryptography/algorithms/range/components"},
+		func() *pb.ComponentsAlgorithmsInRangeResponse { return &pb.ComponentsAlgorithmsInRangeResponse{} },
+		convertRemoteAlgorithmsInRange,
+		func(a, b dtos.CryptoInRangeOutput) dtos.CryptoInRangeOutput {
+			a.Cryptography = append(a.Cryptography, b.Cryptography...)
+			return a
+		}),
+	"versions-in-range": newCLIRemoteCommand(
+		remoteMethod{grpc: pb.Cryptography_GetComponentsVersionsInRange_FullMethodName, rest: "/v2/cryptography/algorithms/versions/range/components"},
+		func() *pb.ComponentsVersionsInRangeResponse { return &pb.ComponentsVersionsInRangeResponse{} },
+		convertRemoteVersionsInRange,
+		func(a, b dtos.VersionsInRangeOutput) dtos.VersionsInRangeOutput {
+			a.Versions = append(a.Versions, b.Versions...)
+			return a
+		}),
+	"hints": newCLIRemoteCommand(
+		remoteMethod{grpc: pb.Cryptography_GetComponentsEncryptionHints_FullMethodName, rest: "/v2/cryptography/hints/components"},
+		func() *pb.ComponentsEncryptionHintsResponse { return &pb.ComponentsEncryptionHintsResponse{} },
+		convertRemoteHints,
+		func(a, b dtos.HintsOutput) dtos.HintsOutput {
+			a.Hints = append(a.Hints, b.Hints...)
+			return a
+		}),
+	"hints-in-range": newCLIRemoteCommand(
+		remoteMethod{grpc: pb.Cryptography_GetComponentsHintsInRange_FullMethodName, rest: "/v2/cryptography/hints/range/components"},
+		func() *pb.ComponentsHintsInRangeResponse { return &pb.ComponentsHintsInRangeResponse{} },
+		convertRemoteHintsInRange,
+		func(a, b dtos.ECOutput) dtos.ECOutput {
+			a.Hints = append(a.Hints, b.Hints...)
+			return a
+		}),
+}
+
+// runRemote queries the remote service in batches of components, printing the merged output and status.
+// An error is returned if the merged status is a failure, after printing any results received.
+func runRemote(cfg *myconfig.ServerConfig, opts cliOptions, name string, command cliCommand, components []dtos.ComponentDTO, stdout, stderr io.Writer) error {
+	remote, ok := cliRemoteCommands[name]
+	if !ok {
+		return fmt.Errorf("command %v is not supported in remote mode", name)
+	}
+	if len(opts.server) > 0 && len(opts.rest) > 0 {
+		return errors.New("only one of -server and -rest can be specified")
+	}
+	if opts.batchSize <= 0 {
+		return fmt.Errorf("invalid batch size: %d", opts.batchSize)
+	}
+	client, err := newRemoteClient(cfg, opts)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := client.close(); err != nil {
+			zlog.S.Warnf("Problem closing the remote connection: %v", err)
+		}
+	}()
+	output, status, err := queryRemote(client, remote, opts, components)
+	if err != nil {
+		return &ExitError{Code: ExitLookupFailure, Err: err}
+	}
+	if err = writeOutput(stdout, opts.format, command, output); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(stderr, "Status: %v - %v\n", status.GetStatus(), status.GetMessage())
+	if status.GetStatus() == common.StatusCode_FAILED {
+		return &ExitError{Code: ExitLookupFailure, Err: fmt.Errorf("remote query failed: %v", status.GetMessage())}
+	}
+	return nil
+}
+
+// queryRemote sends the components in batches, merging the outputs and statuses of all the requests.
+// Batches that fail are reported in the merged status; an error is only returned if no batch succeeded.
+func queryRemote(client remoteClient, remote cliRemoteCommand, opts cliOptions, components []dtos.ComponentDTO) (any, *common.StatusResponse, error) {
+	var output any
+	var statuses []*common.StatusResponse
+	var lastErr error
+	apiKey := cmp.Or(opts.apiKey, os.Getenv(apiKeyEnv))
+	batches := (len(components) + opts.batchSize - 1) / opts.batchSize
+	index := 0
+	for batch := range slices.Chunk(components, opts.batchSize) {
+		index++
+		zlog.S.Debugf("Sending batch %d/%d (%d components)", index, batches, len(batch))
+		ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+		if len(apiKey) > 0 {
+			ctx = metadata.AppendToOutgoingContext(ctx, auth.APIKeyMetadataKey, apiKey)
+		}
+		if tags := splitList(opts.tags); len(tags) > 0 {
+			ctx = metadata.AppendToOutgoingContext(ctx, service.HintTagsMetadataKey, strings.Join(tags, ","))
+		}
+		out, status, err := remote.query(ctx, client, newComponentsRequest(batch))
+		cancel()
+		if err != nil {
+			lastErr = err
+			statuses = append(statuses, &common.StatusResponse{Status: common.StatusCode_FAILED,
+				Message: fmt.Sprintf("batch %d/%d failed: %v", index, batches, err)})
+			continue
+		}
+		output = remote.merge(output, out)
+		statuses = append(statuses, status)
+	}
+	if output == nil {
+		return nil, nil, fmt.Errorf("remote query failed: %v", lastErr)
+	}
+	return output, mergeStatuses(statuses), nil
+}
+
+// newComponentsRequest converts a batch of components into a service request.
+func newComponentsRequest(components []dtos.ComponentDTO) *common.ComponentsRequest {
+	request := &common.ComponentsRequest{Components: make([]*common.ComponentRequest, 0, len(components))}
+	for _, c := range components {
+		request.Components = append(request.Components, &common.ComponentRequest{Purl: c.Purl, Requirement: c.Requirement})
+	}
+	return request
+}
+
+// mergeStatuses combines the statuses of several requests, keeping the most severe status code
+// and the distinct messages reported with it.
+func mergeStatuses(statuses []*common.StatusResponse) *common.StatusResponse {
+	merged := &common.StatusResponse{Status: common.StatusCode_UNSPECIFIED}
+	for _, status := range statuses {
+		if status.GetStatus() > merged.Status { // Status codes are ordered by severity
+			merged.Status = status.GetStatus()
+		}
+	}
+	var messages []string
+	for _, status := range statuses {
+		if status.GetStatus() == merged.Status && len(status.GetMessage()) > 0 && !slices.Contains(messages, status.GetMessage()) {
+			messages = append(messages, status.GetMessage())
+		}
+	}
+	merged.Message = strings.Join(messages, "; ")
+	return merged
+}
+
+// convertRemoteAlgorithms converts an algorithms response into the output of the matching use case.
+func convertRemoteAlgorithms(response *pb.ComponentsAlgorithmsResponse) dtos.CryptoOutput {
+	output := dtos.CryptoOutput{Cryptography: []dtos.CryptoOutputItem{}}
+	for _, c := range response.GetComponents() {
+		output.Cryptography = append(output.Cryptography, dtos.CryptoOutputItem{Purl: c.GetPurl(), Version: c.GetVersion(),
+			Requirement: c.GetRequirement(), Algorithms: convertRemoteAlgorithmList(c.GetAlgorithms())})
+	}
+	return output
+}
+
+// convertRemoteAlgorithmsInRange converts an algorithms in range response into the output of the matching use case.
+func convertRemoteAlgorithmsInRange(response *pb.ComponentsAlgorithmsInRangeResponse) dtos.CryptoInRangeOutput {
+	output := dtos.CryptoInRangeOutput{Cryptography: []dtos.CryptoInRangeOutputItem{}}
+	for _, c := range response.GetComponents() {
+		output.Cryptography = append(output.Cryptography, dtos.CryptoInRangeOutputItem{Purl: c.GetPurl(),
+			Versions: c.GetVersions(), Algorithms: convertRemoteAlgorithmList(c.GetAlgorithms())})
+	}
+	return output
+}
+
+// convertRemoteVersionsInRange converts a versions in range response into the output of the matching use case.
+func convertRemoteVersionsInRange(response *pb.ComponentsVersionsInRangeResponse) dtos.VersionsInRangeOutput {
+	output := dtos.VersionsInRangeOutput{Versions: []dtos.VersionsInRangeUsingCryptoItem{}}
+	for _, c := range response.GetComponents() {
+		output.Versions = append(output.Versions, dtos.VersionsInRangeUsingCryptoItem{Purl: c.GetPurl(),
+			VersionsWith: c.GetVersionsWith(), VersionsWithout: c.GetVersionsWithout()})
+	}
+	return output
+}
+
+// convertRemoteHints converts an encryption hints response into the output of the matching use case.
+func convertRemoteHints(response *pb.ComponentsEncryptionHintsResponse) dtos.HintsOutput {
+	output := dtos.HintsOutput{Hints: []dtos.HintsOutputItem{}}
+	for _, c := range response.GetComponents() {
+		output.Hints = append(output.Hints, dtos.HintsOutputItem{Purl: c.GetPurl(), Version: c.GetVersion(),
+			Requirement: c.GetRequirement(), Detections: convertRemoteHintList(c.GetHints())})
+	}
+	return output
+}
+
+// convertRemoteHintsInRange converts a hints in range response into the output of the matching use case.
+func convertRemoteHintsInRange(response *pb.ComponentsHintsInRangeResponse) dtos.ECOutput {
+	output := dtos.ECOutput{Hints: []dtos.ECOutputItem{}}
+	for _, c := range response.GetComponents() {
+		output.Hints = append(output.Hints, dtos.ECOutputItem{Purl: c.GetPurl(), Versions: c.GetVersions(),
+			Detections: convertRemoteHintList(c.GetHints())})
+	}
+	return output
+}
+
+func convertRemoteAlgorithmList(algorithms []*pb.Algorithm) []dtos.CryptoUsageItem {
+	items := make([]dtos.CryptoUsageItem, 0, len(algorithms))
+	for _, a := range algorithms {
+		items = append(items, dtos.CryptoUsageItem{Algorithm: a.GetAlgorithm(), Strength: a.GetStrength()})
+	}
+	return items
+}
+
+func convertRemoteHintList(hints []*pb.Hint) []dtos.ECDetectedItem {
+	items := make([]dtos.ECDetectedItem, 0, len(hints))
+	for _, h := range hints {
+		items = append(items, dtos.ECDetectedItem{ID: h.GetId(), Name: h.GetName(), Description: h.GetDescription(),
+			URL: h.GetUrl(), Category: h.GetCategory(), Purl: h.GetPurl()})
+	}
+	return items
+}
@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/jmoiron/sqlx"
+	common "github.com/scanoss/papi/api/commonv2"
+	pb "github.com/scanoss/papi/api/cryptographyv2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"scanoss.com/cryptography/pkg/auth"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/models"
+	"scanoss.com/cryptography/pkg/service"
+)
+
+// setupRemoteService creates a Cryptography service backed by the test KB.
+func setupRemoteService(t *testing.T) pb.CryptographyServer {
+	t.Helper()
+	db, err := sqlx.Connect("sqlite", setupCliDB(t))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database", err)
+	}
+	t.Cleanup(func() { models.CloseDB(db) })
+	cfg, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	return service.NewCryptographyServer(db, cfg)
+}
+
+func TestRunCliRemote(t *testing.T) {
+	server := setupRemoteService(t)
+	grpcServer := grpc.NewServer()
+	pb.RegisterCryptographyServer(grpcServer, server)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	go func() { _ = grpcServer.Serve(listener) }()
+	defer grpcServer.Stop()
+	mux := runtime.NewServeMux()
+	if err = pb.RegisterCryptographyHandlerServer(context.Background(), mux, server); err != nil {
+		t.Fatalf("failed to register the REST gateway: %v", err)
+	}
+	restServer := httptest.NewServer(mux)
+	defer restServer.Close()
+
+	for _, remote := range [][]string{{"-server", listener.Addr().String()}, {"-rest", restServer.URL}} {
+		t.Run(remote[0], func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			args := append(remote, "-batch-size", "1", "algorithms", "pkg:github/scanoss/engine@v5.4.5",
+				"pkg:github/scanoss/engines@v1.0.0", "pkg:github/scanoss/engine@v5.4.5")
+			if err := RunCli(args, nil, &stdout, &stderr); err != nil {
+				t.Fatalf("RunCli() unexpected error: %v (stderr: %s)", err, stderr.String())
+			}
+			var output dtos.CryptoOutput
+			if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+				t.Fatalf("RunCli() output is not valid JSON: %v", err)
+			}
+			if len(output.Cryptography) != 2 || output.Cryptography[1].Purl != "pkg:github/scanoss/engine" || len(output.Cryptography[1].Algorithms) == 0 {
+				t.Errorf("RunCli() unexpected merged output: %+v", output)
+			}
+			if !strings.Contains(stderr.String(), "Status: SUCCEEDED_WITH_WARNINGS") {
+				t.Errorf("RunCli() expected a merged warning status, got: %s", stderr.String())
+			}
+			stdout.Reset()
+			args = append(remote, "-tags", "fips", "hints", "pkg:github/pineappleea/pineapple-src@5.4.7")
+			if err := RunCli(args, nil, &stdout, &stderr); err != nil {
+				t.Fatalf("RunCli() unexpected error: %v (stderr: %s)", err, stderr.String())
+			}
+			var hints dtos.HintsOutput
+			if err := json.Unmarshal(stdout.Bytes(), &hints); err != nil {
+				t.Fatalf("RunCli() output is not valid JSON: %v", err)
+			}
+			if len(hints.Hints) != 1 || len(hints.Hints[0].Detections) != 1 || hints.Hints[0].Detections[0].ID != "library/boringssl" {
+				t.Errorf("RunCli() expected the hints to be filtered by tag, got %+v", hints)
+			}
+		})
+	}
+	var stderr bytes.Buffer
+	err = RunCli([]string{"-server", "127.0.0.1:1", "-timeout", "1s", "hints", "pkg:github/scanoss/engine"}, nil, &bytes.Buffer{}, &stderr)
+	if err == nil {
+		t.Errorf("RunCli() expected an error for an unreachable server")
+	}
+}
+
+func TestRunCliRemoteAPIKey(t *testing.T) {
+	var received []string
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		received = append(received, md.Get(auth.APIKeyMetadataKey)...)
+		return handler(ctx, req)
+	}))
+	pb.RegisterCryptographyServer(grpcServer, setupRemoteService(t))
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	go func() { _ = grpcServer.Serve(listener) }()
+	defer grpcServer.Stop()
+
+	t.Setenv(apiKeyEnv, "env-key")
+	for _, args := range [][]string{{}, {"-api-key", "flag-key"}} {
+		args = append(args, "-server", listener.Addr().String(), "algorithms", "pkg:github/scanoss/engine@v5.4.5")
+		if err = RunCli(args, nil, &bytes.Buffer{}, &bytes.Buffer{}); err != nil {
+			t.Fatalf("RunCli() unexpected error: %v", err)
+		}
+	}
+	if !slices.Equal(received, []string{"env-key", "flag-key"}) {
+		t.Errorf("RunCli() sent api keys %v, want the environment key, then the flag key", received)
+	}
+}
+
+func TestMergeStatuses(t *testing.T) {
+	merged := mergeStatuses([]*common.StatusResponse{
+		{Status: common.StatusCode_SUCCESS, Message: "Success"},
+		{Status: common.StatusCode_SUCCEEDED_WITH_WARNINGS, Message: "not found"},
+		{Status: common.StatusCode_SUCCEEDED_WITH_WARNINGS, Message: "not found"},
+		{Status: common.StatusCode_SUCCEEDED_WITH_WARNINGS, Message: "no info"},
+	})
+	if merged.Status != common.StatusCode_SUCCEEDED_WITH_WARNINGS || merged.Message != "not found; no info" {
+		t.Errorf("mergeStatuses() = %v", merged)
+	}
+	merged = mergeStatuses([]*common.StatusResponse{{Status: common.StatusCode_SUCCESS, Message: "Success"}, {Status: common.StatusCode_FAILED, Message: "failed"}})
+	if merged.Status != common.StatusCode_FAILED || merged.Message != "failed" {
+		t.Errorf("mergeStatuses() = %v", merged)
+	}
+}
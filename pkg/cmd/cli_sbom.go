@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"scanoss.com/cryptography/pkg/dtos"
+)
+
+// sbomDocument holds the fields used to extract purls from CycloneDX and SPDX JSON SBOMs.
+type sbomDocument struct {
+	BomFormat   string         `json:"bomFormat"`   // CycloneDX
+	Components  []cdxComponent `json:"components"`  // CycloneDX
+	SpdxVersion string         `json:"spdxVersion"` // SPDX
+	Packages    []spdxPackage  `json:"packages"`    // SPDX
+}
+
+type cdxComponent struct {
+	Purl       string         `json:"purl"`
+	Version    string         `json:"version"`
+	Components []cdxComponent `json:"components"`
+}
+
+type spdxPackage struct {
+	VersionInfo  string `json:"versionInfo"`
+	ExternalRefs []struct {
+		ReferenceType    string `json:"referenceType"`
+		ReferenceLocator string `json:"referenceLocator"`
+	} `json:"externalRefs"`
+}
+
+// isSBOM reports whether the JSON data is a CycloneDX or SPDX document.
+func isSBOM(data []byte) bool {
+	var doc struct {
+		BomFormat   string `json:"bomFormat"`
+		SpdxVersion string `json:"spdxVersion"`
+	}
+	return json.Unmarshal(data, &doc) == nil && (len(doc.BomFormat) > 0 || len(doc.SpdxVersion) > 0)
+}
+
+// parseSBOM extracts the components (with purls) listed in a CycloneDX or SPDX JSON SBOM.
+// The component version is used as requirement if the purl does not have one.
+func parseSBOM(data []byte, requirement string) ([]dtos.ComponentDTO, error) {
+	var doc sbomDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse SBOM: %v", err)
+	}
+	var components []dtos.ComponentDTO
+	add := func(purl, version string) {
+		purl, _, _ = strings.Cut(purl, "?") // Qualifiers and subpaths are not used by the KB
+		purl, _, _ = strings.Cut(purl, "#")
+		if len(purl) == 0 {
+			return
+		}
+		if _, _, found := cutPurlVersion(purl); !found && len(version) > 0 {
+			purl += "@" + version
+		}
+		components = append(components, parseComponent(purl, requirement))
+	}
+	var addCdx func(list []cdxComponent)
+	addCdx = func(list []cdxComponent) {
+		for _, c := range list {
+			add(c.Purl, c.Version)
+			addCdx(c.Components)
+		}
+	}
+	addCdx(doc.Components)
+	for _, p := range doc.Packages {
+		for _, ref := range p.ExternalRefs {
+			if strings.EqualFold(ref.ReferenceType, "purl") {
+				add(ref.ReferenceLocator, p.VersionInfo)
+			}
+		}
+	}
+	return components, nil
+}
@@ -0,0 +1,201 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "modernc.org/sqlite"
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+// setupCliDB creates a SQLite KB file loaded with the test data, returning its path.
+func setupCliDB(t *testing.T) string {
+	t.Helper()
+	dbFile := filepath.Join(t.TempDir(), "kb.sqlite")
+	db, err := sqlx.Connect("sqlite", dbFile)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database", err)
+	}
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, context.Background(), nil); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	return dbFile
+}
+
+func TestRunCli(t *testing.T) {
+	dbFile := setupCliDB(t)
+	dbArgs := []string{"-db-driver", "sqlite", "-db-dsn", dbFile}
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		wantErr    bool
+		wantOutput []string
+		wantStderr []string
+	}{
+		{
+			name:       "algorithms json",
+			args:       append(slices.Clone(dbArgs), "algorithms", "pkg:github/scanoss/engine@v5.4.5"),
+			wantOutput: []string{`"purl": "pkg:github/scanoss/engine"`, `"algorithms"`},
+		},
+		{
+			name:       "options after the command",
+			args:       append([]string{"algorithms-in-range"}, append(slices.Clone(dbArgs), "-format", "table", "-requirement", ">=v5.0.0", "pkg:github/scanoss/engine")...),
+			wantOutput: []string{"PURL", "ALGORITHM", "pkg:github/scanoss/engine"},
+		},
+		{
+			name:       "options after the purls",
+			args:       append(slices.Clone(dbArgs), "algorithms", "pkg:github/scanoss/engine@v5.4.5", "-format", "table"),
+			wantOutput: []string{"PURL", "ALGORITHM", "pkg:github/scanoss/engine"},
+		},
+		{
+			name:    "unknown option after the purls",
+			args:    append(slices.Clone(dbArgs), "algorithms", "pkg:github/scanoss/engine@v5.4.5", "-unknown"),
+			wantErr: true,
+		},
+		{
+			name:       "purls from stdin",
+			args:       append(slices.Clone(dbArgs), "hints-in-range", "-format", "table"),
+			stdin:      "# comment\npkg:github/scanoss/engine@>=v5.0.0\n\npkg:github/scanoss/engines@>=v5.0.0\n",
+			wantOutput: []string{"HINT", "pkg:github/scanoss/engine"},
+			wantStderr: []string{"Not found (1): pkg:github/scanoss/engines"},
+		},
+		{
+			name:       "json request from stdin",
+			args:       append(slices.Clone(dbArgs), "versions-in-range", "-input", "-"),
+			stdin:      `{"purls": [{"purl": "pkg:github/scanoss/engine", "requirement": ">=v5.0.0"}]}`,
+			wantOutput: []string{`"versions_with"`},
+		},
+		{
+			name:       "hints filtered by tag",
+			args:       append(slices.Clone(dbArgs), "hints", "-format", "table", "-tags", "fips", "pkg:github/pineappleea/pineapple-src@5.4.7"),
+			wantOutput: []string{"TAGS", "library/boringssl", "fips,tls"},
+		},
+		{
+			name:       "packages by hash",
+			args:       append(slices.Clone(dbArgs), "packages", "-format", "table", "2C2AE45C192DF28DCFD1CAAB7E2B12DB"),
+			wantOutput: []string{"PACKAGE HASH", "2c2ae45c192df28dcfd1caab7e2b12db", "pkg:github/scanoss/engine"},
+		},
+		{
+			name:       "packages from stdin",
+			args:       append(slices.Clone(dbArgs), "packages"),
+			stdin:      "2c2ae45c192df28dcfd1caab7e2b12db\n00000000000000000000000000000000\nmissing@1.0.tgz\n",
+			wantOutput: []string{`"package_hash": "2c2ae45c192df28dcfd1caab7e2b12db"`},
+			wantStderr: []string{"Failed to parse (1): missing@1.0.tgz", "Not found (1): 00000000000000000000000000000000"},
+		},
+		{
+			name:    "unknown command",
+			args:    append(slices.Clone(dbArgs), "unknown", "pkg:github/scanoss/engine"),
+			wantErr: true,
+		},
+		{
+			name:    "no purls",
+			args:    append(slices.Clone(dbArgs), "hints"),
+			wantErr: true,
+		},
+		{
+			name:    "bad format",
+			args:    append(slices.Clone(dbArgs), "hints", "-format", "xml", "pkg:github/scanoss/engine"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			err := RunCli(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RunCli() error = %v, wantErr %v (stderr: %s)", err, tt.wantErr, stderr.String())
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("RunCli() output missing %q:\n%s", want, stdout.String())
+				}
+			}
+			for _, want := range tt.wantStderr {
+				if !strings.Contains(stderr.String(), want) {
+					t.Errorf("RunCli() stderr missing %q:\n%s", want, stderr.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRunCliJSONOutput(t *testing.T) {
+	dbFile := setupCliDB(t)
+	var stdout, stderr bytes.Buffer
+	err := RunCli([]string{"-db-driver", "sqlite", "-db-dsn", dbFile, "hints", "pkg:github/scanoss/engine@v5.4.5"}, nil, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("RunCli() unexpected error: %v", err)
+	}
+	var output dtos.HintsOutput
+	if err = json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		t.Fatalf("RunCli() output is not valid JSON: %v", err)
+	}
+	if len(output.Hints) != 1 || output.Hints[0].Purl != "pkg:github/scanoss/engine" {
+		t.Errorf("RunCli() unexpected output: %+v", output)
+	}
+}
+
+func TestParseComponent(t *testing.T) {
+	tests := []struct {
+		purl        string
+		requirement string
+		want        dtos.ComponentDTO
+	}{
+		{purl: "pkg:npm/lodash", want: dtos.ComponentDTO{Purl: "pkg:npm/lodash"}},
+		{purl: "pkg:npm/lodash", requirement: ">=4.0.0", want: dtos.ComponentDTO{Purl: "pkg:npm/lodash", Requirement: ">=4.0.0", Version: ">=4.0.0"}},
+		{purl: "pkg:npm/lodash@4.17.21", requirement: ">=4.0.0", want: dtos.ComponentDTO{Purl: "pkg:npm/lodash", Requirement: "4.17.21", Version: "4.17.21"}},
+		{purl: "pkg:npm/@angular/core@16.0.0", want: dtos.ComponentDTO{Purl: "pkg:npm/@angular/core", Requirement: "16.0.0", Version: "16.0.0"}},
+		{purl: "pkg:npm/@angular/core", requirement: ">=16.0.0", want: dtos.ComponentDTO{Purl: "pkg:npm/@angular/core", Requirement: ">=16.0.0", Version: ">=16.0.0"}},
+	}
+	for _, tt := range tests {
+		if got := parseComponent(tt.purl, tt.requirement); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseComponent(%q, %q) = %+v, want %+v", tt.purl, tt.requirement, got, tt.want)
+		}
+	}
+}
+
+func TestParseInterspersed(t *testing.T) {
+	tests := []struct {
+		args       []string
+		wantFormat string
+		want       []string
+	}{
+		{args: []string{"pkg:npm/a", "-format", "table", "pkg:npm/b"}, wantFormat: "table", want: []string{"pkg:npm/a", "pkg:npm/b"}},
+		{args: []string{"-format", "table", "pkg:npm/a"}, wantFormat: "table", want: []string{"pkg:npm/a"}},
+		{args: []string{"pkg:npm/a", "--", "-format", "table"}, want: []string{"pkg:npm/a", "-format", "table"}},
+		{args: []string{"pkg:npm/a", "-", "pkg:npm/b"}, want: []string{"pkg:npm/a", "-", "pkg:npm/b"}},
+	}
+	for _, tt := range tests {
+		var opts cliOptions
+		got, err := parseInterspersed(newCLIFlags(&opts, &bytes.Buffer{}), tt.args)
+		if err != nil || !slices.Equal(got, tt.want) || opts.format != tt.wantFormat {
+			t.Errorf("parseInterspersed(%q) = %q, format %q, %v, want %q, format %q", tt.args, got, opts.format, err, tt.want, tt.wantFormat)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	gd "github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/models"
+	"scanoss.com/cryptography/pkg/usecase"
+)
+
+const diffUsage = `Usage: scanoss-cryptography-tools diff [options] -old-dsn <kb> -new-dsn <kb> [purl...]
+
+Compares two KBs (or KB exports) and reports, per purl and version, the versions mined or removed,
+the algorithms and hints added or removed, and the versions that lost all their data.
+
+Purls are taken as arguments or from a file (-input), one per line, or as a CycloneDX or SPDX JSON SBOM.
+If none are given, all the purls of both KBs are compared.
+
+Options:
+`
+
+// diffOptions holds the options of the KB diff tool.
+type diffOptions struct {
+	input      string
+	format     string
+	oldDriver  string
+	oldDsn     string
+	newDriver  string
+	newDsn     string
+	jsonConfig string
+	envConfig  string
+	debug      bool
+}
+
+// RunDiff compares the algorithms and hints of a list of purls in two KBs, printing the changes as JSON or Markdown.
+func RunDiff(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	var opts diffOptions
+	fs := flag.NewFlagSet("scanoss-cryptography-tools diff", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&opts.input, "input", "", "File with the purls (or SBOM) to compare ('-' for stdin)")
+	fs.StringVar(&opts.format, "format", "json", "Output format (json or markdown)")
+	fs.StringVar(&opts.oldDriver, "old-driver", "", "Database driver of the old KB (sqlite or postgres). Overrides the config")
+	fs.StringVar(&opts.oldDsn, "old-dsn", "", "Database DSN of the old KB (i.e. SQLite file or Postgres URL)")
+	fs.StringVar(&opts.newDriver, "new-driver", "", "Database driver of the new KB (sqlite or postgres). Defaults to the old KB driver")
+	fs.StringVar(&opts.newDsn, "new-dsn", "", "Database DSN of the new KB (i.e. SQLite file or Postgres URL)")
+	fs.StringVar(&opts.jsonConfig, "json-config", "", "Application JSON config")
+	fs.StringVar(&opts.envConfig, "env-config", "", "Application dot-ENV config")
+	fs.BoolVar(&opts.debug, "debug", false, "Enable debug")
+	fs.Usage = func() {
+		_, _ = fmt.Fprint(stderr, diffUsage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if len(opts.oldDsn) == 0 || len(opts.newDsn) == 0 {
+		fs.Usage()
+		return errors.New("both the old and new KBs must be specified (-old-dsn and -new-dsn)")
+	}
+	opts.newDriver = cmp.Or(opts.newDriver, opts.oldDriver)
+	if opts.format != "json" && opts.format != "markdown" {
+		return fmt.Errorf("unsupported output format: %v", opts.format)
+	}
+	var purls []models.PurlNameType
+	if fs.NArg() > 0 || len(opts.input) > 0 {
+		components, err := readComponents(fs.Args(), cliOptions{input: opts.input}, false, stdin)
+		if err != nil {
+			return err
+		}
+		if purls, err = exportPurls(components); err != nil {
+			return err
+		}
+	}
+	if err := setupCliLogger(opts.debug); err != nil {
+		return err
+	}
+	defer zlog.SyncZap()
+	cfg, err := loadConfig(opts.jsonConfig, opts.envConfig, opts.debug)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %v", err)
+	}
+	oldKB, oldPurls, err := loadKBSnapshot(*cfg, opts.oldDriver, opts.oldDsn, purls)
+	if err != nil {
+		return fmt.Errorf("failed to read the old KB: %v", err)
+	}
+	newKB, newPurls, err := loadKBSnapshot(*cfg, opts.newDriver, opts.newDsn, purls)
+	if err != nil {
+		return fmt.Errorf("failed to read the new KB: %v", err)
+	}
+	if len(purls) == 0 {
+		purls = append(oldPurls, newPurls...)
+	}
+	output := usecase.DiffKBSnapshots(purls, oldKB, newKB)
+	if opts.format == "markdown" {
+		printDiffMarkdown(stdout, output)
+		return nil
+	}
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+// loadKBSnapshot opens a KB and reads the snapshot of the given purls (or all of them, if none are given),
+// returning the purls read. The config is a copy, so the driver and DSN of each KB can be overridden.
+func loadKBSnapshot(cfg myconfig.ServerConfig, dbDriver, dbDsn string, purls []models.PurlNameType) (usecase.KBSnapshot, []models.PurlNameType, error) {
+	db, err := openKB(&cfg, dbDriver, dbDsn)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer gd.CloseDBConnection(db)
+	ctx := context.Background()
+	conn, err := db.Connx(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get a database connection: %v", err)
+	}
+	defer gd.CloseSQLConnection(conn)
+	snapshotUc := usecase.NewKBSnapshot(ctx, zlog.S, conn, &cfg)
+	if len(purls) == 0 {
+		if purls, err = snapshotUc.GetPurlNames(); err != nil {
+			return nil, nil, err
+		}
+		if len(purls) == 0 {
+			return usecase.KBSnapshot{}, purls, nil
+		}
+	}
+	snapshot, err := snapshotUc.GetSnapshot(purls)
+	return snapshot, purls, err
+}
+
+// printDiffMarkdown prints the KB changes as a Markdown report, with a table of the changed versions of each purl.
+func printDiffMarkdown(w io.Writer, output dtos.KBDiffOutput) {
+	summary := output.Summary
+	_, _ = fmt.Fprintf(w, "# KB diff\n\n%d purls compared, %d changed: %d new versions, %d removed versions, "+
+		"%d changed versions (%d lost all data).\n", summary.Purls, summary.ChangedPurls, summary.NewVersions,
+		summary.RemovedVersions, summary.ChangedVersions, summary.LostData)
+	for _, p := range output.Purls {
+		_, _ = fmt.Fprintf(w, "\n## %v\n\n", p.Purl)
+		if len(p.NewVersions) > 0 {
+			_, _ = fmt.Fprintf(w, "- New versions: %v\n", strings.Join(p.NewVersions, ", "))
+		}
+		if len(p.RemovedVersions) > 0 {
+			_, _ = fmt.Fprintf(w, "- Removed versions: %v\n", strings.Join(p.RemovedVersions, ", "))
+		}
+		if len(p.Versions) == 0 {
+			continue
+		}
+		if len(p.NewVersions) > 0 || len(p.RemovedVersions) > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+		_, _ = fmt.Fprintln(w, "| Version | Algorithms added | Algorithms removed | Hints added | Hints removed | Lost data |")
+		_, _ = fmt.Fprintln(w, "|---|---|---|---|---|---|")
+		for _, v := range p.Versions {
+			lost := ""
+			if v.LostData {
+				lost = "yes"
+			}
+			_, _ = fmt.Fprintf(w, "| %v | %v | %v | %v | %v | %v |\n", v.Version, markdownAlgorithms(v.AlgorithmsAdded),
+				markdownAlgorithms(v.AlgorithmsRemoved), strings.Join(v.HintsAdded, ", "), strings.Join(v.HintsRemoved, ", "), lost)
+		}
+	}
+	if len(output.NotFound) > 0 {
+		_, _ = fmt.Fprintf(w, "\n## Not found\n\n")
+		for _, p := range output.NotFound {
+			_, _ = fmt.Fprintf(w, "- %v\n", p)
+		}
+	}
+}
+
+// markdownAlgorithms formats a list of algorithms as name (strength), separated by commas.
+func markdownAlgorithms(algorithms []dtos.CryptoUsageItem) string {
+	items := make([]string, 0, len(algorithms))
+	for _, a := range algorithms {
+		items = append(items, fmt.Sprintf("%v (%v)", a.Algorithm, a.Strength))
+	}
+	return strings.Join(items, ", ")
+}
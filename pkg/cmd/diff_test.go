@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+func TestRunDiff(t *testing.T) {
+	oldFile, newFile := setupCliDB(t), setupCliDB(t)
+	db, err := sqlx.Connect("sqlite", newFile)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database", err)
+	}
+	_, err = db.Exec("DELETE FROM component_crypto WHERE url_hash = '2c2ae45c192df28dcfd1caab7e2b12db';" +
+		"INSERT INTO all_urls VALUES ('ffff0000000000000000000000000002','','engine',13229156,'scanoss/engine',5,'2024-01-01',true);")
+	models.CloseDB(db)
+	if err != nil {
+		t.Fatalf("failed to update the new KB: %v", err)
+	}
+	args := []string{"-old-driver", "sqlite", "-old-dsn", oldFile, "-new-dsn", newFile}
+	var stdout, stderr bytes.Buffer
+	if err = RunDiff(append(args, "pkg:github/scanoss/engine", "pkg:github/pineappleea/pineapple-src"), nil, &stdout, &stderr); err != nil {
+		t.Fatalf("RunDiff() error = %v (%v)", err, stderr.String())
+	}
+	var output dtos.KBDiffOutput
+	if err = json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		t.Fatalf("RunDiff() returned invalid JSON: %v", err)
+	}
+	if output.Summary.Purls != 2 || output.Summary.ChangedPurls != 1 || len(output.Purls) != 1 ||
+		output.Purls[0].Purl != "pkg:github/scanoss/engine" || output.Summary.LostData != 1 || output.Summary.NewVersions != 1 {
+		t.Errorf("RunDiff() = %+v", output)
+	}
+
+	// All the purls of both KBs are compared if none are given
+	stdout.Reset()
+	if err = RunDiff(append(args, "-format", "markdown"), nil, &stdout, &stderr); err != nil {
+		t.Fatalf("RunDiff() error = %v (%v)", err, stderr.String())
+	}
+	for _, want := range []string{"# KB diff", "## pkg:github/scanoss/engine", "- New versions: 0.14.6",
+		"| 5.2.4 |  | crc32 (32), des (168), md5 (128), rsa (128) |  |  | yes |"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("RunDiff() markdown missing %q:\n%v", want, stdout.String())
+		}
+	}
+
+	for _, bad := range [][]string{{"-old-dsn", oldFile}, append(args, "-format", "xml"), append(args, "engine")} {
+		if err = RunDiff(bad, nil, &bytes.Buffer{}, &bytes.Buffer{}); err == nil {
+			t.Errorf("RunDiff(%v) expected an error", bad)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/jmoiron/sqlx"
+	gd "github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	purlhelper "github.com/scanoss/go-purl-helper/pkg"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+const exportUsage = `Usage: scanoss-cryptography-tools export [options] -output <file> [purl...]
+
+Exports the KB rows of a list of purls (every version of each) into a new SQLite file, that can be
+opened directly by the service and the CLI.
+
+Purls are taken as arguments, from a file (-input) or from stdin, one per line, or as a CycloneDX or SPDX JSON SBOM.
+
+Options:
+`
+
+// exportOptions holds the options of the export tool.
+type exportOptions struct {
+	input      string
+	output     string
+	format     string
+	force      bool
+	jsonConfig string
+	envConfig  string
+	dbDriver   string
+	dbDsn      string
+	debug      bool
+}
+
+// RunExport copies the KB rows of the given purls from the configured database into a new SQLite file.
+func RunExport(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	var opts exportOptions
+	fs := flag.NewFlagSet("scanoss-cryptography-tools export", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&opts.input, "input", "", "File with the purls (or SBOM) to export ('-' for stdin)")
+	fs.StringVar(&opts.output, "output", "", "SQLite file to export the KB subset into")
+	fs.StringVar(&opts.format, "format", "table", "Output format of the export summary (json or table)")
+	fs.BoolVar(&opts.force, "force", false, "Overwrite the output file, if it exists")
+	fs.StringVar(&opts.jsonConfig, "json-config", "", "Application JSON config")
+	fs.StringVar(&opts.envConfig, "env-config", "", "Application dot-ENV config")
+	fs.StringVar(&opts.dbDriver, "db-driver", "", "Database driver (sqlite or postgres). Overrides the config")
+	fs.StringVar(&opts.dbDsn, "db-dsn", "", "Database DSN (i.e. SQLite file or Postgres URL). Overrides the config")
+	fs.BoolVar(&opts.debug, "debug", false, "Enable debug")
+	fs.Usage = func() {
+		_, _ = fmt.Fprint(stderr, exportUsage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if len(opts.output) == 0 {
+		fs.Usage()
+		return errors.New("no output file specified")
+	}
+	if opts.format != "json" && opts.format != "table" {
+		return fmt.Errorf("unsupported output format: %v", opts.format)
+	}
+	components, err := readComponents(fs.Args(), cliOptions{input: opts.input}, false, stdin)
+	if err != nil {
+		return err
+	}
+	purls, err := exportPurls(components)
+	if err != nil {
+		return err
+	}
+	if _, err = os.Stat(opts.output); err == nil && !opts.force {
+		return fmt.Errorf("output file already exists: %v", opts.output)
+	}
+	if err = setupCliLogger(opts.debug); err != nil {
+		return err
+	}
+	defer zlog.SyncZap()
+	cfg, err := loadConfig(opts.jsonConfig, opts.envConfig, opts.debug)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %v", err)
+	}
+	summary, err := exportKB(cfg, opts, purls)
+	if err != nil {
+		return err
+	}
+	if opts.format == "json" {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(summary)
+	}
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	printExportSummary(tw, summary)
+	return tw.Flush()
+}
+
+// exportPurls converts the components to export into their purl name and type.
+func exportPurls(components []dtos.ComponentDTO) ([]models.PurlNameType, error) {
+	purls := make([]models.PurlNameType, 0, len(components))
+	for _, c := range components {
+		purl, err := purlhelper.PurlFromString(c.Purl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid purl %v: %v", c.Purl, err)
+		}
+		name, err := purlhelper.PurlNameFromString(c.Purl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid purl %v: %v", c.Purl, err)
+		}
+		purls = append(purls, models.PurlNameType{Name: name, Type: purl.Type})
+	}
+	return purls, nil
+}
+
+// exportKB opens the KB and a new output file, and copies the rows of the purls into it.
+// The output file is removed if the export fails.
+func exportKB(cfg *myconfig.ServerConfig, opts exportOptions, purls []models.PurlNameType) (models.KBExportSummary, error) {
+	db, err := openKB(cfg, opts.dbDriver, opts.dbDsn)
+	if err != nil {
+		return models.KBExportSummary{}, err
+	}
+	defer gd.CloseDBConnection(db)
+	if err = os.Remove(opts.output); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return models.KBExportSummary{}, fmt.Errorf("failed to remove the output file: %v", err)
+	}
+	dst, err := sqlx.Connect("sqlite", opts.output)
+	if err != nil {
+		return models.KBExportSummary{}, fmt.Errorf("failed to create the output file: %v", err)
+	}
+	summary, err := models.NewKBExportModel(context.Background(), zlog.S, db).ExportPurls(purls, dst)
+	models.CloseDB(dst)
+	if err != nil {
+		_ = os.Remove(opts.output)
+		return models.KBExportSummary{}, err
+	}
+	return summary, nil
+}
+
+// printExportSummary prints the number of rows exported per table, followed by the purls not found in the KB.
+func printExportSummary(w io.Writer, summary models.KBExportSummary) {
+	_, _ = fmt.Fprintln(w, "TABLE\tROWS")
+	for _, t := range summary.Tables {
+		_, _ = fmt.Fprintf(w, "%v\t%d\n", t.Table, t.Rows)
+	}
+	_, _ = fmt.Fprintf(w, "\nExported %d of %d purls\n", summary.Purls-len(summary.Missing), summary.Purls)
+	for _, p := range summary.Missing {
+		_, _ = fmt.Fprintf(w, "Not found: pkg:%v/%v\n", p.Type, p.Name)
+	}
+}
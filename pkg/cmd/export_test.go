@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunExport(t *testing.T) {
+	dbFile := setupCliDB(t)
+	output := filepath.Join(t.TempDir(), "subset.sqlite")
+	args := []string{"-db-driver", "sqlite", "-db-dsn", dbFile, "-output", output}
+	var stdout, stderr bytes.Buffer
+	sbom := `{"bomFormat": "CycloneDX", "components": [{"purl": "pkg:github/pineappleea/pineapple-src", "version": "5.4.7"},
+		{"purl": "pkg:npm/scanoss/engine"}]}`
+	err := RunExport(append(args, "-input", "-", "pkg:github/scanoss/engine"), strings.NewReader(sbom), &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("RunExport() error = %v (%v)", err, stderr.String())
+	}
+	for _, want := range []string{"all_urls", "crypto_library_tags", "Exported 2 of 3 purls", "Not found: pkg:npm/scanoss/engine"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("RunExport() output missing %q:\n%v", want, stdout.String())
+		}
+	}
+	// The exported KB is opened directly by the CLI
+	stdout.Reset()
+	err = RunCli([]string{"-db-driver", "sqlite", "-db-dsn", output, "-format", "table", "hints", "pkg:github/pineappleea/pineapple-src@v5.4.7"},
+		nil, &stdout, &stderr)
+	if err != nil || !strings.Contains(stdout.String(), "library/boringssl") {
+		t.Errorf("RunCli() on the export = %v:\n%v", err, stdout.String())
+	}
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no output", args: []string{"-db-dsn", dbFile, "pkg:github/scanoss/engine"}},
+		{name: "output exists", args: append(args, "pkg:github/scanoss/engine")},
+		{name: "invalid purl", args: []string{"-db-dsn", dbFile, "-output", filepath.Join(t.TempDir(), "kb.sqlite"), "scanoss/engine"}},
+		{name: "bad format", args: append(args, "-format", "xml", "pkg:github/scanoss/engine")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := RunExport(tt.args, strings.NewReader(""), &bytes.Buffer{}, &bytes.Buffer{}); err == nil {
+				t.Errorf("RunExport() expected an error")
+			}
+		})
+	}
+
+	stdout.Reset()
+	err = RunExport(append(args, "-force", "-format", "json", "pkg:github/scanoss/engine"), nil, &stdout, &stderr)
+	if err != nil || !strings.Contains(stdout.String(), `"purls": 1`) {
+		t.Errorf("RunExport() with -force = %v:\n%v", err, stdout.String())
+	}
+}
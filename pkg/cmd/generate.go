@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"text/tabwriter"
+
+	"github.com/jmoiron/sqlx"
+	common "github.com/scanoss/papi/api/commonv2"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/protobuf/encoding/protojson"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+const generateUsage = `Usage: scanoss-cryptography-tools generate [options] -output <file>
+
+Generates a synthetic KB into a new SQLite file, with the given number of components, versions per component,
+algorithms per URL hash and hints per URL hash, to measure how the service scales.
+
+Optionally, a JSONL file of component requests for the synthetic components can be written, to be replayed
+with the replay tool.
+
+Options:
+`
+
+// generateOptions holds the options of the synthetic KB generator.
+type generateOptions struct {
+	output       string
+	force        bool
+	kb           models.SyntheticKBConfig
+	requests     string
+	requestCount int
+	requestSize  int
+	format       string
+	debug        bool
+}
+
+// RunGenerate generates a synthetic KB into a new SQLite file, along with an optional file of requests to replay.
+func RunGenerate(args []string, stdout, stderr io.Writer) error {
+	var opts generateOptions
+	fs := flag.NewFlagSet("scanoss-cryptography-tools generate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&opts.output, "output", "", "SQLite file to generate the KB into")
+	fs.BoolVar(&opts.force, "force", false, "Overwrite the output file, if it exists")
+	fs.IntVar(&opts.kb.Purls, "purls", 1000, "Number of components")
+	fs.IntVar(&opts.kb.Versions, "versions", 10, "Number of versions per component")
+	fs.IntVar(&opts.kb.Algorithms, "algorithms", 5, "Number of algorithms per URL hash")
+	fs.IntVar(&opts.kb.Hints, "hints", 3, "Maximum number of hints per URL hash")
+	fs.Uint64Var(&opts.kb.Seed, "seed", 1, "Seed of the random data, to reproduce a KB")
+	fs.StringVar(&opts.requests, "requests", "", "Write a JSONL file of component requests for the synthetic components")
+	fs.IntVar(&opts.requestCount, "request-count", 100, "Number of requests to write")
+	fs.IntVar(&opts.requestSize, "request-size", 20, "Number of components per request")
+	fs.StringVar(&opts.format, "format", "table", "Output format of the generation summary (json or table)")
+	fs.BoolVar(&opts.debug, "debug", false, "Enable debug")
+	fs.Usage = func() {
+		_, _ = fmt.Fprint(stderr, generateUsage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if len(opts.output) == 0 {
+		fs.Usage()
+		return errors.New("no output file specified")
+	}
+	if opts.format != "json" && opts.format != "table" {
+		return fmt.Errorf("unsupported output format: %v", opts.format)
+	}
+	if len(opts.requests) > 0 && (opts.requestCount <= 0 || opts.requestSize <= 0) {
+		return fmt.Errorf("invalid request count (%d) or size (%d)", opts.requestCount, opts.requestSize)
+	}
+	if _, err := os.Stat(opts.output); err == nil && !opts.force {
+		return fmt.Errorf("output file already exists: %v", opts.output)
+	}
+	if err := setupCliLogger(opts.debug); err != nil {
+		return err
+	}
+	defer zlog.SyncZap()
+	purls, counts, err := generateKB(opts)
+	if err != nil {
+		return err
+	}
+	if len(opts.requests) > 0 {
+		if err = writeSyntheticRequests(opts, purls); err != nil {
+			return err
+		}
+	}
+	if opts.format == "json" {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(counts)
+	}
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "TABLE\tROWS")
+	for _, c := range counts {
+		_, _ = fmt.Fprintf(tw, "%v\t%d\n", c.Table, c.Rows)
+	}
+	return tw.Flush()
+}
+
+// generateKB creates the output file and generates the synthetic KB into it.
+// The output file is removed if the generation fails.
+func generateKB(opts generateOptions) ([]models.PurlNameType, []models.TableCount, error) {
+	if err := os.Remove(opts.output); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, nil, fmt.Errorf("failed to remove the output file: %v", err)
+	}
+	db, err := sqlx.Connect("sqlite", opts.output)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create the output file: %v", err)
+	}
+	purls, counts, err := models.NewSyntheticKBModel(context.Background(), zlog.S, db).Generate(opts.kb)
+	models.CloseDB(db)
+	if err != nil {
+		_ = os.Remove(opts.output)
+		return nil, nil, err
+	}
+	return purls, counts, nil
+}
+
+// writeSyntheticRequests writes a JSONL file of component requests, one per line, picking random synthetic components.
+// Components are requested without a requirement, for an exact version or for a version range.
+func writeSyntheticRequests(opts generateOptions, purls []models.PurlNameType) error {
+	f, err := os.Create(opts.requests)
+	if err != nil {
+		return fmt.Errorf("failed to create the requests file: %v", err)
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	rng := rand.New(rand.NewPCG(opts.kb.Seed, opts.kb.Seed+1))
+	for range opts.requestCount {
+		request := &common.ComponentsRequest{Components: make([]*common.ComponentRequest, 0, opts.requestSize)}
+		for range opts.requestSize {
+			p := purls[rng.IntN(len(purls))]
+			component := &common.ComponentRequest{Purl: "pkg:" + p.Type + "/" + p.Name}
+			switch rng.IntN(3) {
+			case 1:
+				component.Requirement = models.SyntheticVersion(rng.IntN(opts.kb.Versions))
+			case 2:
+				component.Requirement = ">=" + models.SyntheticVersion(rng.IntN(opts.kb.Versions))
+			}
+			request.Components = append(request.Components, component)
+		}
+		line, err := protojson.Marshal(request)
+		if err != nil {
+			return fmt.Errorf("failed to marshal a request: %v", err)
+		}
+		_, _ = w.Write(line)
+		_ = w.WriteByte('\n')
+	}
+	if err = w.Flush(); err != nil {
+		return fmt.Errorf("failed to write the requests file: %v", err)
+	}
+	return f.Close()
+}
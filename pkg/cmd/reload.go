@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/cryptography/pkg/auth"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/models"
+	"scanoss.com/cryptography/pkg/protocol/grpc"
+)
+
+// liveSettings lists the config settings a reload applies to the running server. Changing any other requires a restart.
+var liveSettings = map[string]bool{
+	"App.Debug":                true,
+	"Filtering.AllowListFile":  true,
+	"Filtering.DenyListFile":   true,
+	"Filtering.BlockByDefault": true,
+	"Auth.KeysFile":            true,
+	"Cache.Enabled":            true,
+	"Cache.Size":               true,
+	"Cache.TTL":                true,
+}
+
+// configSource holds where the server config is loaded from.
+type configSource struct {
+	jsonConfig string
+	envConfig  string
+	debug      bool
+}
+
+// load loads the server config from its source.
+func (c configSource) load() (*myconfig.ServerConfig, error) {
+	return loadConfig(c.jsonConfig, c.envConfig, c.debug)
+}
+
+// configReloader re-reads the server config, and the IP filter and API key files it references, while the server is running.
+// The settings that can be changed live are handed to the components using them, and those requiring a restart are logged.
+// The config shared with the services is never modified, so it can be read without locking.
+type configReloader struct {
+	mu       sync.Mutex
+	source   configSource
+	active   myconfig.ServerConfig // Settings in force, i.e. the startup config plus the live changes applied since
+	filters  *grpc.IPFilters
+	keys     *auth.KeyStore       // Only set if API key authentication is enabled
+	modified map[string]time.Time // Last modification time of each watched file
+}
+
+// newConfigReloader creates a reloader for the active config, recording the current state of the files it watches.
+func newConfigReloader(source configSource, config *myconfig.ServerConfig, filters *grpc.IPFilters, keys *auth.KeyStore) *configReloader {
+	r := &configReloader{source: source, active: *config, filters: filters, keys: keys, modified: make(map[string]time.Time)}
+	r.filesModified()
+	return r
+}
+
+// run reloads the config whenever the process receives a SIGHUP and, if requested, when any of the watched files is modified.
+// It blocks until the context is done.
+func (r *configReloader) run(ctx context.Context) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+	var poll <-chan time.Time
+	if r.active.Reload.Watch && r.active.Reload.Interval > 0 {
+		ticker := time.NewTicker(time.Duration(r.active.Reload.Interval) * time.Second)
+		defer ticker.Stop()
+		poll = ticker.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			zlog.S.Infof("Received SIGHUP, reloading the config")
+			r.filesModified() // Don't reload the same changes again on the next poll
+			_, _ = r.reload()
+		case <-poll:
+			if r.filesModified() {
+				zlog.S.Infof("Config files modified, reloading the config")
+				_, _ = r.reload()
+			}
+		}
+	}
+}
+
+// reload re-reads the config and the files it references, and applies what can be changed live.
+// It returns the names of the changed settings that require a restart. The active settings are kept if the new ones cannot be loaded.
+func (r *configReloader) reload() ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	updated, err := r.source.load()
+	if err != nil {
+		zlog.S.Errorf("Failed to reload the config, keeping the active one: %v", err)
+		return nil, err
+	}
+	var restart []string
+	for _, name := range changedSettings(&r.active, updated) {
+		if !liveSettings[name] {
+			restart = append(restart, name)
+		}
+	}
+	if len(restart) > 0 {
+		zlog.S.Warnf("Config changes requiring a restart to apply: %v", strings.Join(restart, ", "))
+	}
+	if updated.App.Debug != r.active.App.Debug {
+		r.active.App.Debug = updated.App.Debug
+		if updated.App.Debug {
+			zlog.SetLevel("debug")
+		} else {
+			zlog.SetLevel("info")
+		}
+	}
+	if updated.Cache != r.active.Cache {
+		r.active.Cache = updated.Cache
+		configureCache(&r.active)
+	}
+	return restart, errors.Join(r.reloadFilters(updated), r.reloadKeys(updated))
+}
+
+// reloadFilters re-reads the IP filter lists (used by both gRPC and REST) with the updated filtering settings,
+// keeping the active ones if they cannot be loaded.
+func (r *configReloader) reloadFilters(updated *myconfig.ServerConfig) error {
+	settings := r.filters.Settings() // Trust proxy requires a restart, as the request limits use it too
+	settings.AllowListFile = updated.Filtering.AllowListFile
+	settings.DenyListFile = updated.Filtering.DenyListFile
+	settings.BlockByDefault = updated.Filtering.BlockByDefault
+	if _, _, err := r.filters.ReloadFiltersWith(settings); err != nil {
+		return err
+	}
+	r.active.Filtering.AllowListFile = settings.AllowListFile
+	r.active.Filtering.DenyListFile = settings.DenyListFile
+	r.active.Filtering.BlockByDefault = settings.BlockByDefault
+	return nil
+}
+
+// reloadKeys re-reads the API keys from the updated keys file (or table), keeping the active ones if they cannot be loaded.
+func (r *configReloader) reloadKeys(updated *myconfig.ServerConfig) error {
+	if r.keys == nil {
+		return nil
+	}
+	if _, err := r.keys.ReloadFrom(updated.Auth.KeysFile); err != nil {
+		return err
+	}
+	r.active.Auth.KeysFile = updated.Auth.KeysFile
+	return nil
+}
+
+// filesModified reports whether any of the watched files has been modified (or created/removed) since the last check.
+func (r *configReloader) filesModified() bool {
+	files := []string{r.source.jsonConfig, r.source.envConfig, r.active.Filtering.AllowListFile, r.active.Filtering.DenyListFile,
+		r.active.Auth.KeysFile}
+	modified := false
+	for _, file := range files {
+		if len(file) == 0 {
+			continue
+		}
+		var modTime time.Time // Zero if the file does not exist
+		if info, err := os.Stat(file); err == nil {
+			modTime = info.ModTime()
+		}
+		if last, ok := r.modified[file]; !ok || !last.Equal(modTime) {
+			r.modified[file] = modTime
+			modified = modified || ok
+		}
+	}
+	return modified
+}
+
+// changedSettings returns the names (Section.Setting) of the settings that differ between the two configs.
+func changedSettings(current, updated *myconfig.ServerConfig) []string {
+	var changed []string
+	currentValue, updatedValue := reflect.ValueOf(current).Elem(), reflect.ValueOf(updated).Elem()
+	for i := 0; i < currentValue.NumField(); i++ {
+		section := currentValue.Type().Field(i)
+		for j := 0; j < section.Type.NumField(); j++ {
+			if !reflect.DeepEqual(currentValue.Field(i).Field(j).Interface(), updatedValue.Field(i).Field(j).Interface()) {
+				changed = append(changed, section.Name+"."+section.Type.Field(j).Name)
+			}
+		}
+	}
+	return changed
+}
+
+// configureCache sets up the KB lookup cache (if requested).
+func configureCache(cfg *myconfig.ServerConfig) {
+	cacheSize := cfg.Cache.Size
+	if !cfg.Cache.Enabled {
+		cacheSize = 0
+	}
+	models.ConfigureCache(zlog.S, cacheSize, time.Duration(cfg.Cache.TTL)*time.Second)
+}
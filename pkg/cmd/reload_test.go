@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/cryptography/pkg/models"
+	"scanoss.com/cryptography/pkg/protocol/grpc"
+)
+
+// writeReloadFile writes the given contents to the file, moving its modification time forward to make the change visible.
+func writeReloadFile(t *testing.T, file, contents string, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(file, []byte(contents), 0o600); err != nil {
+		t.Fatalf("failed to write %v: %v", file, err)
+	}
+	if err := os.Chtimes(file, modTime, modTime); err != nil {
+		t.Fatalf("failed to set the modification time of %v: %v", file, err)
+	}
+}
+
+func TestConfigReloader(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	defer models.ConfigureCache(zlog.S, 0, 0)
+	dir := t.TempDir()
+	jsonConfig, allowList, denyList := filepath.Join(dir, "config.json"), filepath.Join(dir, "allow.txt"), filepath.Join(dir, "deny.txt")
+	modTime := time.Now().Add(-time.Hour)
+	writeReloadFile(t, allowList, "127.0.0.1\n", modTime)
+	writeReloadFile(t, denyList, "10.0.0.1\n", modTime)
+	writeReloadFile(t, jsonConfig, `{"Filtering": {"AllowListFile": "`+allowList+`"}, "Cache": {"Enabled": true, "Size": 100}}`, modTime)
+	source := configSource{jsonConfig: jsonConfig}
+	cfg, err := source.load()
+	if err != nil {
+		t.Fatalf("failed to load the config: %v", err)
+	}
+	filters := grpc.NewIPFilters(cfg, []string{"127.0.0.1"}, nil)
+	reloader := newConfigReloader(source, cfg, filters, nil)
+	if reloader.filesModified() {
+		t.Errorf("filesModified() = true, expected no changes")
+	}
+	// Live settings are applied, and the others reported as requiring a restart
+	modTime = modTime.Add(time.Minute)
+	writeReloadFile(t, jsonConfig, `{"App": {"GRPCPort": "60000"}, "Filtering": {"AllowListFile": "`+allowList+`", "DenyListFile": "`+denyList+
+		`"}, "Cache": {"Enabled": true, "Size": 200}}`, modTime)
+	if !reloader.filesModified() {
+		t.Errorf("filesModified() = false, expected the config file change to be detected")
+	}
+	restart, err := reloader.reload()
+	if err != nil {
+		t.Fatalf("reload() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(restart, []string{"App.GRPCPort"}) {
+		t.Errorf("reload() restart = %v, expected [App.GRPCPort]", restart)
+	}
+	active := reloader.active
+	if active.Filtering.DenyListFile != denyList || filters.Settings().DenyListFile != denyList || active.Cache.Size != 200 ||
+		active.App.GRPCPort == "60000" {
+		t.Errorf("reload() applied the wrong settings: %+v, %+v, %+v", active.Filtering, active.Cache, active.App)
+	}
+	if len(cfg.Filtering.DenyListFile) > 0 || cfg.Cache.Size != 100 {
+		t.Errorf("reload() modified the config shared with the services: %+v, %+v", cfg.Filtering, cfg.Cache)
+	}
+	if stats := models.GetCacheStats(); len(stats) == 0 {
+		t.Errorf("reload() did not reconfigure the cache: %+v", stats)
+	}
+	// Changes to the referenced files are watched too (from the poll after they are configured),
+	// and unreadable lists leave the active ones in place
+	if reloader.filesModified() {
+		t.Errorf("filesModified() = true, expected the new deny list to only start being watched")
+	}
+	modTime = modTime.Add(time.Minute)
+	writeReloadFile(t, denyList, "10.0.0.2\n", modTime)
+	if !reloader.filesModified() {
+		t.Errorf("filesModified() = false, expected the deny list change to be detected")
+	}
+	writeReloadFile(t, jsonConfig, `{"Filtering": {"DenyListFile": "`+filepath.Join(dir, "missing.txt")+`"}, "Cache": {"Enabled": true, "Size": 200}}`, modTime)
+	if _, err = reloader.reload(); err == nil {
+		t.Errorf("reload() expected an error for a missing deny list")
+	}
+	if reloader.active.Filtering.DenyListFile != denyList || filters.Settings().DenyListFile != denyList {
+		t.Errorf("reload() did not keep the active filtering settings: %+v, %+v", reloader.active.Filtering, filters.Settings())
+	}
+}
@@ -0,0 +1,297 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	gd "github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	common "github.com/scanoss/papi/api/commonv2"
+	pb "github.com/scanoss/papi/api/cryptographyv2"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/service"
+)
+
+const replayUsage = `Usage: scanoss-cryptography-tools replay [options] -requests <file.jsonl>
+
+Replays the component requests of a JSONL file (one ComponentsRequest per line) against an in-process
+Cryptography service backed by the configured KB, and reports the latency percentiles of each RPC.
+
+RPCs are named after the CLI commands: %v.
+
+Options:
+`
+
+// replayRPCs lists the CLI commands whose RPCs are replayed by default.
+var replayRPCs = []string{"algorithms", "algorithms-in-range", "versions-in-range", "hints", "hints-in-range"}
+
+// replayOptions holds the options of the replay tool.
+type replayOptions struct {
+	requests    string
+	rpcs        string
+	iterations  int
+	concurrency int
+	timeout     time.Duration
+	format      string
+	jsonConfig  string
+	envConfig   string
+	dbDriver    string
+	dbDsn       string
+	debug       bool
+}
+
+// replayStats holds the latencies (in milliseconds) of the requests replayed against an RPC.
+type replayStats struct {
+	RPC        string  `json:"rpc"`
+	Requests   int     `json:"requests"`
+	Errors     int     `json:"errors"`     // Requests that failed or returned a failed status
+	Throughput float64 `json:"throughput"` // Requests per second
+	Mean       float64 `json:"mean_ms"`
+	P50        float64 `json:"p50_ms"`
+	P90        float64 `json:"p90_ms"`
+	P95        float64 `json:"p95_ms"`
+	P99        float64 `json:"p99_ms"`
+	Max        float64 `json:"max_ms"`
+}
+
+// RunReplay replays a file of component requests against an in-process service, reporting the latencies per RPC.
+func RunReplay(args []string, stdout, stderr io.Writer) error {
+	var opts replayOptions
+	fs := flag.NewFlagSet("scanoss-cryptography-tools replay", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&opts.requests, "requests", "", "JSONL file of component requests to replay")
+	fs.StringVar(&opts.rpcs, "rpcs", "", "Comma separated list of the RPCs to replay (default all)")
+	fs.IntVar(&opts.iterations, "iterations", 1, "Number of times the requests are replayed")
+	fs.IntVar(&opts.concurrency, "concurrency", 1, "Number of requests sent in parallel")
+	fs.DurationVar(&opts.timeout, "timeout", 60*time.Second, "Timeout of each request")
+	fs.StringVar(&opts.format, "format", "table", "Output format (json or table)")
+	fs.StringVar(&opts.jsonConfig, "json-config", "", "Application JSON config")
+	fs.StringVar(&opts.envConfig, "env-config", "", "Application dot-ENV config")
+	fs.StringVar(&opts.dbDriver, "db-driver", "", "Database driver (sqlite or postgres). Overrides the config")
+	fs.StringVar(&opts.dbDsn, "db-dsn", "", "Database DSN (i.e. SQLite file or Postgres URL). Overrides the config")
+	fs.BoolVar(&opts.debug, "debug", false, "Enable debug")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, replayUsage, strings.Join(replayRPCs, ", "))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if len(opts.requests) == 0 {
+		fs.Usage()
+		return errors.New("no requests file specified")
+	}
+	if opts.format != "json" && opts.format != "table" {
+		return fmt.Errorf("unsupported output format: %v", opts.format)
+	}
+	if opts.iterations <= 0 || opts.concurrency <= 0 {
+		return fmt.Errorf("invalid iterations (%d) or concurrency (%d)", opts.iterations, opts.concurrency)
+	}
+	rpcs := replayRPCs
+	if len(opts.rpcs) > 0 {
+		rpcs = splitList(opts.rpcs)
+		for _, rpc := range rpcs {
+			if !slices.Contains(replayRPCs, rpc) {
+				return fmt.Errorf("unknown RPC: %v", rpc)
+			}
+		}
+	}
+	requests, err := loadReplayRequests(opts.requests)
+	if err != nil {
+		return err
+	}
+	if err = setupCliLogger(opts.debug); err != nil {
+		return err
+	}
+	defer zlog.SyncZap()
+	cfg, err := loadConfig(opts.jsonConfig, opts.envConfig, opts.debug)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %v", err)
+	}
+	db, err := openKB(cfg, opts.dbDriver, opts.dbDsn)
+	if err != nil {
+		return err
+	}
+	defer gd.CloseDBConnection(db)
+	client, stop, err := startInProcessService(db, cfg)
+	if err != nil {
+		return err
+	}
+	defer stop()
+	stats := make([]replayStats, 0, len(rpcs))
+	for _, rpc := range rpcs {
+		stats = append(stats, replayRequests(client, rpc, requests, opts))
+	}
+	if opts.format == "json" {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(stats)
+	}
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	printReplayStats(tw, stats)
+	return tw.Flush()
+}
+
+// loadReplayRequests reads a JSONL file of component requests, ignoring blank lines and lines starting with '#'.
+func loadReplayRequests(path string) ([]*common.ComponentsRequest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the requests file: %v", err)
+	}
+	defer f.Close()
+	var requests []*common.ComponentsRequest
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024) // Requests can have thousands of components
+	line := 0
+	for scanner.Scan() {
+		line++
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 || data[0] == '#' {
+			continue
+		}
+		request := &common.ComponentsRequest{}
+		if err = protojson.Unmarshal(data, request); err != nil {
+			return nil, fmt.Errorf("invalid request on line %d: %v", line, err)
+		}
+		requests = append(requests, request)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the requests file: %v", err)
+	}
+	if len(requests) == 0 {
+		return nil, errors.New("no requests to replay")
+	}
+	return requests, nil
+}
+
+// startInProcessService serves the Cryptography service over an in-memory gRPC connection,
+// returning a client for it and a function to stop both.
+func startInProcessService(db *sqlx.DB, cfg *myconfig.ServerConfig) (remoteClient, func(), error) {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	pb.RegisterCryptographyServer(server, service.NewCryptographyServer(db, cfg))
+	go func() { _ = server.Serve(listener) }()
+	conn, err := grpc.NewClient("passthrough:///in-process",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(64*1024*1024)))
+	if err != nil {
+		server.Stop()
+		return nil, nil, fmt.Errorf("failed to connect to the in-process service: %v", err)
+	}
+	client := &grpcRemoteClient{conn: conn}
+	return client, func() {
+		_ = client.close()
+		server.Stop()
+	}, nil
+}
+
+// replayRequests sends all the requests (for the given number of iterations) to an RPC, with the given concurrency,
+// and returns their latency statistics.
+func replayRequests(client remoteClient, rpc string, requests []*common.ComponentsRequest, opts replayOptions) replayStats {
+	remote := cliRemoteCommands[rpc]
+	jobs := make(chan *common.ComponentsRequest)
+	var mu sync.Mutex
+	var latencies []time.Duration
+	errs := 0
+	var wg sync.WaitGroup
+	start := time.Now()
+	for range opts.concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for request := range jobs {
+				ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+				sent := time.Now()
+				_, status, err := remote.query(ctx, client, request)
+				latency := time.Since(sent)
+				cancel()
+				mu.Lock()
+				latencies = append(latencies, latency)
+				if err != nil || status.GetStatus() == common.StatusCode_FAILED {
+					zlog.S.Debugf("Request to %v failed: %v %v", rpc, err, status.GetMessage())
+					errs++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for range opts.iterations {
+		for _, request := range requests {
+			jobs <- request
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return newReplayStats(rpc, latencies, errs, time.Since(start))
+}
+
+// newReplayStats computes the mean, percentiles (nearest rank) and throughput of the latencies of an RPC.
+func newReplayStats(rpc string, latencies []time.Duration, errs int, elapsed time.Duration) replayStats {
+	stats := replayStats{RPC: rpc, Requests: len(latencies), Errors: errs}
+	if len(latencies) == 0 {
+		return stats
+	}
+	slices.Sort(latencies)
+	var total time.Duration
+	for _, l := range latencies {
+		total += l
+	}
+	ms := func(d time.Duration) float64 { return float64(d.Microseconds()) / 1000 }
+	percentile := func(p int) float64 {
+		rank := (p*len(latencies) + 99) / 100 // Rounded up
+		return ms(latencies[max(rank, 1)-1])
+	}
+	stats.Mean = ms(total / time.Duration(len(latencies)))
+	stats.P50, stats.P90, stats.P95, stats.P99 = percentile(50), percentile(90), percentile(95), percentile(99)
+	stats.Max = ms(latencies[len(latencies)-1])
+	if elapsed > 0 {
+		stats.Throughput = float64(len(latencies)) / elapsed.Seconds()
+	}
+	return stats
+}
+
+func printReplayStats(w io.Writer, stats []replayStats) {
+	_, _ = fmt.Fprintln(w, "RPC\tREQUESTS\tERRORS\tREQ/S\tMEAN\tP50\tP90\tP95\tP99\tMAX")
+	for _, s := range stats {
+		_, _ = fmt.Fprintf(w, "%v\t%d\t%d\t%.1f\t%.2fms\t%.2fms\t%.2fms\t%.2fms\t%.2fms\t%.2fms\n", s.RPC, s.Requests, s.Errors,
+			s.Throughput, s.Mean, s.P50, s.P90, s.P95, s.P99, s.Max)
+	}
+}
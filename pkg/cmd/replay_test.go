@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	common "github.com/scanoss/papi/api/commonv2"
+	"go.uber.org/zap"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+func TestRunReplay(t *testing.T) {
+	dir := t.TempDir()
+	kbFile, requestsFile := filepath.Join(dir, "kb.sqlite"), filepath.Join(dir, "requests.jsonl")
+	var stdout, stderr bytes.Buffer
+	err := RunGenerate([]string{"-output", kbFile, "-purls", "50", "-versions", "5", "-requests", requestsFile,
+		"-request-count", "4", "-request-size", "10"}, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("RunGenerate() error = %v (%v)", err, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "all_urls") || !strings.Contains(stdout.String(), "250") {
+		t.Errorf("RunGenerate() output = %v", stdout.String())
+	}
+	data, err := os.ReadFile(requestsFile)
+	if err != nil || strings.Count(string(data), "\n") != 4 {
+		t.Fatalf("RunGenerate() requests = %s, %v", data, err)
+	}
+	if err = RunGenerate([]string{"-output", kbFile}, &stdout, &stderr); err == nil {
+		t.Errorf("RunGenerate() expected an error overwriting a KB without -force")
+	}
+
+	stdout.Reset()
+	args := []string{"-db-driver", "sqlite", "-db-dsn", kbFile, "-requests", requestsFile}
+	if err = RunReplay(append(args, "-iterations", "2", "-concurrency", "3", "-format", "json"), &stdout, &stderr); err != nil {
+		t.Fatalf("RunReplay() error = %v (%v)", err, stderr.String())
+	}
+	var stats []replayStats
+	if err = json.Unmarshal(stdout.Bytes(), &stats); err != nil || len(stats) != len(replayRPCs) {
+		t.Fatalf("RunReplay() = %v, %v", stdout.String(), err)
+	}
+	for _, s := range stats {
+		if s.Requests != 8 || s.Errors != 0 || s.P50 > s.P99 || s.P99 > s.Max || s.Max == 0 {
+			t.Errorf("RunReplay() stats = %+v", s)
+		}
+	}
+	stdout.Reset()
+	if err = RunReplay(append(args, "-rpcs", "hints"), &stdout, &stderr); err != nil || !strings.Contains(stdout.String(), "P99") {
+		t.Errorf("RunReplay() table = %v, %v", stdout.String(), err)
+	}
+	for _, bad := range [][]string{append(args, "-rpcs", "unknown"), append(args, "-concurrency", "0"), {"-requests", kbFile}} {
+		if err = RunReplay(bad, &bytes.Buffer{}, &bytes.Buffer{}); err == nil {
+			t.Errorf("RunReplay(%v) expected an error", bad)
+		}
+	}
+}
+
+func TestNewReplayStats(t *testing.T) {
+	latencies := make([]time.Duration, 0, 100)
+	for i := 100; i > 0; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	stats := newReplayStats("hints", latencies, 1, time.Second)
+	if stats.P50 != 50 || stats.P90 != 90 || stats.P99 != 99 || stats.Max != 100 || stats.Mean != 50.5 || stats.Throughput != 100 {
+		t.Errorf("newReplayStats() = %+v", stats)
+	}
+	if stats = newReplayStats("hints", nil, 0, 0); stats.Requests != 0 || stats.Max != 0 {
+		t.Errorf("newReplayStats() with no requests = %+v", stats)
+	}
+}
+
+// BenchmarkReplay measures each RPC against an in-process service backed by a synthetic KB.
+func BenchmarkReplay(b *testing.B) {
+	db, err := sqlx.Connect("sqlite", filepath.Join(b.TempDir(), "kb.sqlite"))
+	if err != nil {
+		b.Fatalf("an error '%s' was not expected when opening a stub database", err)
+	}
+	defer models.CloseDB(db)
+	purls, _, err := models.NewSyntheticKBModel(context.Background(), zap.NewNop().Sugar(), db).
+		Generate(models.SyntheticKBConfig{Purls: 500, Versions: 10, Algorithms: 5, Hints: 3, Seed: 1})
+	if err != nil {
+		b.Fatalf("failed to generate the synthetic KB: %v", err)
+	}
+	cfg, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		b.Fatalf("failed to load Config: %v", err)
+	}
+	client, stop, err := startInProcessService(db, cfg)
+	if err != nil {
+		b.Fatalf("failed to start the in-process service: %v", err)
+	}
+	defer stop()
+	request := &common.ComponentsRequest{}
+	for i, p := range purls[:20] {
+		request.Components = append(request.Components,
+			&common.ComponentRequest{Purl: fmt.Sprintf("pkg:%v/%v", p.Type, p.Name), Requirement: ">=" + models.SyntheticVersion(i%10)})
+	}
+	for _, rpc := range replayRPCs {
+		b.Run(rpc, func(b *testing.B) {
+			remote := cliRemoteCommands[rpc]
+			for i := 0; i < b.N; i++ {
+				if _, _, err := remote.query(context.Background(), client, request); err != nil {
+					b.Fatalf("%v request failed: %v", rpc, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/scanner"
+)
+
+const scannerUsage = `Usage: scanoss-cryptography-scanner [options] [directory]
+
+Scans a local source tree (the current directory by default) for cryptographic algorithms, and for
+the crypto libraries, SDKs and frameworks whose keywords are listed in the detection definitions.
+
+Options:
+`
+
+// scannerOptions holds the command line options of the local scanner.
+type scannerOptions struct {
+	definitions string
+	algorithms  bool
+	ignore      string
+	workers     int
+	maxFileSize int64
+	maxEvidence int
+	format      string
+	debug       bool
+}
+
+// RunScanner runs the local source tree scanner with the given arguments.
+func RunScanner(args []string, stdout, stderr io.Writer) error {
+	var opts scannerOptions
+	fs := flag.NewFlagSet("scanoss-cryptography-scanner", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&opts.definitions, "json-definition", "", "Detection definitions JSON file, with the keywords of each crypto library")
+	fs.BoolVar(&opts.algorithms, "algorithms", true, "Search for the built-in algorithm keywords")
+	fs.StringVar(&opts.ignore, "ignore", "", "Comma separated patterns to ignore, besides version control and node_modules folders (i.e. *.md,test/)")
+	fs.IntVar(&opts.workers, "workers", 0, "Number of files scanned concurrently (defaults to the number of CPUs)")
+	fs.Int64Var(&opts.maxFileSize, "max-file-size", 0, "Maximum size (in bytes) of the files scanned (default 5MB)")
+	fs.IntVar(&opts.maxEvidence, "max-evidence", 0, "Maximum evidence reported per algorithm/hint (default 100)")
+	fs.StringVar(&opts.format, "format", "json", "Output format (json or table)")
+	fs.BoolVar(&opts.debug, "debug", false, "Enable debug")
+	fs.Usage = func() {
+		_, _ = fmt.Fprint(stderr, scannerUsage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if opts.format != "json" && opts.format != "table" {
+		return fmt.Errorf("unsupported output format: %v", opts.format)
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return errors.New("only one directory can be scanned at a time")
+	}
+	root := "."
+	if fs.NArg() == 1 {
+		root = fs.Arg(0)
+	}
+	config, err := newScannerConfig(opts)
+	if err != nil {
+		return err
+	}
+	if err = setupCliLogger(opts.debug); err != nil {
+		return err
+	}
+	defer zlog.SyncZap()
+	sc, err := scanner.NewScanner(zlog.S, config)
+	if err != nil {
+		return err
+	}
+	output, err := sc.Scan(context.Background(), root)
+	if err != nil {
+		return fmt.Errorf("failed to scan %v: %v", root, err)
+	}
+	if opts.format == "table" {
+		tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+		printScanTable(tw, output)
+		return tw.Flush()
+	}
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+// newScannerConfig builds the scan settings from the options, loading the detection definitions (if any).
+func newScannerConfig(opts scannerOptions) (scanner.Config, error) {
+	config := scanner.Config{
+		Ignore:      append(scanner.DefaultIgnore, splitList(opts.ignore)...),
+		Workers:     opts.workers,
+		MaxFileSize: opts.maxFileSize,
+		MaxEvidence: opts.maxEvidence,
+	}
+	if opts.algorithms {
+		config.Algorithms = scanner.DefaultAlgorithms()
+	}
+	if len(opts.definitions) > 0 {
+		defs, err := loadDetectionsDefinitions(opts.definitions)
+		if err != nil {
+			return scanner.Config{}, err
+		}
+		for _, def := range defs {
+			config.Hints = append(config.Hints, scanner.HintDefinition{Keywords: def.Keywords, Hint: dtos.ECDetectedItem{
+				ID: def.ID, Name: def.Name, Description: def.Description, URL: def.URL, Category: def.Category, Purl: def.Purl}})
+		}
+	}
+	return config, nil
+}
+
+func printScanTable(w io.Writer, output dtos.LocalScanOutput) {
+	_, _ = fmt.Fprintln(w, "TYPE\tNAME\tSTRENGTH/CATEGORY\tMATCHES\tFIRST MATCH")
+	firstMatch := func(evidence []dtos.ScanEvidence) string {
+		if len(evidence) == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%s:%d", evidence[0].File, evidence[0].Line)
+	}
+	for _, a := range output.Algorithms {
+		_, _ = fmt.Fprintf(w, "algorithm\t%s\t%s\t%d\t%s\n", a.Algorithm, orNone(a.Strength), a.Matches, firstMatch(a.Evidence))
+	}
+	for _, h := range output.Hints {
+		_, _ = fmt.Fprintf(w, "hint\t%s\t%s\t%d\t%s\n", h.Name, orNone(h.Category), h.Matches, firstMatch(h.Evidence))
+	}
+	_, _ = fmt.Fprintf(w, "\n%d files scanned, %d skipped\n", output.FilesScanned, output.FilesSkipped)
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"scanoss.com/cryptography/pkg/dtos"
+)
+
+func TestRunScanner(t *testing.T) {
+	dir := t.TempDir()
+	definitions := filepath.Join(dir, "definitions.json")
+	err := os.WriteFile(definitions, []byte(`[{"id": "library/libsodium", "name": "libsodium", "description": "Sodium crypto library",
+		"keywords": ["sodium.h", "crypto_secretbox"], "category": "library"}]`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := filepath.Join(dir, "src")
+	if err = os.MkdirAll(src, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(filepath.Join(src, "main.c"), []byte("#include <sodium.h>\nSHA256(data, len, md);\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if err = RunScanner([]string{"-json-definition", definitions, src}, &stdout, &stderr); err != nil {
+		t.Fatalf("RunScanner() unexpected error: %v (stderr: %s)", err, stderr.String())
+	}
+	var output dtos.LocalScanOutput
+	if err = json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		t.Fatalf("RunScanner() output is not valid JSON: %v", err)
+	}
+	if len(output.Algorithms) != 1 || output.Algorithms[0].Algorithm != "sha256" || len(output.Hints) != 1 ||
+		output.Hints[0].Name != "libsodium" || output.Hints[0].Evidence[0].File != "main.c" {
+		t.Errorf("RunScanner() unexpected output: %+v", output)
+	}
+	stdout.Reset()
+	if err = RunScanner([]string{"-algorithms=false", "-format", "table", "-json-definition", definitions, src}, &stdout, &stderr); err != nil {
+		t.Fatalf("RunScanner() unexpected error: %v", err)
+	}
+	if !bytes.Contains(stdout.Bytes(), []byte("libsodium")) || bytes.Contains(stdout.Bytes(), []byte("sha256")) {
+		t.Errorf("RunScanner() unexpected table output:\n%s", stdout.String())
+	}
+	if err = RunScanner([]string{"-json-definition", filepath.Join(dir, "missing.json"), src}, &stdout, &stderr); err == nil {
+		t.Errorf("RunScanner() expected an error for missing definitions")
+	}
+}
@@ -33,6 +33,7 @@ import (
 	gs "github.com/scanoss/go-grpc-helper/pkg/grpc/server"
 	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
 	_ "modernc.org/sqlite"
+	"scanoss.com/cryptography/pkg/auth"
 	myconfig "scanoss.com/cryptography/pkg/config"
 
 	"scanoss.com/cryptography/pkg/protocol/grpc"
@@ -45,8 +46,8 @@ import (
 
 var version string
 
-// getConfig checks command line args for option to feed into the config parser.
-func getConfig() (*myconfig.ServerConfig, error) {
+// getConfig checks command line args for option to feed into the config parser, returning the config and where it was loaded from.
+func getConfig() (*myconfig.ServerConfig, configSource, error) {
 	var jsonConfig, envConfig string
 	flag.StringVar(&jsonConfig, "json-config", "", "Application JSON config")
 	flag.StringVar(&envConfig, "env-config", "", "Application dot-ENV config")
@@ -57,6 +58,13 @@ func getConfig() (*myconfig.ServerConfig, error) {
 		fmt.Printf("Version: %v", version)
 		os.Exit(1)
 	}
+	source := configSource{jsonConfig: jsonConfig, envConfig: envConfig, debug: *debug}
+	cfg, err := source.load()
+	return cfg, source, err
+}
+
+// loadConfig loads the server config from the optional JSON/dot-ENV config files and the environment.
+func loadConfig(jsonConfig, envConfig string, debug bool) (*myconfig.ServerConfig, error) {
 	var feeders []config.Feeder
 	if len(jsonConfig) > 0 {
 		feeders = append(feeders, feeder.Json{Path: jsonConfig})
@@ -64,7 +72,7 @@ func getConfig() (*myconfig.ServerConfig, error) {
 	if len(envConfig) > 0 {
 		feeders = append(feeders, feeder.DotEnv{Path: envConfig})
 	}
-	if *debug {
+	if debug {
 		err := os.Setenv("APP_DEBUG", "1")
 		if err != nil {
 			fmt.Printf("Warning: Failed to set env APP_DEBUG to 1: %v", err)
@@ -78,7 +86,7 @@ func getConfig() (*myconfig.ServerConfig, error) {
 // RunServer runs the gRPC Cryptography Server.
 func RunServer() error {
 	// Load command line options and config
-	cfg, err := getConfig()
+	cfg, source, err := getConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %v", err)
 	}
@@ -110,21 +118,40 @@ func RunServer() error {
 		return err
 	}
 	defer gd.CloseDBConnection(db)
+	// Setup the KB lookup cache (if requested)
+	configureCache(cfg)
 	// Setup dynamic logging (if necessary)
 	zlog.SetupAppDynamicLogging(cfg.Logging.DynamicPort, cfg.Logging.DynamicLogging)
 
 	// Register the cryptography service
 	v2API := service.NewCryptographyServer(db, cfg)
+	streamAPI := service.NewCryptographyStreamServer(db, cfg)
+	packagesAPI := service.NewCryptographyPackagesServer(db, cfg)
+	filters := grpc.NewIPFilters(cfg, allowedIPs, deniedIPs)
+	adminAPI := service.NewCryptographyAdminServer(db, cfg, filters)
+	// Load the API keys (if requested)
+	var keys *auth.KeyStore
+	if cfg.Auth.Enabled {
+		if keys, err = auth.NewKeyStore(cfg, db); err != nil {
+			return fmt.Errorf("failed to load the api keys: %v", err)
+		}
+	}
 	ctx := context.Background()
+	// Reload the config on SIGHUP (and file changes, if requested), applying what can be changed live
+	go newConfigReloader(source, cfg, filters, keys).run(ctx)
+	// Check the KB health before serving, and keep checking it in the background
+	health := service.NewHealthChecker(db, cfg)
+	health.Check(ctx)
+	go health.Run(ctx)
 	// Start the REST grpc-gateway if requested
 	var srv *http.Server
 	if len(cfg.App.RESTPort) > 0 {
-		if srv, err = rest.RunServer(cfg, ctx, cfg.App.GRPCPort, cfg.App.RESTPort, allowedIPs, deniedIPs, startTLS); err != nil {
+		if srv, err = rest.RunServer(cfg, ctx, cfg.App.GRPCPort, cfg.App.RESTPort, filters, startTLS, health); err != nil {
 			return err
 		}
 	}
 	// Start the gRPC service
-	server, err := grpc.RunServer(cfg, v2API, cfg.App.GRPCPort, allowedIPs, deniedIPs, startTLS, version)
+	server, err := grpc.RunServer(cfg, v2API, streamAPI, packagesAPI, adminAPI, health.HealthServer(), cfg.App.GRPCPort, filters, keys, startTLS, version)
 	if err != nil {
 		return err
 	}
@@ -17,16 +17,22 @@
 package cmd
 
 import (
+	"context"
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"strings"
 
 	_ "github.com/lib/pq"
+	gd "github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
 	_ "modernc.org/sqlite"
+	"scanoss.com/cryptography/pkg/models"
 )
 
 type DetectionsDefinition struct {
@@ -40,28 +46,58 @@ type DetectionsDefinition struct {
 	Tags        []string `json:"tags,omitempty"`
 }
 
+// loadDetectionsDefinitions reads the crypto library definitions (and their detection keywords) from a JSON file.
+func loadDetectionsDefinitions(path string) ([]DetectionsDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var defs []DetectionsDefinition
+	if err = json.Unmarshal(data, &defs); err != nil {
+		return nil, fmt.Errorf("failed to parse definitions %v: %v", path, err)
+	}
+	return defs, nil
+}
+
+// normalize escapes the single quotes of a value, so it can be used in a SQL string literal.
 func normalize(str string) string {
-	str = strings.ReplaceAll(str, "'", "")
-	str = strings.ReplaceAll(str, "\"", "\\\"")
-	return str
+	return strings.ReplaceAll(str, "'", "''")
 }
 
-// SupportTools runs the gRPC Cryptography Server.
-func SupportTools() error {
-	var defJSONPath string
-	var createLibrariesTable string
+// toolsOptions holds the command line options of the support tools.
+type toolsOptions struct {
+	defJSONPath string
+	createTable string
+	upsert      bool
+	dryRun      bool
+	prune       bool
+	jsonConfig  string
+	envConfig   string
+	dbDriver    string
+	dbDsn       string
+	debug       bool
+}
 
-	flag.StringVar(&defJSONPath, "json-definition", "", "Defines a json file path")
-	flag.StringVar(&createLibrariesTable, "create-table", "", "Defines a table to be created")
+// SupportTools runs the Cryptography support tools (i.e. loading the crypto library definitions).
+func SupportTools() error {
+	var opts toolsOptions
+	flag.StringVar(&opts.defJSONPath, "json-definition", "", "Defines a json file path")
+	flag.StringVar(&opts.createTable, "create-table", "", "Defines a table to be created")
+	flag.BoolVar(&opts.upsert, "upsert", false, "Insert/update the definitions into the crypto_libraries table of the configured database")
+	flag.BoolVar(&opts.dryRun, "dry-run", false, "Report the changes an upsert would make, without applying them")
+	flag.BoolVar(&opts.prune, "prune", false, "Remove the crypto libraries missing from the definitions when upserting")
+	flag.StringVar(&opts.jsonConfig, "json-config", "", "Application JSON config")
+	flag.StringVar(&opts.envConfig, "env-config", "", "Application dot-ENV config")
+	flag.StringVar(&opts.dbDriver, "db-driver", "", "Database driver (sqlite or postgres). Overrides the config")
+	flag.StringVar(&opts.dbDsn, "db-dsn", "", "Database DSN (i.e. SQLite file or Postgres URL). Overrides the config")
+	flag.BoolVar(&opts.debug, "debug", false, "Enable debug")
 	flag.Parse()
-	var defs []DetectionsDefinition
 
-	if createLibrariesTable != "" {
-		data, errFile := os.ReadFile(defJSONPath)
-		if errFile != nil {
-			fmt.Printf("%+v", errFile)
-		}
-		err := json.Unmarshal(data, &defs)
+	if opts.upsert || opts.dryRun {
+		return upsertDefinitions(opts, os.Stdout)
+	}
+	if opts.createTable != "" {
+		defs, err := loadDetectionsDefinitions(opts.defJSONPath)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -73,6 +109,11 @@ func SupportTools() error {
 		url TEXT NOT NULL,
 		category TEXT NOT NULL,
 		purl TEXT NOT NULL
+	);
+	CREATE TABLE if not exists "crypto_library_tags" (
+		library_id TEXT NOT NULL,
+		tag TEXT NOT NULL,
+		PRIMARY KEY (library_id, tag)
 	);`
 
 		for _, def := range defs {
@@ -80,9 +121,73 @@ func SupportTools() error {
 				normalize(def.ID), normalize(def.Name), normalize(def.Description), normalize(def.URL),
 				normalize(def.Category), normalize(def.Purl))
 			sqlContent += line
+			for _, tag := range models.NormalizeTags(def.Tags) {
+				sqlContent += fmt.Sprintf("\nINSERT INTO crypto_library_tags VALUES('%s','%s');", normalize(def.ID), normalize(tag))
+			}
 		}
 		fmt.Print(sqlContent)
 	}
 	// zlog.S.Infof("Starting SCANOSS Cryptography Service: %v", strings.TrimSpace(version))
 	return nil
 }
+
+// upsertDefinitions loads the crypto library definitions into the configured database, in a single transaction,
+// and reports the rows inserted, updated and removed. Nothing is changed in dry run mode.
+func upsertDefinitions(opts toolsOptions, w io.Writer) error {
+	if len(opts.defJSONPath) == 0 {
+		return errors.New("no definitions file specified (-json-definition)")
+	}
+	defs, err := loadDetectionsDefinitions(opts.defJSONPath)
+	if err != nil {
+		return err
+	}
+	if err = setupCliLogger(opts.debug); err != nil {
+		return err
+	}
+	defer zlog.SyncZap()
+	cfg, err := loadConfig(opts.jsonConfig, opts.envConfig, opts.debug)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %v", err)
+	}
+	db, err := openKB(cfg, opts.dbDriver, opts.dbDsn)
+	if err != nil {
+		return err
+	}
+	defer gd.CloseDBConnection(db)
+	libraries := make([]models.CryptoLibrary, 0, len(defs))
+	for _, def := range defs {
+		libraries = append(libraries, models.CryptoLibrary{ID: def.ID, Name: def.Name, Description: def.Description,
+			URL: def.URL, Category: def.Category, Purl: def.Purl, Tags: def.Tags})
+	}
+	changes, err := models.NewCryptoLibrariesModel(context.Background(), zlog.S, db).UpsertCryptoLibraries(libraries, opts.prune, opts.dryRun)
+	if err != nil {
+		return err
+	}
+	printUpsertReport(w, changes)
+	return nil
+}
+
+// printUpsertReport prints a diff of the crypto libraries changed by an upsert, followed by the row counts.
+func printUpsertReport(w io.Writer, changes models.CryptoLibrariesChanges) {
+	for _, c := range changes.Inserted {
+		_, _ = fmt.Fprintf(w, "+ %s\n", c.ID)
+	}
+	for _, c := range changes.Updated {
+		_, _ = fmt.Fprintf(w, "~ %s\n", c.ID)
+		for _, f := range c.Fields {
+			_, _ = fmt.Fprintf(w, "    %s: %q -> %q\n", f.Field, f.Old, f.New)
+		}
+	}
+	for _, c := range changes.Removed {
+		if c.Detections > 0 {
+			_, _ = fmt.Fprintf(w, "- %s (and %d component detections)\n", c.ID, c.Detections)
+		} else {
+			_, _ = fmt.Fprintf(w, "- %s\n", c.ID)
+		}
+	}
+	_, _ = fmt.Fprintf(w, "Inserted: %d, Updated: %d, Removed: %d, Unchanged: %d\n",
+		len(changes.Inserted), len(changes.Updated), len(changes.Removed), changes.Unchanged)
+	if changes.DryRun {
+		_, _ = fmt.Fprintln(w, "Dry run: no changes were applied")
+	}
+}
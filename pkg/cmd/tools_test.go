@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+func TestNormalize(t *testing.T) {
+	if got := normalize(`Bouncy Castle's "lightweight" API`); got != `Bouncy Castle''s "lightweight" API` {
+		t.Errorf("normalize() = %v", got)
+	}
+}
+
+func TestUpsertDefinitions(t *testing.T) {
+	dbFile := setupCliDB(t)
+	definitions := filepath.Join(t.TempDir(), "definitions.json")
+	err := os.WriteFile(definitions, []byte(`[
+		{"id": "library/boringssl", "name": "BoringSSL", "description": "Google's fork of OpenSSL", "keywords": ["openssl/base.h"],
+		 "url": "https://boringssl.googlesource.com/boringssl/", "category": "library", "purl": "pkg:googlesource/boringssl"},
+		{"id": "library/libsodium", "name": "libsodium", "description": "Sodium crypto library", "keywords": ["sodium.h"],
+		 "url": "https://libsodium.org", "category": "library", "purl": "pkg:github/jedisct1/libsodium"}]`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := toolsOptions{defJSONPath: definitions, dbDriver: "sqlite", dbDsn: dbFile, dryRun: true, prune: true}
+	var out bytes.Buffer
+	if err = upsertDefinitions(opts, &out); err != nil {
+		t.Fatalf("upsertDefinitions() unexpected error: %v", err)
+	}
+	for _, want := range []string{"+ library/libsodium", "~ library/boringssl", "- protocol/tls (and 3 component detections)", "Dry run"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("upsertDefinitions() output missing %q:\n%s", want, out.String())
+		}
+	}
+	db, err := sqlx.Connect("sqlite", dbFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer models.CloseDB(db)
+	var count int
+	if err = db.Get(&count, "SELECT COUNT(*) FROM crypto_libraries WHERE id = 'library/libsodium'"); err != nil || count != 0 {
+		t.Errorf("upsertDefinitions() dry run expected no changes, got %v, %v", count, err)
+	}
+
+	opts.dryRun, opts.upsert = false, true
+	out.Reset()
+	if err = upsertDefinitions(opts, &out); err != nil {
+		t.Fatalf("upsertDefinitions() unexpected error: %v", err)
+	}
+	var description string
+	if err = db.Get(&description, "SELECT description FROM crypto_libraries WHERE id = 'library/boringssl'"); err != nil ||
+		description != "Google's fork of OpenSSL" {
+		t.Errorf("upsertDefinitions() stored description %q, %v", description, err)
+	}
+	if err = db.Get(&count, "SELECT COUNT(*) FROM crypto_libraries"); err != nil || count != 2 {
+		t.Errorf("upsertDefinitions() expected the other libraries to be pruned, got %v rows, %v", count, err)
+	}
+	if err = db.Get(&count, "SELECT COUNT(*) FROM component_crypto_library WHERE det_id = 'protocol/tls'"); err != nil || count != 0 {
+		t.Errorf("upsertDefinitions() expected the detections of pruned libraries to be dropped, got %v, %v", count, err)
+	}
+	if err = upsertDefinitions(toolsOptions{dbDriver: "sqlite", dbDsn: dbFile, upsert: true}, &out); err == nil {
+		t.Errorf("upsertDefinitions() expected an error without definitions")
+	}
+}
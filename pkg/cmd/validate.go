@@ -0,0 +1,297 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	purlhelper "github.com/scanoss/go-purl-helper/pkg"
+)
+
+const validateUsage = `Usage: scanoss-cryptography-tools validate [options] -json-definition <file>
+
+Validates a detection definitions file, reporting duplicate IDs, IDs not matching their category,
+missing or placeholder fields, invalid purls and URLs, and empty keyword lists.
+
+Exits with %d if any errors (or warnings, with -strict) are found.
+
+Options:
+`
+
+// Rules reported by the definitions validator.
+const (
+	ruleDuplicateID      = "duplicate-id"
+	ruleInvalidID        = "invalid-id"
+	ruleCategoryMismatch = "category-mismatch"
+	ruleUnknownCategory  = "unknown-category"
+	ruleMissingField     = "missing-field"
+	rulePlaceholder      = "placeholder"
+	ruleWhitespace       = "whitespace"
+	ruleInvalidPurl      = "invalid-purl"
+	ruleInvalidURL       = "invalid-url"
+	ruleNoKeywords       = "no-keywords"
+	ruleEmptyKeyword     = "empty-keyword"
+	ruleDuplicateKeyword = "duplicate-keyword"
+)
+
+// Severities of the definitions validator issues.
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+)
+
+// defaultDefinitionCategories lists the categories a detection definition can belong to.
+const defaultDefinitionCategories = "library,sdk,framework,protocol"
+
+var (
+	definitionIDPattern  = regexp.MustCompile(`^[a-z0-9]+/[a-z0-9][a-z0-9._+-]*$`) // <category>/<name>
+	placeholderPattern   = regexp.MustCompile(`(?i)^(tbd|todo|n/a|this_is_the_\w+)$`)
+	definitionURLSchemes = []string{"http", "https"}
+)
+
+// definitionIssue is a problem found in one of the detection definitions.
+type definitionIssue struct {
+	Index    int    `json:"index"` // Position of the definition in the file
+	ID       string `json:"id"`
+	Field    string `json:"field"`
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// definitionsReport is the result of validating a detection definitions file.
+type definitionsReport struct {
+	File        string            `json:"file"`
+	Definitions int               `json:"definitions"`
+	Errors      int               `json:"errors"`
+	Warnings    int               `json:"warnings"`
+	Valid       bool              `json:"valid"`
+	Issues      []definitionIssue `json:"issues"`
+}
+
+// exitError fails the tool with ExitViolations if the definitions are not valid.
+func (r definitionsReport) exitError() error {
+	if r.Valid {
+		return nil
+	}
+	return &ExitError{Code: ExitViolations, Err: fmt.Errorf("definitions validation failed with %d error(s) and %d warning(s)", r.Errors, r.Warnings)}
+}
+
+// definitionsValidator checks detection definitions against the rules above.
+type definitionsValidator struct {
+	categories []string
+	report     definitionsReport
+}
+
+// RunValidate validates a detection definitions file with the given arguments, printing a report of the issues found.
+func RunValidate(args []string, stdout, stderr io.Writer) error {
+	var file, categories, format string
+	var strict bool
+	fs := flag.NewFlagSet("scanoss-cryptography-tools validate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&file, "json-definition", "", "Detection definitions JSON file to validate")
+	fs.StringVar(&categories, "categories", defaultDefinitionCategories, "Comma separated list of the valid categories")
+	fs.BoolVar(&strict, "strict", false, "Fail on warnings as well as errors")
+	fs.StringVar(&format, "format", "json", "Output format (json or table)")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, validateUsage, ExitViolations)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if len(file) == 0 {
+		fs.Usage()
+		return errors.New("no definitions file specified")
+	}
+	if format != "json" && format != "table" {
+		return fmt.Errorf("unsupported output format: %v", format)
+	}
+	defs, err := loadDetectionsDefinitions(file)
+	if err != nil {
+		return err
+	}
+	report := validateDefinitions(defs, splitList(categories), strict)
+	report.File = file
+	if format == "table" {
+		tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+		printDefinitionsReport(tw, report)
+		err = tw.Flush()
+	} else {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(report)
+	}
+	if err != nil {
+		return err
+	}
+	return report.exitError()
+}
+
+// validateDefinitions checks every definition, returning a report of the issues found.
+// The definitions are valid if there are no errors, nor warnings when strict.
+func validateDefinitions(defs []DetectionsDefinition, categories []string, strict bool) definitionsReport {
+	v := definitionsValidator{categories: categories, report: definitionsReport{Definitions: len(defs), Issues: []definitionIssue{}}}
+	seen := make(map[string]int, len(defs))
+	for i, def := range defs {
+		id := strings.ToLower(strings.TrimSpace(def.ID))
+		if first, found := seen[id]; found && len(id) > 0 {
+			v.add(i, def, "id", ruleDuplicateID, severityError, fmt.Sprintf("id already defined by definition %d", first))
+		} else {
+			seen[id] = i
+		}
+		v.checkID(i, def)
+		v.checkText(i, def, "name", def.Name)
+		v.checkText(i, def, "description", def.Description)
+		v.checkLink(i, def, "url", def.URL, validDefinitionURL)
+		v.checkLink(i, def, "purl", def.Purl, validDefinitionPurl)
+		v.checkKeywords(i, def)
+	}
+	v.report.Valid = v.report.Errors == 0 && (!strict || v.report.Warnings == 0)
+	return v.report
+}
+
+// add records an issue of a definition.
+func (v *definitionsValidator) add(index int, def DetectionsDefinition, field, rule, severity, message string) {
+	if severity == severityError {
+		v.report.Errors++
+	} else {
+		v.report.Warnings++
+	}
+	v.report.Issues = append(v.report.Issues, definitionIssue{Index: index, ID: def.ID, Field: field, Rule: rule, Severity: severity, Message: message})
+}
+
+// checkID makes sure the ID is a lower case <category>/<name> matching a known category.
+func (v *definitionsValidator) checkID(index int, def DetectionsDefinition) {
+	validCategory := v.checkText(index, def, "category", def.Category)
+	if validCategory && !slices.Contains(v.categories, def.Category) {
+		v.add(index, def, "category", ruleUnknownCategory, severityError,
+			fmt.Sprintf("category %q is not one of: %s", def.Category, strings.Join(v.categories, ", ")))
+	}
+	switch {
+	case len(strings.TrimSpace(def.ID)) == 0:
+		v.add(index, def, "id", ruleMissingField, severityError, "id is required")
+	case !definitionIDPattern.MatchString(def.ID):
+		v.add(index, def, "id", ruleInvalidID, severityError, "id must be a lower case <category>/<name>")
+	case validCategory:
+		if prefix, _, _ := strings.Cut(def.ID, "/"); prefix != def.Category {
+			v.add(index, def, "category", ruleCategoryMismatch, severityError,
+				fmt.Sprintf("id prefix %q does not match category %q", prefix, def.Category))
+		}
+	}
+}
+
+// checkText makes sure a required text field is set, reporting whether it is valid.
+func (v *definitionsValidator) checkText(index int, def DetectionsDefinition, field, value string) bool {
+	trimmed := strings.TrimSpace(value)
+	switch {
+	case len(trimmed) == 0:
+		v.add(index, def, field, ruleMissingField, severityError, field+" is required")
+		return false
+	case placeholderPattern.MatchString(trimmed):
+		v.add(index, def, field, rulePlaceholder, severityError, fmt.Sprintf("%s has a placeholder value %q", field, trimmed))
+		return false
+	case trimmed != value:
+		v.add(index, def, field, ruleWhitespace, severityWarning, field+" has leading or trailing whitespace")
+	}
+	return true
+}
+
+// checkLink makes sure an optional URL or purl field is valid, if set.
+func (v *definitionsValidator) checkLink(index int, def DetectionsDefinition, field, value string, valid func(string) error) {
+	if len(strings.TrimSpace(value)) == 0 {
+		v.add(index, def, field, ruleMissingField, severityWarning, field+" is not set")
+		return
+	}
+	if err := valid(value); err != nil {
+		rule := ruleInvalidURL
+		if field == "purl" {
+			rule = ruleInvalidPurl
+		}
+		v.add(index, def, field, rule, severityError, fmt.Sprintf("%s %q is not valid: %v", field, value, err))
+	}
+}
+
+// checkKeywords makes sure the definition has keywords to detect it by, without blank or repeated entries.
+func (v *definitionsValidator) checkKeywords(index int, def DetectionsDefinition) {
+	if len(def.Keywords) == 0 {
+		v.add(index, def, "keywords", ruleNoKeywords, severityError, "at least one keyword is required")
+		return
+	}
+	seen := make(map[string]bool, len(def.Keywords))
+	for _, k := range def.Keywords {
+		keyword := strings.ToLower(strings.TrimSpace(k))
+		switch {
+		case len(keyword) == 0:
+			v.add(index, def, "keywords", ruleEmptyKeyword, severityError, "keywords cannot be blank")
+		case seen[keyword]:
+			v.add(index, def, "keywords", ruleDuplicateKeyword, severityWarning, fmt.Sprintf("keyword %q is repeated", k))
+		}
+		seen[keyword] = true
+	}
+}
+
+// validDefinitionURL checks the value is an absolute http(s) URL.
+func validDefinitionURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(definitionURLSchemes, u.Scheme) || len(u.Host) == 0 {
+		return errors.New("an absolute http(s) URL is required")
+	}
+	return nil
+}
+
+// validDefinitionPurl checks the value is a purl with a type and name.
+func validDefinitionPurl(value string) error {
+	purl, err := purlhelper.PurlFromString(value)
+	if err != nil {
+		return err
+	}
+	if len(purl.Type) == 0 || len(purl.Name) == 0 {
+		return errors.New("a purl type and name are required")
+	}
+	return nil
+}
+
+func printDefinitionsReport(w io.Writer, report definitionsReport) {
+	if len(report.Issues) > 0 {
+		_, _ = fmt.Fprintln(w, "INDEX\tID\tSEVERITY\tRULE\tMESSAGE")
+		for _, issue := range report.Issues {
+			_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", issue.Index, orNone(issue.ID), issue.Severity, issue.Rule, issue.Message)
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+	result := "PASSED"
+	if !report.Valid {
+		result = "FAILED"
+	}
+	_, _ = fmt.Fprintf(w, "Validation %s: %d definitions, %d error(s), %d warning(s)\n", result, report.Definitions, report.Errors, report.Warnings)
+}
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestValidateDefinitions(t *testing.T) {
+	valid := DetectionsDefinition{ID: "library/boringssl", Name: "BoringSSL", Description: "Google fork of OpenSSL",
+		Keywords: []string{"openssl/base.h"}, URL: "https://boringssl.googlesource.com/boringssl/", Category: "library", Purl: "pkg:googlesource/boringssl"}
+	categories := splitList(defaultDefinitionCategories)
+	tests := []struct {
+		name      string
+		change    func(d *DetectionsDefinition)
+		strict    bool
+		wantRules []string
+		wantValid bool
+	}{
+		{name: "valid", change: func(d *DetectionsDefinition) {}, wantValid: true},
+		{name: "category mismatch", change: func(d *DetectionsDefinition) { d.ID = "protocol/https" }, wantRules: []string{ruleCategoryMismatch}},
+		{name: "unknown category", change: func(d *DetectionsDefinition) { d.Category, d.ID = "tool", "tool/boringssl" }, wantRules: []string{ruleUnknownCategory}},
+		{name: "invalid id", change: func(d *DetectionsDefinition) { d.ID = "BoringSSL" }, wantRules: []string{ruleInvalidID}},
+		{name: "placeholders", change: func(d *DetectionsDefinition) { d.Description, d.URL, d.Purl = "TBD", "www.example.com", "TBD" },
+			wantRules: []string{rulePlaceholder, ruleInvalidURL, ruleInvalidPurl}},
+		{name: "blank name", change: func(d *DetectionsDefinition) { d.Name = " " }, wantRules: []string{ruleMissingField}},
+		{name: "no keywords", change: func(d *DetectionsDefinition) { d.Keywords = nil }, wantRules: []string{ruleNoKeywords}},
+		{name: "blank keyword", change: func(d *DetectionsDefinition) { d.Keywords = []string{"a", " "} }, wantRules: []string{ruleEmptyKeyword}},
+		{name: "warnings", change: func(d *DetectionsDefinition) { d.Name, d.Purl = "BoringSSL ", "" },
+			wantRules: []string{ruleWhitespace, ruleMissingField}, wantValid: true},
+		{name: "strict warnings", change: func(d *DetectionsDefinition) { d.Keywords = []string{"a", "A"} }, strict: true,
+			wantRules: []string{ruleDuplicateKeyword}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def := valid
+			tt.change(&def)
+			report := validateDefinitions([]DetectionsDefinition{def}, categories, tt.strict)
+			var rules []string
+			for _, issue := range report.Issues {
+				rules = append(rules, issue.Rule)
+			}
+			if report.Valid != tt.wantValid || !slices.Equal(rules, tt.wantRules) {
+				t.Errorf("validateDefinitions() = %v %v, want %v %v", report.Valid, rules, tt.wantValid, tt.wantRules)
+			}
+		})
+	}
+	report := validateDefinitions([]DetectionsDefinition{valid, valid}, categories, false)
+	if report.Valid || len(report.Issues) != 1 || report.Issues[0].Rule != ruleDuplicateID || report.Issues[0].Index != 1 {
+		t.Errorf("validateDefinitions() expected a duplicate id, got %+v", report)
+	}
+}
+
+func TestRunValidate(t *testing.T) {
+	dir := t.TempDir()
+	definitions := filepath.Join(dir, "definitions.json")
+	err := os.WriteFile(definitions, []byte(`[{"id": "library/libsodium", "name": "libsodium", "description": "Sodium crypto library",
+		"keywords": ["sodium.h"], "url": "https://libsodium.org", "category": "library", "purl": "pkg:github/jedisct1/libsodium"},
+		{"id": "protocol/quic", "name": " ", "description": "", "keywords": ["quic"], "url": " ", "category": "protocol", "purl": "TBD"}]`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	err = RunValidate([]string{"-json-definition", definitions}, &stdout, &stderr)
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != ExitViolations {
+		t.Fatalf("RunValidate() error = %v, want exit code %d", err, ExitViolations)
+	}
+	var report definitionsReport
+	if err = json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatalf("RunValidate() output is not valid JSON: %v", err)
+	}
+	if report.Definitions != 2 || report.Errors != 3 || report.Warnings != 1 || report.Issues[0].ID != "protocol/quic" {
+		t.Errorf("RunValidate() unexpected report: %+v", report)
+	}
+	stdout.Reset()
+	if err = RunValidate([]string{"-format", "table", "-categories", "library", "-json-definition", definitions}, &stdout, &stderr); err == nil {
+		t.Errorf("RunValidate() expected an error for an unknown category")
+	}
+	if !bytes.Contains(stdout.Bytes(), []byte(ruleUnknownCategory)) {
+		t.Errorf("RunValidate() unexpected table output:\n%s", stdout.String())
+	}
+	if err = RunValidate([]string{"-json-definition", filepath.Join(dir, "missing.json")}, &stdout, &stderr); err == nil || errors.As(err, &exitErr) {
+		t.Errorf("RunValidate() expected a plain error for a missing file, got %v", err)
+	}
+}
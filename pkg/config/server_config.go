@@ -45,6 +45,9 @@ type ServerConfig struct {
 	Telemetry struct {
 		Enabled      bool   `env:"OTEL_ENABLED"`       // true/false
 		OltpExporter string `env:"OTEL_EXPORTER_OLTP"` // OTEL OLTP exporter (default 0.0.0.0:4317)
+		// Serve the metrics on /metrics for Prometheus to scrape, with or without the OLTP exporter
+		PrometheusEnabled bool   `env:"OTEL_PROMETHEUS_ENABLED"` // true/false
+		PrometheusPort    string `env:"OTEL_PROMETHEUS_PORT"`    // host:port (default 0.0.0.0:9464)
 	}
 
 	Database struct {
@@ -55,7 +58,35 @@ type ServerConfig struct {
 		Schema  string `env:"DB_SCHEMA"`
 		SslMode string `env:"DB_SSL_MODE"` // enable/disable
 		Dsn     string `env:"DB_DSN"`
-		Trace   bool   `env:"DB_TRACE"` // true/false
+		Trace   bool   `env:"DB_TRACE"`   // true/false
+		Workers int    `env:"DB_WORKERS"` // Number of concurrent workers (pooled connections) used to process the components of a request
+	}
+	Cache struct {
+		Enabled bool `env:"KB_CACHE_ENABLED"` // true/false
+		Size    int  `env:"KB_CACHE_SIZE"`    // Maximum number of entries cached per KB table
+		TTL     int  `env:"KB_CACHE_TTL"`     // Time (in seconds) a cached entry is valid for
+	}
+	Admin struct {
+		Enabled bool   `env:"ADMIN_ENABLED"` // true/false
+		Token   string `env:"ADMIN_TOKEN"`   // Bearer token required to call the admin service
+	}
+	Auth struct {
+		Enabled  bool   `env:"AUTH_ENABLED"`   // true/false
+		KeysFile string `env:"AUTH_KEYS_FILE"` // JSON file of hashed API keys and their scopes (the api_keys table is used if not set)
+	}
+	Limits struct {
+		RateLimit      float64 `env:"LIMITS_RATE"`             // Requests per second allowed for each client (API key or IP). 0 disables rate limiting
+		Burst          int     `env:"LIMITS_BURST"`            // Requests a client can make in a burst above the rate (defaults to the rate)
+		MaxComponents  int     `env:"LIMITS_MAX_COMPONENTS"`   // Maximum number of components (purls or hashes) in a request. 0 for no limit
+		MaxRequestSize int     `env:"LIMITS_MAX_REQUEST_SIZE"` // Maximum size of a request message (bytes). 0 for the gRPC default (4MB)
+	}
+	Health struct {
+		Interval int `env:"HEALTH_INTERVAL"` // Time (in seconds) between background KB health checks. 0 only checks at startup
+		Timeout  int `env:"HEALTH_TIMEOUT"`  // Time (in seconds) allowed for each round of KB health checks
+	}
+	Reload struct {
+		Watch    bool `env:"RELOAD_WATCH"`    // Reload when the config, IP filter or API key files change (SIGHUP always reloads)
+		Interval int  `env:"RELOAD_INTERVAL"` // Time (in seconds) between checks for modified files
 	}
 	TLS struct {
 		CertFile string `env:"CRYPTO_TLS_CERT"` // TLS Certificate
@@ -101,8 +132,18 @@ func setServerConfigDefaults(cfg *ServerConfig) {
 	cfg.Database.Schema = "scanoss"
 	cfg.Database.SslMode = "disable"
 	cfg.Database.Trace = false
+	cfg.Database.Workers = 4
+	cfg.Cache.Enabled = false
+	cfg.Cache.Size = 10000
+	cfg.Cache.TTL = 3600
+	cfg.Health.Interval = 30
+	cfg.Health.Timeout = 5
+	cfg.Reload.Watch = false
+	cfg.Reload.Interval = 10
 	cfg.Logging.DynamicLogging = true
 	cfg.Logging.DynamicPort = "localhost:60054"
 	cfg.Telemetry.Enabled = false
 	cfg.Telemetry.OltpExporter = "0.0.0.0:4317" // Default OTEL OLTP gRPC Exporter endpoint
+	cfg.Telemetry.PrometheusEnabled = false
+	cfg.Telemetry.PrometheusPort = "0.0.0.0:9464" // Default Prometheus exporter port
 }
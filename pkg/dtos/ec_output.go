@@ -27,12 +27,13 @@ type ECOutputItem struct {
 }
 
 type ECDetectedItem struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	URL         string `json:"URL,omitempty"`
-	Category    string `json:"category"`
-	Purl        string `json:"purl,omitempty"`
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	URL         string   `json:"URL,omitempty"`
+	Category    string   `json:"category"`
+	Purl        string   `json:"purl,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
 }
 
 type HintsOutput struct {
@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dtos
+
+// HealthOutput reports the liveness and readiness of the service, along with the KB checks behind them.
+type HealthOutput struct {
+	Live      bool          `json:"live"`  // The KB database can be reached
+	Ready     bool          `json:"ready"` // All the KB checks passed, so requests can be served
+	Checks    []HealthCheck `json:"checks"`
+	CheckedAt string        `json:"checked_at,omitempty"` // Time of the last round of checks (RFC 3339)
+}
+
+// HealthCheck holds the result of a single KB health check.
+type HealthCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"` // ok, failed or skipped
+	Message string `json:"message,omitempty"`
+}
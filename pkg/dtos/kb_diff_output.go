@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dtos
+
+// KBDiffOutput reports the changes between two KBs for a list of purls.
+type KBDiffOutput struct {
+	Summary  KBDiffSummary `json:"summary"`
+	Purls    []KBDiffPurl  `json:"purls"`
+	NotFound []string      `json:"not_found,omitempty"` // Purls in neither of the KBs
+}
+
+// KBDiffSummary counts the changes between two KBs.
+type KBDiffSummary struct {
+	Purls           int `json:"purls"`
+	ChangedPurls    int `json:"changed_purls"`
+	NewVersions     int `json:"new_versions"`
+	RemovedVersions int `json:"removed_versions"`
+	ChangedVersions int `json:"changed_versions"`
+	LostData        int `json:"lost_data"`
+}
+
+// KBDiffPurl lists the versions of a purl added, removed or changed in the new KB.
+type KBDiffPurl struct {
+	Purl            string          `json:"purl"`
+	NewVersions     []string        `json:"new_versions,omitempty"`
+	RemovedVersions []string        `json:"removed_versions,omitempty"`
+	Versions        []KBDiffVersion `json:"versions,omitempty"`
+}
+
+// KBDiffVersion lists the algorithms and hints of a version added or removed in the new KB.
+// Versions that had algorithms or hints, but have none in the new KB, are flagged as having lost data.
+type KBDiffVersion struct {
+	Version           string            `json:"version"`
+	AlgorithmsAdded   []CryptoUsageItem `json:"algorithms_added,omitempty"`
+	AlgorithmsRemoved []CryptoUsageItem `json:"algorithms_removed,omitempty"`
+	HintsAdded        []string          `json:"hints_added,omitempty"`
+	HintsRemoved      []string          `json:"hints_removed,omitempty"`
+	LostData          bool              `json:"lost_data,omitempty"`
+}
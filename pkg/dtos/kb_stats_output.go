@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dtos
+
+// KBStatsOutput summarises the contents of the KB.
+type KBStatsOutput struct {
+	Tables        []KBTableStats `json:"tables"`
+	LatestURLDate string         `json:"latest_url_date,omitempty"` // Date of the most recent version loaded
+}
+
+// KBTableStats holds the row count of a KB table.
+type KBTableStats struct {
+	Table string `json:"table"`
+	Rows  int64  `json:"rows"`
+}
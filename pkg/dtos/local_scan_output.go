@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package dtos
+
+// LocalScanOutput holds the cryptography detected in a local source tree.
+type LocalScanOutput struct {
+	Algorithms   []LocalCryptoUsageItem `json:"algorithms"`
+	Hints        []LocalDetectedItem    `json:"hints"`
+	FilesScanned int                    `json:"files_scanned"`
+	FilesSkipped int                    `json:"files_skipped"` // Binary, oversized or unreadable files
+}
+
+// LocalCryptoUsageItem is an algorithm detected in a local source tree, with the evidence found.
+type LocalCryptoUsageItem struct {
+	CryptoUsageItem
+	Matches  int            `json:"matches"`
+	Evidence []ScanEvidence `json:"evidence"`
+}
+
+// LocalDetectedItem is a crypto library, SDK or framework (hint) detected in a local source tree, with the evidence found.
+type LocalDetectedItem struct {
+	ECDetectedItem
+	Matches  int            `json:"matches"`
+	Evidence []ScanEvidence `json:"evidence"`
+}
+
+// ScanEvidence is a keyword match found in a local file.
+type ScanEvidence struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Keyword string `json:"keyword"`
+}
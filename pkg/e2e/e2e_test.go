@@ -0,0 +1,416 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	pb "github.com/scanoss/papi/api/cryptographyv2"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"scanoss.com/cryptography/pkg/api/packagesv2"
+	"scanoss.com/cryptography/pkg/auth"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/models"
+	"scanoss.com/cryptography/pkg/protocol/rest"
+	"scanoss.com/cryptography/pkg/usecase"
+)
+
+// newRequest builds a request for the given RPC, querying the purl and requirement given.
+func newRequest(rpc RPC, purl, requirement string) proto.Message {
+	switch {
+	case rpc.Name == "Echo":
+		return &common.EchoRequest{Message: "hello"}
+	case strings.HasPrefix(rpc.Name, "GetComponents"):
+		return &common.ComponentsRequest{Components: []*common.ComponentRequest{{Purl: purl, Requirement: requirement}}}
+	case strings.HasPrefix(rpc.Name, "GetComponent"):
+		return &common.ComponentRequest{Purl: purl, Requirement: requirement}
+	default:
+		return &common.PurlRequest{Purls: []*common.PurlRequest_Purls{{Purl: purl, Requirement: requirement}}}
+	}
+}
+
+// fixtureRequest returns a request for the given RPC matching the test fixtures.
+func fixtureRequest(rpc RPC) proto.Message {
+	if strings.Contains(rpc.Name, "Hints") {
+		return newRequest(rpc, "pkg:github/pineappleea/pineapple-src", "v5.4.7")
+	}
+	return newRequest(rpc, "pkg:github/scanoss/engine", "v5.4.5")
+}
+
+func TestRPCsMatchGoldenFiles(t *testing.T) {
+	h := Start(t, Options{})
+	for _, rpc := range RPCs {
+		t.Run(rpc.Name, func(t *testing.T) {
+			golden := filepath.Join("tests", "golden", rpc.Name+".json")
+			request := fixtureRequest(rpc)
+			response, trailer, err := h.CallGRPC(context.Background(), rpc, request)
+			if err != nil {
+				t.Fatalf("gRPC %v failed: %v", rpc.Name, err)
+			}
+			AssertGolden(t, golden, response)
+			restResponse, err := h.CallREST(context.Background(), rpc, request)
+			if err != nil {
+				t.Fatalf("REST %v failed: %v", rpc.Name, err)
+			}
+			if restResponse.StatusCode != http.StatusOK {
+				t.Errorf("REST %v status = %v, want %v (%s)", rpc.Name, restResponse.StatusCode, http.StatusOK, restResponse.Body)
+			}
+			if codes := trailer.Get("x-http-code"); len(codes) > 0 && codes[0] != "200" {
+				t.Errorf("gRPC %v x-http-code = %v, want 200", rpc.Name, codes)
+			}
+			decoded, err := restResponse.Decode(rpc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			AssertGolden(t, golden, decoded)
+		})
+	}
+}
+
+// statusResponse is implemented by all the responses of the component endpoints.
+type statusResponse interface {
+	GetStatus() *common.StatusResponse
+}
+
+func TestHTTPCodeTrailers(t *testing.T) {
+	h := Start(t, Options{})
+	tests := []struct {
+		rpc        string
+		purl       string
+		wantCode   int
+		wantStatus common.StatusCode
+	}{
+		{rpc: "GetComponentAlgorithms", purl: "pkg:github/scanoss/engine", wantCode: http.StatusOK, wantStatus: common.StatusCode_SUCCESS},
+		{rpc: "GetComponentAlgorithms", purl: "pkg:github/scanoss/unknown", wantCode: http.StatusOK, wantStatus: common.StatusCode_SUCCEEDED_WITH_WARNINGS},
+		{rpc: "GetComponentAlgorithms", purl: "pkg:githubscanossengine", wantCode: http.StatusBadRequest, wantStatus: common.StatusCode_FAILED},
+		{rpc: "GetComponentsAlgorithms", purl: "pkg:github/scanoss/unknown", wantCode: http.StatusOK, wantStatus: common.StatusCode_SUCCEEDED_WITH_WARNINGS},
+		{rpc: "GetComponentEncryptionHints", purl: "pkg:github/scanoss/unknown", wantCode: http.StatusOK, wantStatus: common.StatusCode_SUCCEEDED_WITH_WARNINGS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rpc+" "+tt.purl, func(t *testing.T) {
+			rpc, _ := LookupRPC(tt.rpc)
+			request := newRequest(rpc, tt.purl, "")
+			response, trailer, err := h.CallGRPC(context.Background(), rpc, request)
+			if err != nil {
+				t.Fatalf("gRPC %v failed: %v", rpc.Name, err)
+			}
+			if got := response.(statusResponse).GetStatus().GetStatus(); got != tt.wantStatus {
+				t.Errorf("gRPC %v status = %v, want %v", rpc.Name, got, tt.wantStatus)
+			}
+			if got := trailer.Get("x-http-code"); len(got) != 1 || got[0] != strconv.Itoa(tt.wantCode) {
+				t.Errorf("gRPC %v x-http-code = %v, want %v", rpc.Name, got, tt.wantCode)
+			}
+			restResponse, err := h.CallREST(context.Background(), rpc, request)
+			if err != nil {
+				t.Fatalf("REST %v failed: %v", rpc.Name, err)
+			}
+			if restResponse.StatusCode != tt.wantCode {
+				t.Errorf("REST %v status = %v, want %v (%s)", rpc.Name, restResponse.StatusCode, tt.wantCode, restResponse.Body)
+			}
+			decoded, err := restResponse.Decode(rpc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := decoded.(statusResponse).GetStatus().GetStatus(); got != tt.wantStatus {
+				t.Errorf("REST %v status = %v, want %v", rpc.Name, got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestHintTagsMetadata(t *testing.T) {
+	h := Start(t, Options{})
+	rpc, _ := LookupRPC("GetComponentEncryptionHints")
+	request := newRequest(rpc, "pkg:github/pineappleea/pineapple-src", "v5.4.7")
+	all, _, err := h.CallGRPC(context.Background(), rpc, request)
+	if err != nil {
+		t.Fatalf("gRPC %v failed: %v", rpc.Name, err)
+	}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-hint-tags", "fips")
+	response, _, err := h.CallGRPC(ctx, rpc, request)
+	if err != nil {
+		t.Fatalf("gRPC %v failed: %v", rpc.Name, err)
+	}
+	hints := response.(*pb.ComponentEncryptionHintsResponse).GetComponent().GetHints()
+	if allHints := all.(*pb.ComponentEncryptionHintsResponse).GetComponent().GetHints(); len(hints) == 0 || len(hints) >= len(allHints) {
+		t.Errorf("gRPC %v returned %d fips hints out of %d", rpc.Name, len(hints), len(allHints))
+	}
+	restResponse, err := h.CallREST(ctx, rpc, request)
+	if err != nil {
+		t.Fatalf("REST %v failed: %v", rpc.Name, err)
+	}
+	decoded, err := restResponse.Decode(rpc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(response, decoded) {
+		t.Errorf("REST %v = %v, want %v", rpc.Name, decoded, response)
+	}
+}
+
+func TestIPFiltering(t *testing.T) {
+	h := Start(t, Options{DeniedIPs: []string{"127.0.0.1", "::1"}})
+	rpc, _ := LookupRPC("GetComponentsAlgorithms")
+	request := fixtureRequest(rpc)
+	if _, _, err := h.CallGRPC(context.Background(), rpc, request); status.Code(err) == codes.OK {
+		t.Errorf("gRPC %v from a denied IP was not rejected", rpc.Name)
+	}
+	restResponse, err := h.CallREST(context.Background(), rpc, request)
+	if err != nil {
+		t.Fatalf("REST %v failed: %v", rpc.Name, err)
+	}
+	if restResponse.StatusCode != http.StatusForbidden {
+		t.Errorf("REST %v from a denied IP status = %v, want %v", rpc.Name, restResponse.StatusCode, http.StatusForbidden)
+	}
+}
+
+func TestAPIKeys(t *testing.T) {
+	keysFile := filepath.Join(t.TempDir(), "api_keys.json")
+	keys := `{"keys": [{"id": "team-hints", "hash": "` + auth.HashKey("hints-key") + `", "scopes": ["*Hints*"]}]}`
+	if err := os.WriteFile(keysFile, []byte(keys), 0o600); err != nil {
+		t.Fatalf("failed to write the keys file: %v", err)
+	}
+	h := Start(t, Options{Configure: func(cfg *myconfig.ServerConfig) {
+		cfg.Auth.Enabled, cfg.Auth.KeysFile = true, keysFile
+	}})
+	tests := []struct {
+		name     string
+		rpc      string
+		md       []string
+		wantCode codes.Code
+		wantHTTP int
+	}{
+		{name: "api key", rpc: "GetComponentsEncryptionHints", md: []string{"x-api-key", "hints-key"}, wantCode: codes.OK, wantHTTP: http.StatusOK},
+		{name: "bearer", rpc: "GetComponentEncryptionHints", md: []string{"authorization", "Bearer hints-key"}, wantCode: codes.OK, wantHTTP: http.StatusOK},
+		{name: "missing key", rpc: "GetComponentsEncryptionHints", wantCode: codes.Unauthenticated, wantHTTP: http.StatusUnauthorized},
+		{name: "invalid key", rpc: "GetComponentsEncryptionHints", md: []string{"x-api-key", "wrong"}, wantCode: codes.Unauthenticated,
+			wantHTTP: http.StatusUnauthorized},
+		{name: "out of scope", rpc: "GetComponentsAlgorithms", md: []string{"x-api-key", "hints-key"}, wantCode: codes.PermissionDenied,
+			wantHTTP: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpc, _ := LookupRPC(tt.rpc)
+			ctx := metadata.AppendToOutgoingContext(context.Background(), tt.md...)
+			if _, _, err := h.CallGRPC(ctx, rpc, fixtureRequest(rpc)); status.Code(err) != tt.wantCode {
+				t.Errorf("gRPC %v error = %v, want code %v", rpc.Name, err, tt.wantCode)
+			}
+			restResponse, err := h.CallREST(ctx, rpc, fixtureRequest(rpc))
+			if err != nil {
+				t.Fatalf("REST %v failed: %v", rpc.Name, err)
+			}
+			if restResponse.StatusCode != tt.wantHTTP {
+				t.Errorf("REST %v status = %v, want %v (%s)", rpc.Name, restResponse.StatusCode, tt.wantHTTP, restResponse.Body)
+			}
+		})
+	}
+}
+
+func TestAPIKeysBearerREST(t *testing.T) {
+	keysFile := filepath.Join(t.TempDir(), "api_keys.json")
+	keys := `{"keys": [{"id": "team-hints", "hash": "` + auth.HashKey("hints-key") + `", "scopes": ["*Hints*"]}]}`
+	if err := os.WriteFile(keysFile, []byte(keys), 0o600); err != nil {
+		t.Fatalf("failed to write the keys file: %v", err)
+	}
+	h := Start(t, Options{Configure: func(cfg *myconfig.ServerConfig) {
+		cfg.Auth.Enabled, cfg.Auth.KeysFile = true, keysFile
+	}})
+	rpc, _ := LookupRPC("GetComponentsEncryptionHints")
+	body, err := protojson.Marshal(fixtureRequest(rpc))
+	if err != nil {
+		t.Fatalf("failed to marshal the request: %v", err)
+	}
+	// A plain HTTP Authorization header, rather than the Grpc-Metadata- prefixed one
+	for authorization, wantHTTP := range map[string]int{"Bearer hints-key": http.StatusOK, "Bearer wrong": http.StatusUnauthorized} {
+		req, err := http.NewRequestWithContext(context.Background(), rpc.HTTPMethod, h.RESTURL+rpc.Path, bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", authorization)
+		resp, err := h.client.Do(req)
+		if err != nil {
+			t.Fatalf("REST %v failed: %v", rpc.Name, err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != wantHTTP {
+			t.Errorf("REST %v with %q status = %v, want %v", rpc.Name, authorization, resp.StatusCode, wantHTTP)
+		}
+	}
+}
+
+func TestRequestLimits(t *testing.T) {
+	keysFile := filepath.Join(t.TempDir(), "api_keys.json")
+	keys := `{"keys": [{"id": "team-a", "hash": "` + auth.HashKey("key-a") + `", "scopes": ["*"]}]}`
+	if err := os.WriteFile(keysFile, []byte(keys), 0o600); err != nil {
+		t.Fatalf("failed to write the keys file: %v", err)
+	}
+	// The API key gives the test its own rate limit bucket, untouched by the readiness checks
+	h := Start(t, Options{Configure: func(cfg *myconfig.ServerConfig) {
+		cfg.Auth.Enabled, cfg.Auth.KeysFile = true, keysFile
+		cfg.Limits.RateLimit, cfg.Limits.Burst, cfg.Limits.MaxComponents, cfg.Limits.MaxRequestSize = 0.001, 4, 3, 2048
+	}})
+	rpc, _ := LookupRPC("GetComponentsAlgorithms")
+	request := func(n int, purl string) *common.ComponentsRequest {
+		r := &common.ComponentsRequest{}
+		for i := 0; i < n; i++ {
+			r.Components = append(r.Components, &common.ComponentRequest{Purl: purl, Requirement: "v5.4.5"})
+		}
+		return r
+	}
+	tests := []struct {
+		name     string
+		request  *common.ComponentsRequest
+		wantCode codes.Code
+		wantHTTP int
+	}{
+		{name: "too many components", request: request(4, "pkg:github/scanoss/engine"), wantCode: codes.ResourceExhausted,
+			wantHTTP: http.StatusTooManyRequests},
+		{name: "request too large", request: request(3, "pkg:github/scanoss/"+strings.Repeat("x", 1000)), wantCode: codes.ResourceExhausted,
+			wantHTTP: http.StatusTooManyRequests},
+		{name: "within limits", request: request(3, "pkg:github/scanoss/engine"), wantCode: codes.OK, wantHTTP: http.StatusOK},
+		{name: "rate limited", request: request(1, "pkg:github/scanoss/engine"), wantCode: codes.ResourceExhausted,
+			wantHTTP: http.StatusTooManyRequests},
+	}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "key-a")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := h.CallGRPC(ctx, rpc, tt.request); status.Code(err) != tt.wantCode {
+				t.Errorf("gRPC %v error = %v, want code %v", rpc.Name, err, tt.wantCode)
+			}
+			restResponse, err := h.CallREST(ctx, rpc, tt.request)
+			if err != nil {
+				t.Fatalf("REST %v failed: %v", rpc.Name, err)
+			}
+			if restResponse.StatusCode != tt.wantHTTP {
+				t.Errorf("REST %v status = %v, want %v (%s)", rpc.Name, restResponse.StatusCode, tt.wantHTTP, restResponse.Body)
+			}
+		})
+	}
+}
+
+// assertHealth checks the gRPC health service and the REST probes report the expected status.
+func assertHealth(t *testing.T, h *Harness, serving bool) {
+	t.Helper()
+	want, wantReady := healthpb.HealthCheckResponse_NOT_SERVING, http.StatusServiceUnavailable
+	if serving {
+		want, wantReady = healthpb.HealthCheckResponse_SERVING, http.StatusOK
+	}
+	client := healthpb.NewHealthClient(h.conn)
+	for _, service := range []string{"", pb.Cryptography_ServiceDesc.ServiceName} {
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil || resp.GetStatus() != want {
+			t.Errorf("gRPC health check of '%v' = %v, %v, want %v", service, resp.GetStatus(), err, want)
+		}
+	}
+	for path, wantStatus := range map[string]int{"/health": http.StatusOK, "/ready": wantReady} {
+		resp, err := h.client.Get(h.RESTURL + path)
+		if err != nil {
+			t.Fatalf("REST %v failed: %v", path, err)
+		}
+		var report dtos.HealthOutput
+		err = json.NewDecoder(resp.Body).Decode(&report)
+		_ = resp.Body.Close()
+		if err != nil || resp.StatusCode != wantStatus || report.Ready != serving || !report.Live || len(report.Checks) != 3 {
+			t.Errorf("REST %v = %v %+v (%v), want status %v", path, resp.StatusCode, report, err, wantStatus)
+		}
+	}
+}
+
+func TestHealthProbes(t *testing.T) {
+	h := Start(t, Options{})
+	assertHealth(t, h, true)
+	// The KB emptying is only picked up by the next round of checks
+	if err := models.RunTestSQL(h.DB, context.Background(), nil, "DELETE FROM component_crypto"); err != nil {
+		t.Fatalf("failed to empty the component_crypto table: %v", err)
+	}
+	assertHealth(t, h, true)
+	if report := h.Health.Check(context.Background()); report.Ready || report.Checks[2].Status != usecase.HealthCheckFailed {
+		t.Errorf("Check() with an empty table = %+v", report)
+	}
+	assertHealth(t, h, false)
+
+	h = Start(t, Options{SQL: []string{"ALTER TABLE versions DROP COLUMN semver"}})
+	assertHealth(t, h, false)
+	if report := h.Health.HealthReport(); report.Checks[1].Status != usecase.HealthCheckFailed ||
+		report.Checks[2].Status != usecase.HealthCheckSkipped {
+		t.Errorf("HealthReport() with an incompatible schema = %+v", report)
+	}
+}
+
+func TestPackagesLookup(t *testing.T) {
+	h := Start(t, Options{Configure: func(c *myconfig.ServerConfig) { c.App.GRPCReflection = true }})
+	ctx := context.Background()
+	request := &packagesv2.PackagesRequest{Hashes: []string{"2c2ae45c192df28dcfd1caab7e2b12db", "not-a-hash"}}
+	response, err := packagesv2.NewCryptographyPackagesClient(h.conn).GetPackagesCryptography(ctx, request)
+	if err != nil {
+		t.Fatalf("gRPC GetPackagesCryptography failed: %v", err)
+	}
+	if len(response.GetPackages()) != 1 || response.GetPackages()[0].GetPackageHash() != request.Hashes[0] ||
+		len(response.GetPackages()[0].GetAlgorithms()) == 0 || response.GetStatus().GetStatus() != common.StatusCode_SUCCEEDED_WITH_WARNINGS {
+		t.Errorf("gRPC GetPackagesCryptography() = %v", response)
+	}
+	rpc := RPC{Name: "GetPackagesCryptography", HTTPMethod: http.MethodPost, Path: rest.PackagesHashesPath,
+		NewResponse: func() proto.Message { return &packagesv2.PackagesResponse{} }}
+	restResponse, err := h.CallREST(ctx, rpc, request)
+	if err != nil {
+		t.Fatalf("REST %v failed: %v", rpc.Name, err)
+	}
+	decoded, err := restResponse.Decode(rpc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restResponse.StatusCode != http.StatusOK || !proto.Equal(decoded, response) {
+		t.Errorf("REST %v = %v %v, want %v", rpc.Name, restResponse.StatusCode, decoded, response)
+	}
+	// The service and its messages can be resolved through reflection
+	stream, err := reflectionpb.NewServerReflectionClient(h.conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		t.Fatalf("failed to open the reflection stream: %v", err)
+	}
+	err = stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: packagesv2.ServiceName}})
+	if err != nil {
+		t.Fatalf("failed to send the reflection request: %v", err)
+	}
+	reflected, err := stream.Recv()
+	if err != nil || len(reflected.GetFileDescriptorResponse().GetFileDescriptorProto()) == 0 {
+		t.Fatalf("reflection of %v = %v, %v", packagesv2.ServiceName, reflected, err)
+	}
+	file := &descriptorpb.FileDescriptorProto{}
+	if err = proto.Unmarshal(reflected.GetFileDescriptorResponse().GetFileDescriptorProto()[0], file); err != nil ||
+		file.GetName() != packagesv2.CryptographyPackagesServiceDesc.Metadata {
+		t.Errorf("reflection of %v returned the file %v, %v", packagesv2.ServiceName, file.GetName(), err)
+	}
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// UpdateGoldenEnv is the environment variable that, when set to true, rewrites the golden files instead of comparing them.
+const UpdateGoldenEnv = "UPDATE_GOLDEN"
+
+// GoldenJSON returns the stable JSON encoding of a response used in golden files.
+// protojson output is deliberately unstable, so it is re-encoded with sorted keys and indentation.
+func GoldenJSON(response proto.Message) ([]byte, error) {
+	data, err := protojson.Marshal(response)
+	if err != nil {
+		return nil, err
+	}
+	var value any
+	if err = json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	if data, err = json.MarshalIndent(value, "", "  "); err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// AssertGolden compares a response with the contents of a golden file.
+// The file is (re)written instead when the UPDATE_GOLDEN environment variable is true.
+func AssertGolden(t testing.TB, file string, response proto.Message) {
+	t.Helper()
+	got, err := GoldenJSON(response)
+	if err != nil {
+		t.Fatalf("failed to encode the response: %v", err)
+	}
+	if update, _ := strconv.ParseBool(os.Getenv(UpdateGoldenEnv)); update {
+		if err = os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatalf("failed to create the golden file directory: %v", err)
+		}
+		if err = os.WriteFile(file, got, 0o600); err != nil {
+			t.Fatalf("failed to write golden file %v: %v", file, err)
+		}
+		return
+	}
+	want, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("failed to read golden file %v (set %v=true to create it): %v", file, UpdateGoldenEnv, err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("response does not match golden file %v\ngot:\n%s\nwant:\n%s", file, got, want)
+	}
+}
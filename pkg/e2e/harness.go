@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package e2e provides an in-process end-to-end test harness for the Cryptography service.
+// It boots the gRPC server and the REST gateway on ephemeral ports against a SQLite KB seeded with
+// the model test fixtures, so tests exercise the gateway mapping, x-http-code trailers and IP filtering.
+package e2e
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	common "github.com/scanoss/papi/api/commonv2"
+	pb "github.com/scanoss/papi/api/cryptographyv2"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	_ "modernc.org/sqlite"
+	"scanoss.com/cryptography/pkg/auth"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/models"
+	mygrpc "scanoss.com/cryptography/pkg/protocol/grpc"
+	"scanoss.com/cryptography/pkg/protocol/rest"
+	"scanoss.com/cryptography/pkg/service"
+)
+
+const startupTimeout = 10 * time.Second
+
+// Options customize the services started by the harness.
+type Options struct {
+	AllowedIPs []string                     // IPs allowed to connect (no filtering if both lists are empty)
+	DeniedIPs  []string                     // IPs denied from connecting
+	SQL        []string                     // Extra statements run after loading the fixtures
+	Configure  func(*myconfig.ServerConfig) // Optional changes to the default server config
+}
+
+// Harness holds a running gRPC server and REST gateway, along with clients to call them.
+type Harness struct {
+	Config   *myconfig.ServerConfig
+	DB       *sqlx.DB
+	GRPCAddr string                 // host:port of the gRPC server
+	RESTURL  string                 // Base URL of the REST gateway
+	Health   *service.HealthChecker // Checked once at startup. Call Check to re-run the KB health checks
+
+	conn       *grpc.ClientConn
+	client     *http.Client
+	grpcServer *grpc.Server
+	restServer *http.Server
+}
+
+// Start boots the gRPC server and REST gateway against a freshly seeded KB, waiting for both to accept requests.
+// Everything is stopped when the test completes.
+func Start(t testing.TB, opts Options) *Harness {
+	t.Helper()
+	if err := zlog.NewSugaredDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	h := &Harness{client: &http.Client{Timeout: startupTimeout}}
+	t.Cleanup(h.stop)
+	kbFile := filepath.Join(t.TempDir(), "kb.sqlite")
+	var err error
+	if h.DB, err = seedKB(kbFile, opts.SQL); err != nil {
+		t.Fatalf("failed to seed the test KB: %v", err)
+	}
+	if h.Config, err = myconfig.NewServerConfig(nil); err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	grpcPort, err := freePort()
+	if err != nil {
+		t.Fatalf("failed to find a free gRPC port: %v", err)
+	}
+	restPort, err := freePort()
+	if err != nil {
+		t.Fatalf("failed to find a free REST port: %v", err)
+	}
+	h.Config.App.GRPCPort, h.Config.App.RESTPort = "127.0.0.1:"+grpcPort, "127.0.0.1:"+restPort
+	h.Config.Database.Driver, h.Config.Database.Dsn = "sqlite", kbFile
+	if opts.Configure != nil {
+		opts.Configure(h.Config)
+	}
+	if err = h.startServers(opts.AllowedIPs, opts.DeniedIPs); err != nil {
+		t.Fatalf("failed to start the services: %v", err)
+	}
+	if h.conn, err = grpc.NewClient(h.Config.App.GRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials())); err != nil {
+		t.Fatalf("failed to connect to %v: %v", h.Config.App.GRPCPort, err)
+	}
+	h.GRPCAddr, h.RESTURL = h.Config.App.GRPCPort, "http://"+h.Config.App.RESTPort
+	if err = h.waitReady(); err != nil {
+		t.Fatalf("services not ready: %v", err)
+	}
+	return h
+}
+
+// startServers starts the gRPC server and the REST gateway, as the server command does.
+func (h *Harness) startServers(allowedIPs, deniedIPs []string) error {
+	filters := mygrpc.NewIPFilters(h.Config, allowedIPs, deniedIPs)
+	var keys *auth.KeyStore
+	var err error
+	if h.Config.Auth.Enabled {
+		if keys, err = auth.NewKeyStore(h.Config, h.DB); err != nil {
+			return err
+		}
+	}
+	h.Health = service.NewHealthChecker(h.DB, h.Config)
+	h.Health.Check(context.Background())
+	if h.restServer, err = rest.RunServer(h.Config, context.Background(), h.Config.App.GRPCPort, h.Config.App.RESTPort,
+		filters, false, h.Health); err != nil {
+		return err
+	}
+	h.grpcServer, err = mygrpc.RunServer(h.Config, service.NewCryptographyServer(h.DB, h.Config),
+		service.NewCryptographyStreamServer(h.DB, h.Config), service.NewCryptographyPackagesServer(h.DB, h.Config),
+		service.NewCryptographyAdminServer(h.DB, h.Config, filters), h.Health.HealthServer(), h.Config.App.GRPCPort, filters, keys,
+		false, "e2e")
+	return err
+}
+
+// waitReady polls the echo endpoint over both transports until they respond.
+// Any HTTP response counts for the gateway, as IP filtering may legitimately reject the request.
+func (h *Harness) waitReady() error {
+	deadline := time.Now().Add(startupTimeout)
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		_, grpcErr := pb.NewCryptographyClient(h.conn).Echo(ctx, &common.EchoRequest{Message: "ready"}, grpc.WaitForReady(true))
+		cancel()
+		var restErr error
+		if resp, err := h.client.Post(h.RESTURL+"/v2/cryptography/echo", "application/json", nil); err != nil {
+			restErr = err
+		} else {
+			_ = resp.Body.Close()
+		}
+		if code := status.Code(grpcErr); code != codes.Unavailable && code != codes.DeadlineExceeded && restErr == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return errors.Join(grpcErr, restErr)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// stop shuts down the services and releases the KB.
+func (h *Harness) stop() {
+	if h.conn != nil {
+		_ = h.conn.Close()
+	}
+	if h.restServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		_ = h.restServer.Shutdown(ctx)
+		cancel()
+	}
+	if h.grpcServer != nil {
+		h.grpcServer.Stop()
+	}
+	if h.DB != nil {
+		models.CloseDB(h.DB)
+	}
+	h.client.CloseIdleConnections()
+}
+
+// seedKB creates a SQLite KB file loaded with the model test fixtures and any extra statements.
+func seedKB(file string, statements []string) (*sqlx.DB, error) {
+	db, err := sqlx.Connect("sqlite", file)
+	if err != nil {
+		return nil, err
+	}
+	if err = models.LoadTestSQLData(db, context.Background(), nil); err != nil {
+		models.CloseDB(db)
+		return nil, err
+	}
+	for _, stm := range statements {
+		if err = models.RunTestSQL(db, context.Background(), nil, stm); err != nil {
+			models.CloseDB(db)
+			return nil, fmt.Errorf("failed to run %q: %v", stm, err)
+		}
+	}
+	return db, nil
+}
+
+// freePort asks the OS for an unused local TCP port.
+func freePort() (string, error) {
+	listen, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer listen.Close()
+	return strconv.Itoa(listen.Addr().(*net.TCPAddr).Port), nil
+}
@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	common "github.com/scanoss/papi/api/commonv2"
+	pb "github.com/scanoss/papi/api/cryptographyv2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// RPC describes an endpoint of the Cryptography service, both on gRPC and the REST gateway.
+type RPC struct {
+	Name        string // gRPC method name, i.e. GetComponentsAlgorithms
+	HTTPMethod  string // REST gateway method
+	Path        string // REST gateway path
+	NewResponse func() proto.Message
+}
+
+// FullMethod returns the full gRPC method name of the RPC.
+func (r RPC) FullMethod() string {
+	return "/" + pb.Cryptography_ServiceDesc.ServiceName + "/" + r.Name
+}
+
+// legacyPath returns the gateway path generated for the legacy (PurlRequest) endpoints, which have no HTTP annotation.
+func legacyPath(name string) string {
+	return "/" + pb.Cryptography_ServiceDesc.ServiceName + "/" + name
+}
+
+// RPCs lists all the endpoints of the Cryptography service, in the order of the service definition.
+var RPCs = []RPC{
+	{"Echo", http.MethodPost, "/v2/cryptography/echo", func() proto.Message { return &common.EchoResponse{} }},
+	{"GetAlgorithms", http.MethodPost, legacyPath("GetAlgorithms"), func() proto.Message { return &pb.AlgorithmResponse{} }},
+	{"GetComponentAlgorithms", http.MethodGet, "/v2/cryptography/algorithms/component",
+		func() proto.Message { return &pb.ComponentAlgorithmsResponse{} }},
+	{"GetComponentsAlgorithms", http.MethodPost, "/v2/cryptography/algorithms/components",
+		func() proto.Message { return &pb.ComponentsAlgorithmsResponse{} }},
+	{"GetAlgorithmsInRange", http.MethodPost, legacyPath("GetAlgorithmsInRange"), func() proto.Message { return &pb.AlgorithmsInRangeResponse{} }},
+	{"GetComponentAlgorithmsInRange", http.MethodGet, "/v2/cryptography/algorithms/range/component",
+		func() proto.Message { return &pb.ComponentAlgorithmsInRangeResponse{} }},
+	{"GetComponentsAlgorithmsInRange", http.MethodPost, "/v2/cryptography/algorithms/range/components",
+		func() proto.Message { return &pb.ComponentsAlgorithmsInRangeResponse{} }},
+	{"GetVersionsInRange", http.MethodPost, legacyPath("GetVersionsInRange"), func() proto.Message { return &pb.VersionsInRangeResponse{} }},
+	{"GetComponentVersionsInRange", http.MethodGet, "/v2/cryptography/algorithms/versions/range/component",
+		func() proto.Message { return &pb.ComponentVersionsInRangeResponse{} }},
+	{"GetComponentsVersionsInRange", http.MethodPost, "/v2/cryptography/algorithms/versions/range/components",
+		func() proto.Message { return &pb.ComponentsVersionsInRangeResponse{} }},
+	{"GetHintsInRange", http.MethodPost, legacyPath("GetHintsInRange"), func() proto.Message { return &pb.HintsInRangeResponse{} }},
+	{"GetComponentHintsInRange", http.MethodGet, "/v2/cryptography/hints/range/component",
+		func() proto.Message { return &pb.ComponentHintsInRangeResponse{} }},
+	{"GetComponentsHintsInRange", http.MethodPost, "/v2/cryptography/hints/range/components",
+		func() proto.Message { return &pb.ComponentsHintsInRangeResponse{} }},
+	{"GetEncryptionHints", http.MethodPost, legacyPath("GetEncryptionHints"), func() proto.Message { return &pb.HintsResponse{} }},
+	{"GetComponentEncryptionHints", http.MethodGet, "/v2/cryptography/hints/component",
+		func() proto.Message { return &pb.ComponentEncryptionHintsResponse{} }},
+	{"GetComponentsEncryptionHints", http.MethodPost, "/v2/cryptography/hints/components",
+		func() proto.Message { return &pb.ComponentsEncryptionHintsResponse{} }},
+}
+
+// LookupRPC returns the Cryptography endpoint with the given gRPC method name.
+func LookupRPC(name string) (RPC, bool) {
+	for _, rpc := range RPCs {
+		if rpc.Name == name {
+			return rpc, true
+		}
+	}
+	return RPC{}, false
+}
+
+// RESTResponse is the raw response of a REST gateway call.
+type RESTResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Decode unmarshals the response body into the response message of the given RPC.
+func (r *RESTResponse) Decode(rpc RPC) (proto.Message, error) {
+	response := rpc.NewResponse()
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(r.Body, response); err != nil {
+		return nil, fmt.Errorf("failed to decode the %v response (%d): %v", rpc.Name, r.StatusCode, err)
+	}
+	return response, nil
+}
+
+// CallGRPC calls the RPC on the gRPC server, returning its response and trailer metadata (i.e. x-http-code).
+// Outgoing metadata on the context is sent with the request.
+func (h *Harness) CallGRPC(ctx context.Context, rpc RPC, request proto.Message) (proto.Message, metadata.MD, error) {
+	response := rpc.NewResponse()
+	var trailer metadata.MD
+	if err := h.conn.Invoke(ctx, rpc.FullMethod(), request, response, grpc.Trailer(&trailer)); err != nil {
+		return nil, trailer, err
+	}
+	return response, trailer, nil
+}
+
+// CallREST calls the RPC on the REST gateway. GET endpoints take the request fields as query parameters,
+// and POST endpoints as a JSON body. Outgoing metadata on the context is sent as gateway metadata headers.
+// An error is only returned if no HTTP response was received.
+func (h *Harness) CallREST(ctx context.Context, rpc RPC, request proto.Message) (*RESTResponse, error) {
+	body, err := protojson.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the request: %v", err)
+	}
+	target := h.RESTURL + rpc.Path
+	var reader io.Reader
+	if rpc.HTTPMethod == http.MethodGet {
+		query, err := queryParams(body)
+		if err != nil {
+			return nil, err
+		}
+		target += "?" + query.Encode()
+	} else {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, rpc.HTTPMethod, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	md, _ := metadata.FromOutgoingContext(ctx)
+	for key, values := range md {
+		for _, v := range values {
+			req.Header.Add(runtime.MetadataHeaderPrefix+key, v)
+		}
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the response: %v", err)
+	}
+	return &RESTResponse{StatusCode: resp.StatusCode, Header: resp.Header, Body: data}, nil
+}
+
+// queryParams converts the top level scalar fields of a JSON request into query parameters.
+func queryParams(body []byte) (url.Values, error) {
+	var fields map[string]any
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, fmt.Errorf("failed to convert the request to query parameters: %v", err)
+	}
+	query := url.Values{}
+	for key, value := range fields {
+		switch value.(type) {
+		case map[string]any, []any:
+			return nil, fmt.Errorf("request field %v cannot be sent as a query parameter", key)
+		default:
+			query.Set(key, fmt.Sprint(value))
+		}
+	}
+	return query, nil
+}
@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 
 	"github.com/Masterminds/semver/v3"
@@ -49,6 +50,12 @@ type AllURL struct {
 	URL       string `db:"-"` // TODO remove?
 }
 
+// PurlNameType identifies a component by its Purl Name and Type.
+type PurlNameType struct {
+	Name string
+	Type string
+}
+
 // NewAllURLModel creates a new instance of the All URL Model.
 func NewAllURLModel(ctx context.Context, s *zap.SugaredLogger, q *database.DBQueryContext) *AllUrlsModel {
 	return &AllUrlsModel{ctx: ctx, s: s, q: q}
@@ -72,8 +79,10 @@ func (m *AllUrlsModel) GetUrlsByPurlList(list []utils.PurlReq) ([]AllURL, error)
 		"WHERE u.purl_name in " + inStmt +
 		" and package_hash!= '' ORDER BY date DESC;"
 
+	ctx, query := startQuery(m.ctx, "AllUrlsModel.GetUrlsByPurlList", attribute.Int("purls", len(list)))
 	var allUrls []AllURL
-	err := m.q.SelectContext(m.ctx, &allUrls, stmt)
+	err := m.q.SelectContext(ctx, &allUrls, stmt)
+	query.end(len(allUrls), err)
 	if err != nil {
 		m.s.Errorf("Failed to query a list of urls:  %v", err)
 		return []AllURL{}, fmt.Errorf("failed to query the all urls table: %v", err)
@@ -125,8 +134,9 @@ func (m *AllUrlsModel) GetUrlsByPurlNameType(purlName, purlType, purlReq string)
 		m.s.Errorf("Please specify a valid Purl Type to query: %v", purlName)
 		return AllURL{}, errors.New("please specify a valid Purl Type to query")
 	}
+	ctx, query := startQuery(m.ctx, "AllUrlsModel.GetUrlsByPurlNameType", attribute.Int("purls", 1))
 	var allUrls []AllURL
-	err := m.q.SelectContext(m.ctx, &allUrls,
+	err := m.q.SelectContext(ctx, &allUrls,
 		"SELECT package_hash AS url_hash, component, v.version_name AS version, v.semver AS semver, "+
 			"purl_name, mine_id FROM all_urls u "+
 			"LEFT JOIN mines m ON u.mine_id = m.id "+
@@ -134,7 +144,7 @@ func (m *AllUrlsModel) GetUrlsByPurlNameType(purlName, purlType, purlReq string)
 			"WHERE m.purl_type = $1 AND u.purl_name = $2  "+
 			"ORDER BY date DESC;",
 		purlType, purlName)
-
+	query.end(len(allUrls), err)
 	if err != nil {
 		m.s.Errorf("Failed to query all urls table for %v - %v: %v", purlType, purlName, err)
 		return AllURL{}, fmt.Errorf("failed to query the all urls table: %v", err)
@@ -159,8 +169,9 @@ func (m *AllUrlsModel) GetUrlsByPurlNameTypeVersion(purlName, purlType, purlVers
 		m.s.Errorf("Please specify a valid Purl Version to query")
 		return AllURL{}, errors.New("please specify a valid Purl Version to query")
 	}
+	ctx, query := startQuery(m.ctx, "AllUrlsModel.GetUrlsByPurlNameTypeVersion", attribute.Int("purls", 1))
 	var allUrls []AllURL
-	err := m.q.SelectContext(m.ctx, &allUrls,
+	err := m.q.SelectContext(ctx, &allUrls,
 		"SELECT package_hash AS url_hash, component, v.version_name AS version, v.semver AS semver, "+
 			"purl_name, mine_id FROM all_urls u "+
 			"LEFT JOIN mines m ON u.mine_id = m.id "+
@@ -168,6 +179,7 @@ func (m *AllUrlsModel) GetUrlsByPurlNameTypeVersion(purlName, purlType, purlVers
 			"WHERE m.purl_type = $1 AND u.purl_name = $2 AND v.version_name = $3 AND is_mined = true "+
 			"ORDER BY date DESC;",
 		purlType, purlName, purlVersion)
+	query.end(len(allUrls), err)
 	if err != nil {
 		m.s.Errorf("Failed to query all urls table for %v - %v: %v", purlType, purlName, err)
 		return AllURL{}, fmt.Errorf("failed to query the all urls table: %v", err)
@@ -190,25 +202,150 @@ func (m *AllUrlsModel) GetUrlsByPurlNameTypeInRange(purlName, purlType, purlRang
 		m.s.Infof("Please specify a valid Purl Version range to query")
 		return []AllURL{}, errors.New("please specify a valid Purl Version to query")
 	}
-	var allUrls []AllURL
-	var filteredUrls []AllURL
-	err := m.q.SelectContext(m.ctx, &allUrls,
-		"SELECT package_hash AS url_hash, component, v.version_name AS version, v.semver AS semver, "+
-			"purl_name, mine_id FROM all_urls u "+
-			"LEFT JOIN mines m ON u.mine_id = m.id "+
-			"LEFT JOIN versions v ON u.version_id = v.id "+
-			"WHERE m.purl_type = $1 AND u.purl_name = $2 "+
-			"AND package_hash!='404' "+
-			"ORDER BY date DESC;",
-		purlType, purlName)
+	key := PurlNameType{Name: purlName, Type: purlType}
+	urlsByPurl, err := m.GetUrlsByPurlNames([]PurlNameType{key})
 	if err != nil {
-		m.s.Infof("Failed to query all urls table for %v - %v: %v", purlType, purlName, err)
-		return []AllURL{}, fmt.Errorf("failed to query the all urls table: %v", err)
+		return []AllURL{}, err
+	}
+	allUrls := urlsByPurl[key]
+	return FilterUrlsInRange(m.s, allUrls, purlName, purlType, purlRange, summary)
+}
+
+// GetUrlsByPurlNames searches the URLs of all the supplied Purl Name/Type pairs using a single query per chunk of names.
+// The results are grouped by Purl Name/Type, keeping the date order of the table.
+// Purls already in the KB cache (if enabled) are not queried again.
+func (m *AllUrlsModel) GetUrlsByPurlNames(purls []PurlNameType) (map[PurlNameType][]AllURL, error) {
+	if len(purls) == 0 {
+		m.s.Infof("Please specify a valid Purl list to query")
+		return nil, errors.New("please specify a valid Purl list to query")
+	}
+	var cache *lruCache[PurlNameType, []AllURL]
+	if c := kbCacheInstance.Load(); c != nil {
+		cache = c.urls
+	}
+	return cachedLookup(cache, purls, m.queryUrlsByPurlNames)
+}
+
+// queryUrlsByPurlNames runs the all_urls query of GetUrlsByPurlNames.
+func (m *AllUrlsModel) queryUrlsByPurlNames(purls []PurlNameType) (map[PurlNameType][]AllURL, error) {
+	requested := make(map[PurlNameType]bool, len(purls))
+	var names []string
+	seenNames := make(map[string]bool, len(purls))
+	for _, p := range purls {
+		requested[p] = true
+		if !seenNames[p.Name] {
+			seenNames[p.Name] = true
+			names = append(names, p.Name)
+		}
+	}
+	ctx, query := startQuery(m.ctx, "AllUrlsModel.GetUrlsByPurlNames", attribute.Int("purls", len(requested)))
+	rows := 0
+	urls := make(map[PurlNameType][]AllURL, len(requested))
+	for start := 0; start < len(names); start += maxQueryParams {
+		chunk := names[start:min(start+maxQueryParams, len(names))]
+		var allUrls []AllURL
+		err := m.q.SelectContext(ctx, &allUrls,
+			"SELECT package_hash AS url_hash, component, v.version_name AS version, v.semver AS semver, "+
+				"m.purl_type AS purl_type, purl_name, mine_id FROM all_urls u "+
+				"LEFT JOIN mines m ON u.mine_id = m.id "+
+				"LEFT JOIN versions v ON u.version_id = v.id "+
+				"WHERE u.purl_name IN ("+bindParams(len(chunk))+") "+
+				"AND package_hash!='404' "+
+				"ORDER BY date DESC;",
+			queryArgs(chunk)...)
+		if err != nil {
+			query.end(rows, err)
+			m.s.Errorf("Failed to query all urls table for %d purls: %v", len(chunk), err)
+			return nil, fmt.Errorf("failed to query the all urls table: %v", err)
+		}
+		rows += len(allUrls)
+		for _, u := range allUrls {
+			key := PurlNameType{Name: u.PurlName, Type: u.PurlType}
+			if requested[key] {
+				urls[key] = append(urls[key], u)
+			}
+		}
+	}
+	query.end(rows, nil)
+	m.s.Debugf("Found URLs for %d of %d purls.", len(urls), len(requested))
+	return urls, nil
+}
+
+// GetUrlsByPackageHashes searches the URLs whose package hash (MD5 of the downloaded package) is in the given list.
+// The results are keyed by package hash. If a hash is shared by several URLs, the most recent one is returned.
+func (m *AllUrlsModel) GetUrlsByPackageHashes(hashes []string) (map[string]AllURL, error) {
+	unique := uniqueValues(hashes, true)
+	if len(unique) == 0 {
+		m.s.Infof("Please specify a valid package hash list to query")
+		return nil, errors.New("please specify a valid package hash list to query")
+	}
+	ctx, query := startQuery(m.ctx, "AllUrlsModel.GetUrlsByPackageHashes", attribute.Int("hashes", len(unique)))
+	rows := 0
+	urls := make(map[string]AllURL, len(unique))
+	for start := 0; start < len(unique); start += maxQueryParams {
+		chunk := unique[start:min(start+maxQueryParams, len(unique))]
+		var allUrls []AllURL
+		err := m.q.SelectContext(ctx, &allUrls,
+			"SELECT package_hash AS url_hash, component, v.version_name AS version, v.semver AS semver, "+
+				"m.purl_type AS purl_type, purl_name, mine_id FROM all_urls u "+
+				"LEFT JOIN mines m ON u.mine_id = m.id "+
+				"LEFT JOIN versions v ON u.version_id = v.id "+
+				"WHERE u.package_hash IN ("+bindParams(len(chunk))+") "+
+				"ORDER BY date DESC;",
+			queryArgs(chunk)...)
+		if err != nil {
+			query.end(rows, err)
+			m.s.Errorf("Failed to query all urls table for %d package hashes: %v", len(chunk), err)
+			return nil, fmt.Errorf("failed to query the all urls table: %v", err)
+		}
+		rows += len(allUrls)
+		for _, u := range allUrls {
+			if _, found := urls[u.URLHash]; !found {
+				urls[u.URLHash] = u
+			}
+		}
+	}
+	query.end(rows, nil)
+	m.s.Debugf("Found URLs for %d of %d package hashes.", len(urls), len(unique))
+	return urls, nil
+}
+
+// GetPurlNames returns the Purl Name/Type of all the components of the KB, sorted by type and name.
+func (m *AllUrlsModel) GetPurlNames() ([]PurlNameType, error) {
+	var names []struct {
+		Name string `db:"purl_name"`
+		Type string `db:"purl_type"`
+	}
+	ctx, query := startQuery(m.ctx, "AllUrlsModel.GetPurlNames")
+	err := m.q.SelectContext(ctx, &names,
+		"SELECT DISTINCT u.purl_name AS purl_name, m.purl_type AS purl_type FROM all_urls u "+
+			"JOIN mines m ON u.mine_id = m.id "+
+			"WHERE package_hash!='404' "+
+			"ORDER BY purl_type, purl_name;")
+	query.end(len(names), err)
+	if err != nil {
+		m.s.Errorf("Failed to query the purl names of the all urls table: %v", err)
+		return nil, fmt.Errorf("failed to query the all urls table: %v", err)
+	}
+	purls := make([]PurlNameType, 0, len(names))
+	for _, n := range names {
+		purls = append(purls, PurlNameType{Name: n.Name, Type: n.Type})
+	}
+	return purls, nil
+}
+
+// FilterUrlsInRange keeps the URLs whose semver falls inside the given version range.
+// Purls whose versions are not semver compliant are recorded in the summary.
+func FilterUrlsInRange(s *zap.SugaredLogger, allUrls []AllURL, purlName, purlType, purlRange string, summary *QuerySummary) ([]AllURL, error) {
+	if len(purlRange) == 0 {
+		s.Infof("Please specify a valid Purl Version range to query")
+		return []AllURL{}, errors.New("please specify a valid Purl Version to query")
 	}
 	rangeSpec, err := semver.NewConstraint(purlRange)
 	if err != nil {
 		return []AllURL{}, fmt.Errorf("failed to analyze range: %v", err)
 	}
+	var filteredUrls []AllURL
 	// Track versions without semver for summary reporting
 	woSemver := []string{}
 
@@ -241,8 +378,7 @@ func (m *AllUrlsModel) GetUrlsByPurlNameTypeInRange(purlName, purlType, purlRang
 			Versions: woSemver,
 		})
 	}
-	m.s.Debugf("Found %d results for %v, %v.", len(filteredUrls), purlType, purlName)
-	// Pick one URL to return
+	s.Debugf("Found %d results for %v, %v.", len(filteredUrls), purlType, purlName)
 	return filteredUrls, nil
 }
 
@@ -180,6 +180,62 @@ func TestAllUrlsSearchVersionRange(t *testing.T) {
 	}
 }
 
+func TestAllUrlsSearchPurlNames(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	conn := sqliteConn(t, ctx, db) // Get a connection from the pool
+	defer CloseConn(conn)
+	err = LoadTestSQLData(db, ctx, conn)
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	allUrlsModel := NewAllURLModel(ctx, s, database.NewDBSelectContext(s, nil, conn, myConfig.Database.Trace))
+
+	engine := PurlNameType{Name: "scanoss/engine", Type: "github"}
+	missing := PurlNameType{Name: "scanoss/engines", Type: "github"}
+	urlsByPurl, err := allUrlsModel.GetUrlsByPurlNames([]PurlNameType{engine, missing, engine})
+	if err != nil {
+		t.Fatalf("all_urls.GetUrlsByPurlNames() error = %v", err)
+	}
+	if len(urlsByPurl[engine]) == 0 {
+		t.Errorf("all_urls.GetUrlsByPurlNames() No URLs returned for %v", engine)
+	}
+	if len(urlsByPurl[missing]) != 0 {
+		t.Errorf("all_urls.GetUrlsByPurlNames() unexpected URLs returned for %v", missing)
+	}
+	summary := QuerySummary{}
+	inRange, err := FilterUrlsInRange(s, urlsByPurl[engine], engine.Name, engine.Type, ">2.0", &summary)
+	if err != nil {
+		t.Errorf("FilterUrlsInRange() error = %v", err)
+	}
+	single, err := allUrlsModel.GetUrlsByPurlNameTypeInRange(engine.Name, engine.Type, ">2.0", &QuerySummary{})
+	if err != nil {
+		t.Errorf("all_urls.GetUrlsByPurlNameTypeInRange() error = %v", err)
+	}
+	if len(inRange) != len(single) {
+		t.Errorf("FilterUrlsInRange() returned %d URLs, expected %d", len(inRange), len(single))
+	}
+	_, err = FilterUrlsInRange(s, urlsByPurl[engine], engine.Name, engine.Type, "", &summary)
+	if err == nil {
+		t.Errorf("expected error FilterUrlsInRange() with an empty range")
+	}
+	_, err = allUrlsModel.GetUrlsByPurlNames(nil)
+	if err == nil {
+		t.Errorf("expected error all_urls.GetUrlsByPurlNames() with an empty purl list")
+	}
+}
+
 func TestAllUrlsSearchPurlList(t *testing.T) {
 	err := zlog.NewSugaredDevLogger()
 	if err != nil {
@@ -322,3 +378,36 @@ func TestAllUrlsSearchBadSql(t *testing.T) {
 	}
 	fmt.Printf("All Urls: %v\n", allUrls)
 }
+
+func TestAllUrlsSearchPackageHashes(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	conn := sqliteConn(t, ctx, db) // Get a connection from the pool
+	defer CloseConn(conn)
+	err = LoadTestSQLData(db, ctx, conn)
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	allUrlsModel := NewAllURLModel(ctx, s, database.NewDBSelectContext(s, nil, conn, false))
+
+	found := "2c2ae45c192df28dcfd1caab7e2b12db"
+	missing := "00000000000000000000000000000000"
+	urls, err := allUrlsModel.GetUrlsByPackageHashes([]string{found, missing, found, ""})
+	if err != nil {
+		t.Fatalf("all_urls.GetUrlsByPackageHashes() error = %v", err)
+	}
+	if len(urls) != 1 || urls[found].PurlName != "scanoss/engine" || urls[found].PurlType != "github" || len(urls[found].Version) == 0 {
+		t.Errorf("all_urls.GetUrlsByPackageHashes() unexpected URLs: %+v", urls)
+	}
+	_, err = allUrlsModel.GetUrlsByPackageHashes([]string{""})
+	if err == nil {
+		t.Errorf("expected error all_urls.GetUrlsByPackageHashes() with an empty hash list")
+	}
+}
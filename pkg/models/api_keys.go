@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// APIKeysModel reads the API keys allowed to call the service.
+type APIKeysModel struct {
+	ctx context.Context
+	s   *zap.SugaredLogger
+	db  *sqlx.DB
+}
+
+// APIKey is an API key allowed to call the service. Only the (hex encoded) SHA-256 hash of the key is stored.
+type APIKey struct {
+	ID     string   `db:"id" json:"id"`
+	Hash   string   `db:"key_hash" json:"hash"`
+	Scopes []string `db:"-" json:"scopes"` // Stored as a comma separated list
+}
+
+// NewAPIKeysModel creates a new instance of the API Keys Model.
+func NewAPIKeysModel(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB) *APIKeysModel {
+	return &APIKeysModel{ctx: ctx, s: s, db: db}
+}
+
+// GetAPIKeys returns all the API keys of the api_keys table, ordered by ID.
+func (m *APIKeysModel) GetAPIKeys() ([]APIKey, error) {
+	var rows []struct {
+		APIKey
+		Scopes string `db:"scopes"`
+	}
+	if err := m.db.SelectContext(m.ctx, &rows, "SELECT id, key_hash, scopes FROM api_keys ORDER BY id"); err != nil {
+		m.s.Errorf("Failed to query api_keys: %v", err)
+		return nil, fmt.Errorf("failed to query the api keys: %v", err)
+	}
+	keys := make([]APIKey, 0, len(rows))
+	for _, r := range rows {
+		key := r.APIKey
+		for _, scope := range strings.Split(r.Scopes, ",") {
+			if scope = strings.TrimSpace(scope); len(scope) > 0 {
+				key.Scopes = append(key.Scopes, scope)
+			}
+		}
+		keys = append(keys, key)
+	}
+	m.s.Debugf("Loaded %d API keys", len(keys))
+	return keys, nil
+}
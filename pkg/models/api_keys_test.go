@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestGetAPIKeys(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	db.SetMaxOpenConns(1)
+	defer CloseDB(db)
+	model := NewAPIKeysModel(ctx, s, db)
+	if _, err = model.GetAPIKeys(); err == nil {
+		t.Errorf("GetAPIKeys() expected an error without an api_keys table")
+	}
+	if err = LoadTestAPIKeys(db, ctx, nil); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	keys, err := model.GetAPIKeys()
+	if err != nil || len(keys) != 2 {
+		t.Fatalf("GetAPIKeys() = %v, %v", keys, err)
+	}
+	if keys[1].ID != "team-hints" || len(keys[1].Hash) != 64 || !slices.Equal(keys[1].Scopes, []string{"Echo", "*Hints*"}) {
+		t.Errorf("GetAPIKeys() = %+v", keys[1])
+	}
+}
@@ -0,0 +1,245 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"container/list"
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+// CacheStats holds the usage metrics of a single KB cache.
+type CacheStats struct {
+	Name      string `json:"name"`
+	Entries   int    `json:"entries"`
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+}
+
+// lruCache is a size and TTL bounded, least recently used, cache safe for concurrent use.
+type lruCache[K comparable, V any] struct {
+	name      string
+	size      int
+	ttl       time.Duration
+	mu        sync.Mutex
+	items     map[K]*list.Element
+	order     *list.List // Most recently used entries at the front
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+// cacheEntry is a value stored in the cache along with its expiry time.
+type cacheEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+// newLRUCache creates a cache holding up to size entries, each valid for the given TTL (no expiry if zero).
+func newLRUCache[K comparable, V any](name string, size int, ttl time.Duration) *lruCache[K, V] {
+	return &lruCache[K, V]{name: name, size: size, ttl: ttl, items: make(map[K]*list.Element), order: list.New()}
+}
+
+// get returns the value cached for the key, if present and not expired.
+func (c *lruCache[K, V]) get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[key]; ok {
+		entry := e.Value.(*cacheEntry[K, V])
+		if c.ttl <= 0 || time.Now().Before(entry.expires) {
+			c.order.MoveToFront(e)
+			c.hits++
+			cacheMetrics.record(c.name, true)
+			return entry.value, true
+		}
+		c.remove(e)
+	}
+	c.misses++
+	cacheMetrics.record(c.name, false)
+	var zero V
+	return zero, false
+}
+
+// set stores the value for the key, evicting the least recently used entries if the cache is full.
+func (c *lruCache[K, V]) set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expires := time.Now().Add(c.ttl)
+	if e, ok := c.items[key]; ok {
+		entry := e.Value.(*cacheEntry[K, V])
+		entry.value, entry.expires = value, expires
+		c.order.MoveToFront(e)
+		return
+	}
+	c.items[key] = c.order.PushFront(&cacheEntry[K, V]{key: key, value: value, expires: expires})
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+		c.evictions++
+	}
+}
+
+// remove drops an entry from the cache. The lock must be held by the caller.
+func (c *lruCache[K, V]) remove(e *list.Element) {
+	c.order.Remove(e)
+	delete(c.items, e.Value.(*cacheEntry[K, V]).key)
+}
+
+// purge drops all the entries of the cache.
+func (c *lruCache[K, V]) purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = make(map[K]*list.Element)
+	c.order.Init()
+}
+
+// stats returns a snapshot of the cache usage metrics.
+func (c *lruCache[K, V]) stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheStats{Name: c.name, Entries: c.order.Len(), Hits: c.hits, Misses: c.misses, Evictions: c.evictions}
+}
+
+// kbCache groups the caches of the KB tables served by the models.
+type kbCache struct {
+	urls      *lruCache[PurlNameType, []AllURL] // all_urls rows of each Purl Name/Type
+	crypto    *lruCache[string, []CryptoUsage]  // component_crypto rows of each URL hash
+	libraries *lruCache[string, []ECUsage]      // crypto_libraries detected in each URL hash
+}
+
+// kbCacheInstance holds the active KB cache. Caching is disabled while it is nil.
+var kbCacheInstance atomic.Pointer[kbCache]
+
+// ConfigureCache enables the KB cache, holding up to size entries per table for the given TTL.
+// A size of zero or less disables caching. Any previously cached entries are dropped.
+func ConfigureCache(s *zap.SugaredLogger, size int, ttl time.Duration) {
+	if size <= 0 {
+		s.Infof("KB cache disabled")
+		kbCacheInstance.Store(nil)
+		return
+	}
+	cacheMetrics.setup()
+	s.Infof("KB cache enabled with %d entries per table and a TTL of %v", size, ttl)
+	kbCacheInstance.Store(&kbCache{
+		urls:      newLRUCache[PurlNameType, []AllURL]("all_urls", size, ttl),
+		crypto:    newLRUCache[string, []CryptoUsage]("component_crypto", size, ttl),
+		libraries: newLRUCache[string, []ECUsage]("crypto_libraries", size, ttl),
+	})
+}
+
+// InvalidateCache drops every cached KB entry. It should be called whenever the KB is reloaded.
+func InvalidateCache() {
+	if c := kbCacheInstance.Load(); c != nil {
+		c.urls.purge()
+		c.crypto.purge()
+		c.libraries.purge()
+	}
+}
+
+// GetCacheStats returns the usage metrics of each KB cache, or nothing if caching is disabled.
+func GetCacheStats() []CacheStats {
+	c := kbCacheInstance.Load()
+	if c == nil {
+		return nil
+	}
+	return []CacheStats{c.urls.stats(), c.crypto.stats(), c.libraries.stats()}
+}
+
+// cachedLookup serves the requested keys from the cache (if enabled), calling query for the keys not found.
+// The values returned by query are cached, including those of keys without results.
+func cachedLookup[K comparable, V any](cache *lruCache[K, []V], keys []K, query func(keys []K) (map[K][]V, error)) (map[K][]V, error) {
+	if cache == nil {
+		return query(keys)
+	}
+	results := make(map[K][]V, len(keys))
+	var missing []K
+	for _, k := range keys {
+		if v, ok := cache.get(k); ok {
+			if len(v) > 0 {
+				results[k] = v
+			}
+		} else {
+			missing = append(missing, k)
+		}
+	}
+	if len(missing) == 0 {
+		return results, nil
+	}
+	found, err := query(missing)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range missing {
+		v := slices.Clip(found[k]) // Make sure appends by the caller never write into the cached slice
+		cache.set(k, v)
+		if len(v) > 0 {
+			results[k] = v
+		}
+	}
+	return results, nil
+}
+
+// cacheCounters holds the OTEL metrics recorders of the KB cache.
+type cacheCounters struct {
+	once   sync.Once
+	hits   metric.Int64Counter
+	misses metric.Int64Counter
+}
+
+var cacheMetrics = cacheCounters{}
+
+// setup configures the cache metrics recorders.
+func (m *cacheCounters) setup() {
+	m.once.Do(func() {
+		meter := otel.Meter("scanoss.com/cryptography")
+		m.hits, _ = meter.Int64Counter("kb.cache.hits", metric.WithDescription("The number of KB lookups served from the cache"))
+		m.misses, _ = meter.Int64Counter("kb.cache.misses", metric.WithDescription("The number of KB lookups not found in the cache"))
+		_, _ = meter.Float64ObservableGauge("kb.cache.hit_ratio",
+			metric.WithDescription("The ratio of KB lookups served from the cache since it was configured"),
+			metric.WithFloat64Callback(observeHitRatios))
+	})
+}
+
+// observeHitRatios reports the hit ratio of each KB cache that has been used.
+func observeHitRatios(_ context.Context, o metric.Float64Observer) error {
+	for _, stats := range GetCacheStats() {
+		if lookups := stats.Hits + stats.Misses; lookups > 0 {
+			o.Observe(float64(stats.Hits)/float64(lookups), metric.WithAttributes(attribute.String("cache", stats.Name)))
+		}
+	}
+	return nil
+}
+
+// record adds a cache hit or miss to the metrics.
+func (m *cacheCounters) record(name string, hit bool) {
+	counter := m.misses
+	if hit {
+		counter = m.hits
+	}
+	if counter != nil {
+		counter.Add(context.Background(), 1, metric.WithAttributes(attribute.String("cache", name)))
+	}
+}
@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestLRUCache(t *testing.T) {
+	cache := newLRUCache[string, int]("test", 2, time.Hour)
+	cache.set("a", 1)
+	cache.set("b", 2)
+	if _, ok := cache.get("a"); !ok { // Makes "b" the least recently used entry
+		t.Errorf("lruCache.get() expected a hit for a")
+	}
+	cache.set("c", 3)
+	if _, ok := cache.get("b"); ok {
+		t.Errorf("lruCache.get() expected b to be evicted")
+	}
+	if v, ok := cache.get("c"); !ok || v != 3 {
+		t.Errorf("lruCache.get() = %v, %v, want 3, true", v, ok)
+	}
+	stats := cache.stats()
+	if stats.Entries != 2 || stats.Hits != 2 || stats.Misses != 1 || stats.Evictions != 1 {
+		t.Errorf("lruCache.stats() unexpected stats: %+v", stats)
+	}
+	cache.purge()
+	if _, ok := cache.get("a"); ok {
+		t.Errorf("lruCache.get() expected a miss after purge")
+	}
+
+	expiring := newLRUCache[string, int]("expiring", 2, time.Millisecond)
+	expiring.set("a", 1)
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := expiring.get("a"); ok {
+		t.Errorf("lruCache.get() expected an expired entry to be a miss")
+	}
+	if stats = expiring.stats(); stats.Entries != 0 {
+		t.Errorf("lruCache.stats() expected expired entry to be removed: %+v", stats)
+	}
+}
+
+func TestCachedLookup(t *testing.T) {
+	cache := newLRUCache[string, []string]("test", 10, time.Hour)
+	var queried []string
+	query := func(keys []string) (map[string][]string, error) {
+		queried = append(queried, keys...)
+		results := map[string][]string{}
+		for _, k := range keys {
+			if k != "missing" {
+				results[k] = []string{k + "-value"}
+			}
+		}
+		return results, nil
+	}
+	results, err := cachedLookup(cache, []string{"a", "missing"}, query)
+	if err != nil || len(results) != 1 || results["a"][0] != "a-value" {
+		t.Errorf("cachedLookup() = %v, %v", results, err)
+	}
+	results, err = cachedLookup(cache, []string{"a", "b", "missing"}, query)
+	if err != nil || len(results) != 2 {
+		t.Errorf("cachedLookup() = %v, %v", results, err)
+	}
+	if len(queried) != 3 || queried[2] != "b" {
+		t.Errorf("cachedLookup() expected only uncached keys to be queried, got %v", queried)
+	}
+	queried = nil
+	if _, err = cachedLookup(nil, []string{"a"}, query); err != nil || len(queried) != 1 {
+		t.Errorf("cachedLookup() expected a query without cache, got %v, %v", queried, err)
+	}
+}
+
+func TestCachedModels(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	conn := sqliteConn(t, ctx, db) // Get a connection from the pool
+	defer CloseConn(conn)
+	err = LoadTestSQLData(db, ctx, conn)
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	ConfigureCache(s, 100, time.Hour)
+	defer ConfigureCache(s, 0, 0)
+	q := database.NewDBSelectContext(s, nil, conn, false)
+	allUrlsModel := NewAllURLModel(ctx, s, q)
+	cryptoUsageModel := NewCryptoUsageModel(ctx, s, q)
+
+	engine := PurlNameType{Name: "scanoss/engine", Type: "github"}
+	first, err := allUrlsModel.GetUrlsByPurlNames([]PurlNameType{engine})
+	if err != nil || len(first[engine]) == 0 {
+		t.Fatalf("all_urls.GetUrlsByPurlNames() = %v, %v", first, err)
+	}
+	usages, err := cryptoUsageModel.GetCryptoUsageByURLHashes([]string{first[engine][0].URLHash})
+	if err != nil {
+		t.Fatalf("crypto_usage.GetCryptoUsageByURLHashes() error = %v", err)
+	}
+	_, err = conn.ExecContext(ctx, "DELETE FROM all_urls; DELETE FROM component_crypto;")
+	if err != nil {
+		t.Fatalf("failed to clear the KB tables: %v", err)
+	}
+	second, err := allUrlsModel.GetUrlsByPurlNames([]PurlNameType{engine})
+	if err != nil || len(second[engine]) != len(first[engine]) {
+		t.Errorf("all_urls.GetUrlsByPurlNames() expected cached URLs, got %v, %v", second, err)
+	}
+	cached, err := cryptoUsageModel.GetCryptoUsageByURLHashes([]string{first[engine][0].URLHash})
+	if err != nil || len(cached) != len(usages) {
+		t.Errorf("crypto_usage.GetCryptoUsageByURLHashes() expected cached usages, got %v, %v", cached, err)
+	}
+	for _, stats := range GetCacheStats() {
+		if stats.Name != "crypto_libraries" && (stats.Hits != 1 || stats.Misses != 1) {
+			t.Errorf("GetCacheStats() unexpected stats: %+v", stats)
+		}
+	}
+	InvalidateCache()
+	second, err = allUrlsModel.GetUrlsByPurlNames([]PurlNameType{engine})
+	if err != nil || len(second[engine]) != 0 {
+		t.Errorf("all_urls.GetUrlsByPurlNames() expected no URLs after invalidation, got %v, %v", second, err)
+	}
+}
@@ -50,10 +50,15 @@ func loadSQLData(db *sqlx.DB, ctx context.Context, conn *sqlx.Conn, filename str
 func LoadTestSQLData(db *sqlx.DB, ctx context.Context, conn *sqlx.Conn) error {
 	files := []string{"../models/tests/mines.sql", "../models/tests/all_urls.sql", "../models/tests/versions.sql",
 		"../models/tests/component_crypto.sql", "../models/tests/component_crypto_libraries.sql",
-		"../models/tests/crypto_libraries.sql"}
+		"../models/tests/crypto_libraries.sql", "../models/tests/crypto_library_tags.sql"}
 	return loadTestSQLDataFiles(db, ctx, conn, files)
 }
 
+// LoadTestAPIKeys loads the test API keys (not part of the KB fixtures).
+func LoadTestAPIKeys(db *sqlx.DB, ctx context.Context, conn *sqlx.Conn) error {
+	return loadTestSQLDataFiles(db, ctx, conn, []string{"../models/tests/api_keys.sql"})
+}
+
 func RunTestSQL(db *sqlx.DB, ctx context.Context, conn *sqlx.Conn, stm string) error {
 	return runSQL(db, ctx, conn, stm)
 }
@@ -0,0 +1,268 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// cryptoLibrariesSchema creates the crypto_libraries table, if it does not exist yet.
+const cryptoLibrariesSchema = `CREATE TABLE IF NOT EXISTS crypto_libraries (
+	id TEXT PRIMARY KEY,
+	name TEXT NOT NULL,
+	description TEXT NOT NULL,
+	url TEXT NOT NULL,
+	category TEXT NOT NULL,
+	purl TEXT NOT NULL
+)`
+
+// cryptoLibraryTagsSchema creates the crypto_library_tags join table, if it does not exist yet.
+const cryptoLibraryTagsSchema = `CREATE TABLE IF NOT EXISTS crypto_library_tags (
+	library_id TEXT NOT NULL,
+	tag TEXT NOT NULL,
+	PRIMARY KEY (library_id, tag)
+)`
+
+// CryptoLibrariesModel manages the crypto library (hint) definitions of the KB.
+// Unlike the other models, it writes to the KB, so it works on the database rather than a single connection.
+type CryptoLibrariesModel struct {
+	ctx context.Context
+	s   *zap.SugaredLogger
+	db  *sqlx.DB
+}
+
+// CryptoLibrary is a row of the crypto_libraries table.
+type CryptoLibrary struct {
+	ID          string   `db:"id" json:"id"`
+	Name        string   `db:"name" json:"name"`
+	Description string   `db:"description" json:"description"`
+	URL         string   `db:"url" json:"url"`
+	Category    string   `db:"category" json:"category"`
+	Purl        string   `db:"purl" json:"purl"`
+	Tags        []string `db:"-" json:"tags,omitempty"` // Stored in crypto_library_tags
+}
+
+// CryptoLibraryChange lists the fields of a crypto library changed by an upsert.
+type CryptoLibraryChange struct {
+	ID         string              `json:"id"`
+	Fields     []CryptoLibraryDiff `json:"fields,omitempty"`
+	Detections int64               `json:"detections,omitempty"` // Component detections dropped along with a removed library
+}
+
+// CryptoLibraryDiff is the old and new value of a changed crypto library field.
+type CryptoLibraryDiff struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// CryptoLibrariesChanges reports the rows inserted, updated and removed by an upsert.
+type CryptoLibrariesChanges struct {
+	Inserted  []CryptoLibraryChange `json:"inserted"`
+	Updated   []CryptoLibraryChange `json:"updated"`
+	Removed   []CryptoLibraryChange `json:"removed"`
+	Unchanged int                   `json:"unchanged"`
+	DryRun    bool                  `json:"dry_run"`
+}
+
+// NewCryptoLibrariesModel creates a new instance of the Crypto Libraries Model.
+func NewCryptoLibrariesModel(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB) *CryptoLibrariesModel {
+	return &CryptoLibrariesModel{ctx: ctx, s: s, db: db}
+}
+
+// UpsertCryptoLibraries inserts the given crypto libraries (and their tags), or updates them if their ID already exists,
+// in a single transaction. Libraries missing from the list are removed if prune is set, along with their component
+// detections (component_crypto_library rows), so no detection is left pointing to a missing library.
+// In dry run mode, the changes are reported but the transaction is rolled back.
+func (m *CryptoLibrariesModel) UpsertCryptoLibraries(libraries []CryptoLibrary, prune, dryRun bool) (CryptoLibrariesChanges, error) {
+	changes := CryptoLibrariesChanges{Inserted: []CryptoLibraryChange{}, Updated: []CryptoLibraryChange{},
+		Removed: []CryptoLibraryChange{}, DryRun: dryRun}
+	ids := make(map[string]bool, len(libraries))
+	for _, l := range libraries {
+		if len(l.ID) == 0 {
+			return CryptoLibrariesChanges{}, errors.New("crypto library without an id")
+		}
+		if ids[l.ID] {
+			return CryptoLibrariesChanges{}, fmt.Errorf("duplicate crypto library id: %v", l.ID)
+		}
+		ids[l.ID] = true
+	}
+	tx, err := m.db.BeginTxx(m.ctx, nil)
+	if err != nil {
+		return CryptoLibrariesChanges{}, fmt.Errorf("failed to start a transaction: %v", err)
+	}
+	defer func() {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			m.s.Warnf("Failed to rollback the crypto libraries transaction: %v", rerr)
+		}
+	}()
+	existing, err := m.loadCryptoLibraries(tx)
+	if err != nil {
+		return CryptoLibrariesChanges{}, err
+	}
+	for _, l := range libraries {
+		l.Tags = NormalizeTags(l.Tags)
+		old, found := existing[l.ID]
+		switch {
+		case !found:
+			_, err = tx.ExecContext(m.ctx, tx.Rebind("INSERT INTO crypto_libraries (id, name, description, url, category, purl) VALUES (?, ?, ?, ?, ?, ?)"),
+				l.ID, l.Name, l.Description, l.URL, l.Category, l.Purl)
+			changes.Inserted = append(changes.Inserted, CryptoLibraryChange{ID: l.ID})
+		case len(diffCryptoLibraries(old, l)) > 0:
+			_, err = tx.ExecContext(m.ctx, tx.Rebind("UPDATE crypto_libraries SET name = ?, description = ?, url = ?, category = ?, purl = ? WHERE id = ?"),
+				l.Name, l.Description, l.URL, l.Category, l.Purl, l.ID)
+			changes.Updated = append(changes.Updated, CryptoLibraryChange{ID: l.ID, Fields: diffCryptoLibraries(old, l)})
+		default:
+			changes.Unchanged++
+			continue
+		}
+		if err == nil {
+			err = m.replaceTags(tx, l.ID, l.Tags)
+		}
+		if err != nil {
+			return CryptoLibrariesChanges{}, fmt.Errorf("failed to upsert crypto library %v: %v", l.ID, err)
+		}
+	}
+	if prune {
+		for id := range existing {
+			if ids[id] {
+				continue
+			}
+			detections, err := m.removeCryptoLibrary(tx, id)
+			if err != nil {
+				return CryptoLibrariesChanges{}, fmt.Errorf("failed to remove crypto library %v: %v", id, err)
+			}
+			changes.Removed = append(changes.Removed, CryptoLibraryChange{ID: id, Detections: detections})
+		}
+	}
+	for _, list := range [][]CryptoLibraryChange{changes.Inserted, changes.Updated, changes.Removed} {
+		slices.SortFunc(list, func(a, b CryptoLibraryChange) int { return strings.Compare(a.ID, b.ID) })
+	}
+	if dryRun {
+		m.s.Debugf("Dry run, rolling back the crypto libraries changes")
+		return changes, nil
+	}
+	if err = tx.Commit(); err != nil {
+		return CryptoLibrariesChanges{}, fmt.Errorf("failed to commit the crypto libraries changes: %v", err)
+	}
+	InvalidateCache() // Cached hints may hold the old definitions
+	return changes, nil
+}
+
+// loadCryptoLibraries creates the crypto library tables (if needed) and returns their current rows, along with their tags.
+func (m *CryptoLibrariesModel) loadCryptoLibraries(tx *sqlx.Tx) (map[string]CryptoLibrary, error) {
+	for _, schema := range []string{cryptoLibrariesSchema, cryptoLibraryTagsSchema} {
+		if _, err := tx.ExecContext(m.ctx, schema); err != nil {
+			return nil, fmt.Errorf("failed to create the crypto libraries tables: %v", err)
+		}
+	}
+	var rows []CryptoLibrary
+	if err := tx.SelectContext(m.ctx, &rows, "SELECT id, name, description, url, category, purl FROM crypto_libraries"); err != nil {
+		return nil, fmt.Errorf("failed to query the crypto libraries table: %v", err)
+	}
+	var tags []ECTag
+	if err := tx.SelectContext(m.ctx, &tags, "SELECT library_id, tag FROM crypto_library_tags ORDER BY library_id, tag"); err != nil {
+		return nil, fmt.Errorf("failed to query the crypto library tags table: %v", err)
+	}
+	existing := make(map[string]CryptoLibrary, len(rows))
+	for _, r := range rows {
+		existing[r.ID] = r
+	}
+	for _, t := range tags {
+		if l, found := existing[t.ID]; found {
+			l.Tags = append(l.Tags, t.Tag)
+			existing[t.ID] = l
+		}
+	}
+	// Normalized as the upserted tags are, so case and order differences are not reported as changes
+	for id, l := range existing {
+		l.Tags = NormalizeTags(l.Tags)
+		existing[id] = l
+	}
+	return existing, nil
+}
+
+// removeCryptoLibrary deletes a crypto library, its tags and its component detections, returning the number of detections removed.
+func (m *CryptoLibrariesModel) removeCryptoLibrary(tx *sqlx.Tx, id string) (int64, error) {
+	res, err := tx.ExecContext(m.ctx, tx.Rebind("DELETE FROM component_crypto_library WHERE det_id = ?"), id)
+	if err != nil {
+		return 0, err
+	}
+	detections, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if _, err = tx.ExecContext(m.ctx, tx.Rebind("DELETE FROM crypto_libraries WHERE id = ?"), id); err != nil {
+		return 0, err
+	}
+	return detections, m.replaceTags(tx, id, nil)
+}
+
+// replaceTags replaces the tags of a crypto library.
+func (m *CryptoLibrariesModel) replaceTags(tx *sqlx.Tx, id string, tags []string) error {
+	if _, err := tx.ExecContext(m.ctx, tx.Rebind("DELETE FROM crypto_library_tags WHERE library_id = ?"), id); err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		if _, err := tx.ExecContext(m.ctx, tx.Rebind("INSERT INTO crypto_library_tags (library_id, tag) VALUES (?, ?)"), id, tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NormalizeTags lower cases and sorts the tags, dropping blank and repeated ones.
+func NormalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if t = strings.ToLower(strings.TrimSpace(t)); len(t) > 0 {
+			normalized = append(normalized, t)
+		}
+	}
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
+
+// diffCryptoLibraries lists the fields that differ between two versions of a crypto library.
+func diffCryptoLibraries(old, updated CryptoLibrary) []CryptoLibraryDiff {
+	fields := []struct {
+		name     string
+		old, new string
+	}{
+		{"name", old.Name, updated.Name},
+		{"description", old.Description, updated.Description},
+		{"url", old.URL, updated.URL},
+		{"category", old.Category, updated.Category},
+		{"purl", old.Purl, updated.Purl},
+		{"tags", strings.Join(old.Tags, ","), strings.Join(updated.Tags, ",")},
+	}
+	var diffs []CryptoLibraryDiff
+	for _, f := range fields {
+		if f.old != f.new {
+			diffs = append(diffs, CryptoLibraryDiff{Field: f.name, Old: f.old, New: f.new})
+		}
+	}
+	return diffs
+}
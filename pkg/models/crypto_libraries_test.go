@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestUpsertCryptoLibraries(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	db.SetMaxOpenConns(1)
+	defer CloseDB(db)
+	model := NewCryptoLibrariesModel(ctx, s, db)
+	_, err = db.Exec("CREATE TABLE component_crypto_library (url_hash TEXT NOT NULL, det_id TEXT NOT NULL);" +
+		"INSERT INTO component_crypto_library VALUES ('h1', 'protocol/tls'), ('h2', 'protocol/tls'), ('h1', 'library/libsodium');")
+	if err != nil {
+		t.Fatalf("failed to create the component_crypto_library table: %v", err)
+	}
+
+	libraries := []CryptoLibrary{
+		{ID: "library/libsodium", Name: "libsodium", Description: "Sodium's crypto library", URL: "https://libsodium.org",
+			Category: "library", Purl: "pkg:github/jedisct1/libsodium"},
+		{ID: "protocol/tls", Name: "TLS", Description: "Transport Layer Security", URL: "https://www.rfc-editor.org/rfc/rfc8446",
+			Category: "protocol", Tags: []string{"TLS", " pqc", "tls"}},
+	}
+	changes, err := model.UpsertCryptoLibraries(libraries, false, false)
+	if err != nil || len(changes.Inserted) != 2 || len(changes.Updated) != 0 {
+		t.Fatalf("UpsertCryptoLibraries() = %+v, %v", changes, err)
+	}
+	var description string
+	if err = db.Get(&description, "SELECT description FROM crypto_libraries WHERE id = 'library/libsodium'"); err != nil ||
+		description != "Sodium's crypto library" {
+		t.Errorf("UpsertCryptoLibraries() stored description %q, %v", description, err)
+	}
+
+	var tags []string
+	if err = db.Select(&tags, "SELECT tag FROM crypto_library_tags WHERE library_id = 'protocol/tls' ORDER BY tag"); err != nil ||
+		!slices.Equal(tags, []string{"pqc", "tls"}) {
+		t.Errorf("UpsertCryptoLibraries() stored tags %v, %v", tags, err)
+	}
+
+	updated := []CryptoLibrary{libraries[0], {ID: "protocol/ssh", Name: "SSH", Description: "Secure Shell", Category: "protocol"}}
+	updated[0].Name = "Libsodium"
+	changes, err = model.UpsertCryptoLibraries(updated, true, true)
+	if err != nil || len(changes.Inserted) != 1 || len(changes.Updated) != 1 || len(changes.Removed) != 1 || !changes.DryRun {
+		t.Fatalf("UpsertCryptoLibraries() dry run = %+v, %v", changes, err)
+	}
+	if changes.Removed[0].Detections != 2 {
+		t.Errorf("UpsertCryptoLibraries() expected 2 detections of the removed library, got %+v", changes.Removed[0])
+	}
+	if fields := changes.Updated[0].Fields; len(fields) != 1 || fields[0].Field != "name" || fields[0].Old != "libsodium" {
+		t.Errorf("UpsertCryptoLibraries() unexpected updated fields: %+v", fields)
+	}
+	var count int
+	if err = db.Get(&count, "SELECT COUNT(*) FROM crypto_libraries WHERE id = 'protocol/ssh'"); err != nil || count != 0 {
+		t.Errorf("UpsertCryptoLibraries() dry run expected no changes to be applied, got %v, %v", count, err)
+	}
+
+	changes, err = model.UpsertCryptoLibraries(updated, true, false)
+	if err != nil || len(changes.Inserted) != 1 || len(changes.Updated) != 1 || changes.Removed[0].ID != "protocol/tls" {
+		t.Fatalf("UpsertCryptoLibraries() = %+v, %v", changes, err)
+	}
+	if err = db.Get(&count, "SELECT COUNT(*) FROM crypto_libraries"); err != nil || count != 2 {
+		t.Errorf("UpsertCryptoLibraries() expected 2 rows, got %v, %v", count, err)
+	}
+	if err = db.Get(&count, "SELECT COUNT(*) FROM crypto_library_tags"); err != nil || count != 0 {
+		t.Errorf("UpsertCryptoLibraries() expected the tags of removed libraries to be dropped, got %v, %v", count, err)
+	}
+	if err = db.Get(&count, "SELECT COUNT(*) FROM component_crypto_library"); err != nil || count != 1 {
+		t.Errorf("UpsertCryptoLibraries() expected the detections of removed libraries to be dropped, got %v, %v", count, err)
+	}
+	// Tags stored with a different case or order are not a change
+	if _, err = db.Exec("INSERT INTO crypto_library_tags VALUES ('library/libsodium', 'PQC'), ('library/libsodium', 'fips')"); err != nil {
+		t.Fatal(err)
+	}
+	updated[0].Tags = []string{"pqc", "FIPS"}
+	changes, err = model.UpsertCryptoLibraries(updated, false, false)
+	if err != nil || changes.Unchanged != 2 {
+		t.Errorf("UpsertCryptoLibraries() expected no changes, got %+v, %v", changes, err)
+	}
+	if _, err = model.UpsertCryptoLibraries([]CryptoLibrary{libraries[0], libraries[0]}, false, false); err == nil {
+		t.Errorf("UpsertCryptoLibraries() expected an error for duplicate ids")
+	}
+}
@@ -20,9 +20,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
@@ -54,30 +54,56 @@ func NewCryptoUsageModel(ctx context.Context, s *zap.SugaredLogger, q *database.
 	return &CryptoUsageModel{ctx: ctx, s: s, q: q}
 }
 
+// GetCryptoUsageByURLHashes returns the algorithms used by each of the given URL hashes.
 func (m *CryptoUsageModel) GetCryptoUsageByURLHashes(urlHashes []string) ([]CryptoUsage, error) {
 	if len(urlHashes) == 0 {
 		m.s.Infof("Please specify a valid Purl list to query")
 		return []CryptoUsage{}, errors.New("please specify a valid Purl list to query")
 	}
-	var purlNames []string
-	for p := range urlHashes {
-		purlNames = append(purlNames, "'"+urlHashes[p]+"'")
-	}
-	inStmt := strings.Join(purlNames, ",")
-	inStmt = "(" + inStmt + ")"
-
-	if inStmt == "()" {
+	hashes := uniqueValues(urlHashes, false)
+	if len(hashes) == 0 {
 		m.s.Errorf("No hashes to query")
 		return []CryptoUsage{}, errors.New("no hashes to query")
 	}
-	stmt := "SELECT url_hash AS url_hash, algorithm_name, strength " +
-		"FROM component_crypto c " +
-		"WHERE url_hash in " + inStmt
-	var usages []CryptoUsage
-	err := m.q.SelectContext(m.ctx, &usages, stmt)
+	var cache *lruCache[string, []CryptoUsage]
+	if c := kbCacheInstance.Load(); c != nil {
+		cache = c.crypto
+	}
+	usagesByHash, err := cachedLookup(cache, hashes, m.queryCryptoUsageByURLHashes)
 	if err != nil {
-		m.s.Errorf("Failed to query cryptoUsage:  %v", err)
-		return []CryptoUsage{}, fmt.Errorf("failed to query the all urls table: %v", err)
+		return []CryptoUsage{}, err
+	}
+	var usages []CryptoUsage
+	for _, h := range hashes {
+		usages = append(usages, usagesByHash[h]...)
+	}
+	return usages, nil
+}
+
+// queryCryptoUsageByURLHashes runs the usage query of GetCryptoUsageByURLHashes, grouping the results by URL hash.
+func (m *CryptoUsageModel) queryCryptoUsageByURLHashes(hashes []string) (map[string][]CryptoUsage, error) {
+	ctx, query := startQuery(m.ctx, "CryptoUsageModel.GetCryptoUsageByURLHashes", attribute.Int("hashes", len(hashes)))
+	rows := 0
+	usages := make(map[string][]CryptoUsage, len(hashes))
+	args := queryArgs(hashes)
+	for start := 0; start < len(args); start += maxQueryParams {
+		chunk := args[start:min(start+maxQueryParams, len(args))]
+		var chunkUsages []CryptoUsage
+		err := m.q.SelectContext(ctx, &chunkUsages,
+			"SELECT url_hash AS url_hash, algorithm_name, strength "+
+				"FROM component_crypto c "+
+				"WHERE url_hash in ("+bindParams(len(chunk))+")",
+			chunk...)
+		if err != nil {
+			query.end(rows, err)
+			m.s.Errorf("Failed to query cryptoUsage:  %v", err)
+			return nil, fmt.Errorf("failed to query the all urls table: %v", err)
+		}
+		rows += len(chunkUsages)
+		for _, u := range chunkUsages {
+			usages[u.URLHash] = append(usages[u.URLHash], u)
+		}
 	}
+	query.end(rows, nil)
 	return usages, nil
 }
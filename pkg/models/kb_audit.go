@@ -0,0 +1,347 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	"go.uber.org/zap"
+)
+
+// strengthRange is the plausible strength (in bits) of an algorithm.
+type strengthRange struct {
+	min, max int
+}
+
+// algorithmStrengths lists the plausible strengths of the well known algorithms found in the KB.
+// Hashes and checksums have a fixed size, so their implausible strengths can be fixed automatically.
+var algorithmStrengths = map[string]strengthRange{
+	"crc32": {32, 32}, "crc64": {64, 64}, "md5": {128, 128}, "mdc2": {128, 128}, "ripemd160": {160, 160},
+	"sha1": {160, 160}, "sha224": {224, 224}, "sha256": {256, 256}, "sha384": {384, 384}, "sha512": {512, 512},
+	"des": {56, 168}, "aes": {128, 256}, "blowfish": {32, 448}, "rc4": {40, 256}, "chacha20": {256, 256},
+	"rsa": {512, 16384}, "dsa": {512, 15360}, "elgamal": {512, 16384},
+}
+
+// kbAuditCheck is a KB audit check that finds the rows of a table matching a condition.
+// The fix (if any) is the SQL applied to those rows.
+type kbAuditCheck struct {
+	name        string
+	table       string
+	description string
+	condition   string
+	sample      string
+	fix         string
+}
+
+// kbAuditChecks lists the row checks of the KB audit. The conditions use the full table names (no aliases),
+// so they can also be used by the DELETE fixes on both SQLite and Postgres.
+var kbAuditChecks = []kbAuditCheck{
+	{name: "orphan-algorithm", table: "component_crypto", description: "Algorithms of URL hashes missing from all_urls",
+		condition: "NOT EXISTS (SELECT 1 FROM all_urls u WHERE u.package_hash = component_crypto.url_hash)",
+		sample:    "url_hash", fix: "DELETE"},
+	{name: "orphan-hint", table: "component_crypto_library", description: "Hints of URL hashes missing from all_urls",
+		condition: "NOT EXISTS (SELECT 1 FROM all_urls u WHERE u.package_hash = component_crypto_library.url_hash)",
+		sample:    "url_hash", fix: "DELETE"},
+	{name: "unknown-crypto-library", table: "component_crypto_library", description: "Hints of crypto libraries missing from crypto_libraries",
+		condition: "NOT EXISTS (SELECT 1 FROM crypto_libraries l WHERE l.id = component_crypto_library.det_id)",
+		sample:    "det_id", fix: "DELETE"},
+	{name: "orphan-tag", table: "crypto_library_tags", description: "Tags of crypto libraries missing from crypto_libraries",
+		condition: "NOT EXISTS (SELECT 1 FROM crypto_libraries l WHERE l.id = crypto_library_tags.library_id)",
+		sample:    "library_id", fix: "DELETE"},
+	{name: "unknown-version", table: "all_urls", description: "URLs of versions missing from versions",
+		condition: "NOT EXISTS (SELECT 1 FROM versions v WHERE v.id = all_urls.version_id)", sample: "purl_name"},
+	{name: "unknown-mine", table: "all_urls", description: "URLs of mines missing from mines",
+		condition: "NOT EXISTS (SELECT 1 FROM mines m WHERE m.id = all_urls.mine_id)", sample: "purl_name"},
+	{name: "empty-version-name", table: "versions", description: "Versions without a name",
+		condition: "COALESCE(version_name, '') = ''", sample: "id"},
+	{name: "missing-semver", table: "versions", description: "Named versions without a semver",
+		condition: "COALESCE(semver, '') = '' AND COALESCE(version_name, '') <> ''", sample: "version_name"},
+}
+
+// kbDuplicateCheck is a KB audit check for rows repeated in a table.
+type kbDuplicateCheck struct {
+	name        string
+	table       string
+	description string
+	group       string // Columns identifying a duplicate
+	rows        string // Distinct rows kept by the fix, for each duplicated URL hash
+	// adjust returns the SQL updating the rows kept in the dedup table, when the GROUP BY cannot pick them
+	adjust func(m *KBAuditModel, duplicates, dedup string) (string, error)
+}
+
+// kbDuplicateChecks lists the duplicate checks of the KB audit. Case variants of an algorithm are duplicates,
+// as the service reports algorithms in lower case.
+var kbDuplicateChecks = []kbDuplicateCheck{
+	{name: "duplicate-algorithm", table: "component_crypto", description: "Algorithms repeated for a URL hash (including case variants)",
+		group: "url_hash, LOWER(algorithm_name)", rows: "SELECT url_hash, LOWER(algorithm_name) AS algorithm_name, MAX(strength) AS strength",
+		adjust: (*KBAuditModel).strongestAlgorithms},
+	{name: "duplicate-hint", table: "component_crypto_library", description: "Hints repeated for a URL hash",
+		group: "url_hash, det_id", rows: "SELECT url_hash, det_id"},
+}
+
+// KBAuditModel checks the KB for inconsistent data.
+type KBAuditModel struct {
+	ctx     context.Context
+	s       *zap.SugaredLogger
+	q       *database.DBQueryContext
+	samples int
+}
+
+// KBAuditFinding is the result of a KB audit check: the number of rows found, some samples and the SQL to fix them,
+// if there is a safe one. Checks that cannot be run (i.e. on older KBs missing a table) report an error instead.
+type KBAuditFinding struct {
+	Check       string   `json:"check"`
+	Table       string   `json:"table"`
+	Description string   `json:"description"`
+	Count       int64    `json:"count"`
+	Samples     []string `json:"samples,omitempty"`
+	Fix         string   `json:"fix,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// KBAuditReport lists the findings of all the KB audit checks.
+type KBAuditReport struct {
+	Issues   int64            `json:"issues"`
+	Findings []KBAuditFinding `json:"findings"`
+}
+
+// NewKBAuditModel creates a new instance of the KB Audit Model, reporting up to the given number of samples per check.
+func NewKBAuditModel(ctx context.Context, s *zap.SugaredLogger, q *database.DBQueryContext, samples int) *KBAuditModel {
+	return &KBAuditModel{ctx: ctx, s: s, q: q, samples: max(samples, 0)}
+}
+
+// Audit runs all the KB checks, reporting the rows found by each of them.
+func (m *KBAuditModel) Audit() KBAuditReport {
+	var report KBAuditReport
+	add := func(finding KBAuditFinding, err error) {
+		if err != nil {
+			m.s.Warnf("Failed to run the %v audit check: %v", finding.Check, err)
+			finding.Error, finding.Count, finding.Samples, finding.Fix = err.Error(), 0, nil, ""
+		}
+		report.Issues += finding.Count
+		report.Findings = append(report.Findings, finding)
+	}
+	for _, check := range kbAuditChecks {
+		add(m.runCheck(check))
+	}
+	add(m.auditStrengths())
+	for _, check := range kbDuplicateChecks {
+		add(m.runDuplicateCheck(check))
+	}
+	return report
+}
+
+// runCheck counts and samples the rows of a table matching the check condition.
+func (m *KBAuditModel) runCheck(check kbAuditCheck) (KBAuditFinding, error) {
+	finding := KBAuditFinding{Check: check.name, Table: check.table, Description: check.description}
+	// Table and column names come from a fixed list, so they are safe to build into the statement
+	where := " FROM " + check.table + " WHERE " + check.condition
+	if err := m.count(&finding, "SELECT COUNT(*)"+where); err != nil || finding.Count == 0 {
+		return finding, err
+	}
+	if err := m.sample(&finding, "SELECT DISTINCT "+check.sample+where); err != nil {
+		return finding, err
+	}
+	if check.fix == "DELETE" {
+		finding.Fix = "DELETE" + where + ";"
+	}
+	return finding, nil
+}
+
+// runDuplicateCheck counts and samples the groups of rows repeated in a table.
+// The fix rewrites the rows of the URL hashes with duplicates, keeping the distinct ones.
+func (m *KBAuditModel) runDuplicateCheck(check kbDuplicateCheck) (KBAuditFinding, error) {
+	finding := KBAuditFinding{Check: check.name, Table: check.table, Description: check.description}
+	duplicates := "FROM " + check.table + " GROUP BY " + check.group + " HAVING COUNT(*) > 1"
+	if err := m.count(&finding, "SELECT COUNT(*) FROM (SELECT url_hash "+duplicates+") d"); err != nil || finding.Count == 0 {
+		return finding, err
+	}
+	if err := m.sample(&finding, "SELECT "+strings.ReplaceAll(check.group, ", ", " || ' ' || ")+" "+duplicates); err != nil {
+		return finding, err
+	}
+	dedup := "audit_" + check.table
+	adjust := ""
+	if check.adjust != nil {
+		statements, err := check.adjust(m, duplicates, dedup)
+		if err != nil {
+			return finding, err
+		}
+		adjust = statements + "\n"
+	}
+	finding.Fix = fmt.Sprintf("CREATE TABLE %[1]s AS %[2]s FROM %[3]s WHERE url_hash IN (SELECT url_hash %[4]s) GROUP BY %[5]s;\n"+
+		"%[7]sDELETE FROM %[3]s WHERE url_hash IN (SELECT url_hash FROM %[1]s);\n"+
+		"INSERT INTO %[3]s (%[6]s) SELECT %[6]s FROM %[1]s;\n"+
+		"DROP TABLE %[1]s;",
+		dedup, check.rows, check.table, duplicates, check.group, strings.Join(selectedColumns(check.rows), ", "), adjust)
+	return finding, nil
+}
+
+// strongestAlgorithms keeps the highest strength of each duplicated algorithm in the dedup table. The strengths are
+// compared numerically (using the upper bound of ranges, i.e. 128-256) here rather than in SQL, so the fix only uses
+// literal values and runs on both SQLite and Postgres. Each update only applies if the strength is still in the KB
+// when the fix runs, so it does not undo the implausible-strength fixes.
+func (m *KBAuditModel) strongestAlgorithms(duplicates, dedup string) (string, error) {
+	var algorithms []struct {
+		URLHash   string         `db:"url_hash"`
+		Algorithm string         `db:"algorithm"`
+		Strength  sql.NullString `db:"strength"`
+	}
+	err := m.q.SelectContext(m.ctx, &algorithms, "SELECT url_hash, LOWER(algorithm_name) AS algorithm, strength FROM component_crypto "+
+		"WHERE url_hash IN (SELECT url_hash "+duplicates+") ORDER BY 1, 2, 3")
+	if err != nil {
+		return "", fmt.Errorf("failed to query the duplicated component_crypto algorithms: %v", err)
+	}
+	kept := algorithms[:0]
+	for _, a := range algorithms {
+		if last := len(kept) - 1; last >= 0 && kept[last].URLHash == a.URLHash && kept[last].Algorithm == a.Algorithm {
+			if strengthBits(a.Strength) > strengthBits(kept[last].Strength) {
+				kept[last].Strength = a.Strength
+			}
+			continue
+		}
+		kept = append(kept, a)
+	}
+	var updates []string
+	for _, a := range kept {
+		if !a.Strength.Valid {
+			continue
+		}
+		hash, algorithm, strength := sqlString(a.URLHash), sqlString(a.Algorithm), sqlString(a.Strength.String)
+		updates = append(updates, fmt.Sprintf("UPDATE %[1]s SET strength = %[4]s WHERE url_hash = %[2]s AND algorithm_name = %[3]s AND EXISTS "+
+			"(SELECT 1 FROM component_crypto WHERE url_hash = %[2]s AND LOWER(algorithm_name) = %[3]s AND strength = %[4]s);",
+			dedup, hash, algorithm, strength))
+	}
+	return strings.Join(updates, "\n"), nil
+}
+
+// strengthBits returns the bits of a strength, using the upper bound of ranges (i.e. 128-256).
+// Strengths that are not numbers rank above missing ones, and below any number.
+func strengthBits(strength sql.NullString) int {
+	if !strength.Valid {
+		return -2
+	}
+	_, high, found := strings.Cut(strength.String, "-")
+	if !found {
+		high = strength.String
+	}
+	bits, err := strconv.Atoi(strings.TrimSpace(high))
+	if err != nil {
+		return -1
+	}
+	return bits
+}
+
+// sqlString quotes a value as an SQL string literal.
+func sqlString(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// auditStrengths checks the strength of the well known algorithms of component_crypto against their plausible range.
+// Implausible strengths of fixed size algorithms are fixed with their only plausible value.
+func (m *KBAuditModel) auditStrengths() (KBAuditFinding, error) {
+	finding := KBAuditFinding{Check: "implausible-strength", Table: "component_crypto",
+		Description: "Algorithms with a strength outside of their plausible range"}
+	var strengths []struct {
+		Algorithm string         `db:"algorithm"`
+		Strength  sql.NullString `db:"strength"`
+		Total     int64          `db:"total"`
+	}
+	err := m.q.SelectContext(m.ctx, &strengths,
+		"SELECT LOWER(algorithm_name) AS algorithm, strength, COUNT(*) AS total "+
+			"FROM component_crypto GROUP BY LOWER(algorithm_name), strength ORDER BY 1, 2")
+	if err != nil {
+		return finding, fmt.Errorf("failed to query the component_crypto strengths: %v", err)
+	}
+	var fixes []string
+	for _, s := range strengths {
+		plausible, known := algorithmStrengths[s.Algorithm]
+		if !known || plausibleStrength(s.Strength.String, plausible) {
+			continue
+		}
+		finding.Count += s.Total
+		strength, match := "NULL", "strength IS NULL"
+		if s.Strength.Valid {
+			strength, match = s.Strength.String, fmt.Sprintf("strength = '%v'", s.Strength.String)
+		}
+		if len(finding.Samples) < m.samples {
+			finding.Samples = append(finding.Samples, fmt.Sprintf("%v strength %v (%d rows)", s.Algorithm, strength, s.Total))
+		}
+		if plausible.min == plausible.max && !strings.Contains(s.Strength.String, "'") {
+			fixes = append(fixes, fmt.Sprintf("UPDATE component_crypto SET strength = '%d' WHERE LOWER(algorithm_name) = '%v' AND %v;",
+				plausible.min, s.Algorithm, match))
+		}
+	}
+	finding.Fix = strings.Join(fixes, "\n")
+	return finding, nil
+}
+
+// plausibleStrength reports whether a strength (or range of strengths, i.e. 128-256) is inside the plausible range.
+func plausibleStrength(strength string, plausible strengthRange) bool {
+	low, high, found := strings.Cut(strength, "-")
+	if !found {
+		high = low
+	}
+	for _, value := range []string{low, high} {
+		bits, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || bits < plausible.min || bits > plausible.max {
+			return false
+		}
+	}
+	return true
+}
+
+// count runs a COUNT query, storing the result in the finding.
+func (m *KBAuditModel) count(finding *KBAuditFinding, stmt string) error {
+	var counts []int64
+	if err := m.q.SelectContext(m.ctx, &counts, stmt); err != nil {
+		return fmt.Errorf("failed to query the %v table: %v", finding.Table, err)
+	}
+	if len(counts) > 0 {
+		finding.Count = counts[0]
+	}
+	return nil
+}
+
+// sample stores up to the model number of samples returned by the query in the finding.
+func (m *KBAuditModel) sample(finding *KBAuditFinding, stmt string) error {
+	if m.samples == 0 {
+		return nil
+	}
+	if err := m.q.SelectContext(m.ctx, &finding.Samples, stmt+" LIMIT $1", m.samples); err != nil {
+		return fmt.Errorf("failed to query the %v table: %v", finding.Table, err)
+	}
+	return nil
+}
+
+// selectedColumns returns the names of the columns of a SELECT clause, using their alias if they have one.
+func selectedColumns(selectClause string) []string {
+	fields := strings.Split(strings.TrimPrefix(selectClause, "SELECT "), ",")
+	columns := make([]string, 0, len(fields))
+	for _, f := range fields {
+		f = strings.TrimSpace(f)
+		if _, alias, found := strings.Cut(f, " AS "); found {
+			f = alias
+		}
+		columns = append(columns, f)
+	}
+	return columns
+}
@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestKBAudit(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	conn := sqliteConn(t, ctx, db)
+	defer CloseConn(conn)
+	if err = LoadTestSQLData(db, ctx, conn); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	model := NewKBAuditModel(ctx, s, database.NewDBSelectContext(s, nil, conn, false), 3)
+
+	report := model.Audit()
+	findings := make(map[string]KBAuditFinding, len(report.Findings))
+	for _, f := range report.Findings {
+		if len(f.Error) > 0 {
+			t.Errorf("Audit() check %v failed: %v", f.Check, f.Error)
+		}
+		if len(f.Samples) > 3 {
+			t.Errorf("Audit() check %v returned %v samples", f.Check, len(f.Samples))
+		}
+		findings[f.Check] = f
+	}
+	expected := map[string]int64{"orphan-algorithm": 48, "orphan-hint": 7, "unknown-crypto-library": 6, "orphan-tag": 6,
+		"unknown-version": 0, "unknown-mine": 0, "empty-version-name": 1, "implausible-strength": 16,
+		"duplicate-algorithm": 13, "duplicate-hint": 0}
+	for check, count := range expected {
+		if findings[check].Count != count {
+			t.Errorf("Audit() check %v found %v rows, expected %v", check, findings[check].Count, count)
+		}
+	}
+	if findings["missing-semver"].Count == 0 || len(findings["missing-semver"].Fix) > 0 {
+		t.Errorf("Audit() missing-semver = %+v", findings["missing-semver"])
+	}
+	strengths := findings["implausible-strength"]
+	if !strings.Contains(strengths.Fix, "SET strength = '256' WHERE LOWER(algorithm_name) = 'sha256' AND strength = '32'") ||
+		strings.Contains(strengths.Fix, "'rc4'") {
+		t.Errorf("Audit() implausible-strength fix = %v", strengths.Fix)
+	}
+
+	// Applying the fixes (in any order) leaves the rows they apply to clean
+	for i := len(report.Findings) - 1; i >= 0; i-- {
+		f := report.Findings[i]
+		if len(f.Fix) == 0 {
+			continue
+		}
+		if _, err = conn.ExecContext(ctx, f.Fix); err != nil {
+			t.Fatalf("failed to apply the %v fix: %v", f.Check, err)
+		}
+		if f.Check == "duplicate-algorithm" {
+			var algorithms []string
+			err = conn.SelectContext(ctx, &algorithms, "SELECT algorithm_name FROM component_crypto "+
+				"WHERE url_hash = '000003fd86c88389555041342793d402' ORDER BY 1")
+			if err != nil || strings.Join(algorithms, ",") != "blowfish,elgamal,fortuna,md5,mdc2,pbe,rsa-oaep,sha1,shax,srp,ssha" {
+				t.Errorf("Audit() duplicate-algorithm fix left the algorithms %v, %v", algorithms, err)
+			}
+		}
+	}
+	report = model.Audit()
+	for _, f := range report.Findings {
+		if f.Check != "implausible-strength" && f.Check != "empty-version-name" && f.Check != "missing-semver" && f.Count > 0 {
+			t.Errorf("Audit() check %v found %v rows after the fixes", f.Check, f.Count)
+		}
+	}
+
+	if _, err = conn.ExecContext(ctx, "DROP TABLE crypto_library_tags"); err != nil {
+		t.Fatalf("failed to drop the tags table: %v", err)
+	}
+	for _, f := range model.Audit().Findings {
+		if (f.Check == "orphan-tag") != (len(f.Error) > 0) {
+			t.Errorf("Audit() check %v error = %q", f.Check, f.Error)
+		}
+	}
+}
+
+func TestKBAuditStrengthFixes(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	defer CloseDB(db)
+	conn := sqliteConn(t, ctx, db)
+	defer CloseConn(conn)
+	err = RunTestSQL(db, ctx, conn, "CREATE TABLE component_crypto (url_hash text, algorithm_name text, strength text);"+
+		"INSERT INTO component_crypto VALUES ('h1', 'aes', '64'), ('h1', 'AES', '256'), ('h2', 'aes', '192'), ('h2', 'Aes', '128-256'),"+
+		"('h3', 'sha256', NULL), ('h3', 'SHA256', NULL), ('h4', 'sha256', ''), ('h5', 'sha256', '32'), ('h5', 'sha256', '512');")
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	findings := make(map[string]KBAuditFinding)
+	for _, f := range NewKBAuditModel(ctx, s, database.NewDBSelectContext(s, nil, conn, false), 3).Audit().Findings {
+		findings[f.Check] = f
+	}
+	// Duplicates keep the numerically highest strength (the upper bound of ranges), and NULL strengths are matched with IS NULL
+	strengths := findings["implausible-strength"]
+	if strengths.Count != 6 || !strings.Contains(strengths.Fix, "WHERE LOWER(algorithm_name) = 'sha256' AND strength IS NULL;") ||
+		!strings.Contains(strengths.Fix, "WHERE LOWER(algorithm_name) = 'sha256' AND strength = '';") {
+		t.Errorf("Audit() implausible-strength = %+v", strengths)
+	}
+	if strings.Join(strengths.Samples, ",") != "aes strength 64 (1 rows),sha256 strength NULL (2 rows),sha256 strength  (1 rows)" {
+		t.Errorf("Audit() implausible-strength samples = %v", strengths.Samples)
+	}
+	// The fixes only use literal values, so the same script runs on Postgres
+	if !strings.Contains(findings["duplicate-algorithm"].Fix, "UPDATE audit_component_crypto SET strength = '256' WHERE url_hash = 'h1' "+
+		"AND algorithm_name = 'aes' AND EXISTS (SELECT 1 FROM component_crypto WHERE url_hash = 'h1' AND LOWER(algorithm_name) = 'aes' AND strength = '256');") {
+		t.Errorf("Audit() duplicate-algorithm fix = %v", findings["duplicate-algorithm"].Fix)
+	}
+	// Applied in the order of the fix script, the duplicates keep the fixed strengths
+	for _, check := range []string{"implausible-strength", "duplicate-algorithm"} {
+		if _, err = conn.ExecContext(ctx, findings[check].Fix); err != nil {
+			t.Fatalf("failed to apply the %v fix: %v", check, err)
+		}
+	}
+	var rows []string
+	err = conn.SelectContext(ctx, &rows, "SELECT url_hash || ' ' || algorithm_name || ' ' || COALESCE(strength, 'NULL') "+
+		"FROM component_crypto ORDER BY 1")
+	expected := "h1 aes 256,h2 aes 128-256,h3 sha256 256,h4 sha256 256,h5 sha256 256"
+	if err != nil || strings.Join(rows, ",") != expected {
+		t.Errorf("Audit() fixes left the rows %v (%v), expected %v", rows, err, expected)
+	}
+}
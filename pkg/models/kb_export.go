@@ -0,0 +1,257 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// kbExportTable describes a KB table copied into an exported KB, with the columns queried by the service.
+type kbExportTable struct {
+	name    string
+	columns []string
+	schema  string
+}
+
+// KB tables copied by an export. Their schemas only hold the columns used by the service.
+var (
+	exportMines = kbExportTable{name: "mines", columns: []string{"id", "name", "purl_type"},
+		schema: "CREATE TABLE mines (id INTEGER PRIMARY KEY, name TEXT DEFAULT '', purl_type TEXT DEFAULT '')"}
+	exportAllUrls = kbExportTable{name: "all_urls",
+		columns: []string{"package_hash", "url", "component", "version_id", "purl_name", "mine_id", "date", "is_mined"},
+		schema: "CREATE TABLE all_urls (package_hash TEXT DEFAULT '', url TEXT DEFAULT '', component TEXT DEFAULT '', " +
+			"version_id INTEGER, purl_name TEXT DEFAULT '', mine_id INTEGER, date TEXT DEFAULT '', is_mined BOOLEAN DEFAULT true);" +
+			"CREATE INDEX idx_all_urls_purl_name ON all_urls(purl_name);" +
+			"CREATE INDEX idx_all_urls_package_hash ON all_urls(package_hash)"}
+	exportVersions = kbExportTable{name: "versions", columns: []string{"id", "version_name", "semver"},
+		schema: "CREATE TABLE versions (id INTEGER PRIMARY KEY, version_name TEXT NOT NULL, semver TEXT DEFAULT '')"}
+	exportComponentCrypto = kbExportTable{name: "component_crypto", columns: []string{"url_hash", "algorithm_name", "strength"},
+		schema: "CREATE TABLE component_crypto (url_hash TEXT NOT NULL, algorithm_name TEXT NOT NULL, strength TEXT);" +
+			"CREATE INDEX idx_component_crypto ON component_crypto(url_hash)"}
+	exportComponentCryptoLibrary = kbExportTable{name: "component_crypto_library", columns: []string{"url_hash", "det_id"},
+		schema: "CREATE TABLE component_crypto_library (url_hash TEXT NOT NULL, det_id TEXT NOT NULL);" +
+			"CREATE INDEX idx_component_crypto_library ON component_crypto_library(url_hash)"}
+	exportCryptoLibraries = kbExportTable{name: "crypto_libraries",
+		columns: []string{"id", "name", "description", "url", "category", "purl"}, schema: cryptoLibrariesSchema}
+	exportCryptoLibraryTags = kbExportTable{name: "crypto_library_tags", columns: []string{"library_id", "tag"},
+		schema: cryptoLibraryTagsSchema}
+)
+
+// KBExportModel copies the KB rows of a list of purls into a new (SQLite) database.
+type KBExportModel struct {
+	ctx context.Context
+	s   *zap.SugaredLogger
+	db  *sqlx.DB
+}
+
+// KBExportSummary reports the (distinct) purls and the rows copied by an export.
+type KBExportSummary struct {
+	Purls   int            `json:"purls"`
+	Missing []PurlNameType `json:"missing"`
+	Tables  []TableCount   `json:"tables"`
+}
+
+// NewKBExportModel creates a new instance of the KB Export Model, reading from the given KB.
+func NewKBExportModel(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB) *KBExportModel {
+	return &KBExportModel{ctx: ctx, s: s, db: db}
+}
+
+// ExportPurls copies the URLs (of every version) of the given purls, along with their versions, mines, algorithms and
+// crypto library hints, into the destination database, in a single transaction.
+// The destination tables are created, so it is expected to be empty.
+func (m *KBExportModel) ExportPurls(purls []PurlNameType, dst *sqlx.DB) (KBExportSummary, error) {
+	if len(purls) == 0 {
+		return KBExportSummary{}, errors.New("please specify a valid Purl list to export")
+	}
+	tx, err := dst.BeginTxx(m.ctx, nil)
+	if err != nil {
+		return KBExportSummary{}, fmt.Errorf("failed to start a transaction: %v", err)
+	}
+	defer func() {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			m.s.Warnf("Failed to rollback the KB export transaction: %v", rerr)
+		}
+	}()
+	for _, table := range []kbExportTable{exportMines, exportAllUrls, exportVersions, exportComponentCrypto,
+		exportComponentCryptoLibrary, exportCryptoLibraries, exportCryptoLibraryTags} {
+		if _, err = tx.ExecContext(m.ctx, table.schema); err != nil {
+			return KBExportSummary{}, fmt.Errorf("failed to create the %v table: %v", table.name, err)
+		}
+	}
+	summary := KBExportSummary{Missing: []PurlNameType{}}
+	count := func(table kbExportTable, rows [][]any) {
+		summary.Tables = append(summary.Tables, TableCount{Table: table.name, Rows: int64(len(rows))})
+	}
+	// Mines are few and needed to resolve the purl types, so they are all copied
+	mines, err := m.copyRows(tx, exportMines, "", nil, nil)
+	if err != nil {
+		return KBExportSummary{}, err
+	}
+	count(exportMines, mines)
+	mineTypes := make(map[string]string, len(mines))
+	for _, row := range mines {
+		mineTypes[fmt.Sprint(row[0])] = fmt.Sprint(row[2])
+	}
+	requested := make(map[PurlNameType]bool, len(purls))
+	var names []any
+	for _, p := range purls {
+		if !requested[p] {
+			requested[p] = true
+			names = append(names, p.Name)
+		}
+	}
+	summary.Purls = len(requested)
+	found := make(map[PurlNameType]bool, len(requested))
+	urls, err := m.copyRows(tx, exportAllUrls, "purl_name", names, func(row []any) bool {
+		key := PurlNameType{Name: fmt.Sprint(row[4]), Type: mineTypes[fmt.Sprint(row[5])]}
+		found[key] = found[key] || requested[key]
+		return requested[key]
+	})
+	if err != nil {
+		return KBExportSummary{}, err
+	}
+	count(exportAllUrls, urls)
+	for p := range requested {
+		if !found[p] {
+			summary.Missing = append(summary.Missing, p)
+		}
+	}
+	slices.SortFunc(summary.Missing, func(a, b PurlNameType) int {
+		return cmp.Or(strings.Compare(a.Type, b.Type), strings.Compare(a.Name, b.Name))
+	})
+	versions, err := m.copyRows(tx, exportVersions, "id", columnValues(urls, 3), nil)
+	if err != nil {
+		return KBExportSummary{}, err
+	}
+	count(exportVersions, versions)
+	hashes := columnValues(urls, 0)
+	algorithms, err := m.copyRows(tx, exportComponentCrypto, "url_hash", hashes, nil)
+	if err != nil {
+		return KBExportSummary{}, err
+	}
+	count(exportComponentCrypto, algorithms)
+	hints, err := m.copyRows(tx, exportComponentCryptoLibrary, "url_hash", hashes, nil)
+	if err != nil {
+		return KBExportSummary{}, err
+	}
+	count(exportComponentCryptoLibrary, hints)
+	detIDs := columnValues(hints, 1)
+	libraries, err := m.copyRows(tx, exportCryptoLibraries, "id", detIDs, nil)
+	if err != nil {
+		return KBExportSummary{}, err
+	}
+	count(exportCryptoLibraries, libraries)
+	tags, err := m.copyRows(tx, exportCryptoLibraryTags, "library_id", detIDs, nil)
+	if err != nil {
+		// Older KBs do not have the tags table, so the hints are exported untagged
+		m.s.Warnf("Failed to export the crypto library tags: %v", err)
+	}
+	count(exportCryptoLibraryTags, tags)
+	if err = tx.Commit(); err != nil {
+		return KBExportSummary{}, fmt.Errorf("failed to commit the KB export: %v", err)
+	}
+	m.s.Debugf("Exported %d of %d purls.", len(requested)-len(summary.Missing), len(requested))
+	return summary, nil
+}
+
+// copyRows copies the rows of a KB table whose key column is in the given list (or all of them, if no key is given)
+// into the destination transaction, and returns them. Rows are skipped if the keep function does not accept them.
+func (m *KBExportModel) copyRows(tx *sqlx.Tx, table kbExportTable, key string, values []any, keep func(row []any) bool) ([][]any, error) {
+	columns := strings.Join(table.columns, ", ")
+	insert := "INSERT INTO " + table.name + " (" + columns + ") VALUES (" + bindParams(len(table.columns)) + ")"
+	var copied [][]any
+	for start := 0; start == 0 || start < len(values); start += maxQueryParams {
+		// Table and column names come from a fixed list, so they are safe to build into the statement
+		stmt := "SELECT " + columns + " FROM " + table.name
+		var args []any
+		if len(key) > 0 {
+			if len(values) == 0 {
+				break
+			}
+			args = values[start:min(start+maxQueryParams, len(values))]
+			stmt += " WHERE " + key + " IN (" + bindParams(len(args)) + ")"
+		}
+		rows, err := m.db.QueryxContext(m.ctx, stmt, args...)
+		if err != nil {
+			m.s.Errorf("Failed to query the %v table: %v", table.name, err)
+			return nil, fmt.Errorf("failed to query the %v table: %v", table.name, err)
+		}
+		var chunk [][]any
+		for rows.Next() {
+			row, err := rows.SliceScan()
+			if err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("failed to read the %v table: %v", table.name, err)
+			}
+			for i, v := range row {
+				row[i] = exportValue(v)
+			}
+			if keep == nil || keep(row) {
+				chunk = append(chunk, row)
+			}
+		}
+		err = rows.Err()
+		_ = rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the %v table: %v", table.name, err)
+		}
+		for _, row := range chunk {
+			if _, err = tx.ExecContext(m.ctx, insert, row...); err != nil {
+				return nil, fmt.Errorf("failed to export the %v table: %v", table.name, err)
+			}
+		}
+		copied = append(copied, chunk...)
+		if len(key) == 0 {
+			break
+		}
+	}
+	return copied, nil
+}
+
+// exportValue converts a value read from the KB into one that can be written to SQLite.
+func exportValue(v any) any {
+	switch value := v.(type) {
+	case []byte:
+		return string(value)
+	case time.Time:
+		return value.Format(time.DateOnly)
+	}
+	return v
+}
+
+// columnValues returns the distinct, non-empty values of a column of the given rows.
+func columnValues(rows [][]any, column int) []any {
+	seen := make(map[string]bool, len(rows))
+	var values []any
+	for _, row := range rows {
+		if v := row[column]; v != nil && fmt.Sprint(v) != "" && !seen[fmt.Sprint(v)] {
+			seen[fmt.Sprint(v)] = true
+			values = append(values, v)
+		}
+	}
+	return values
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestExportPurls(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	db.SetMaxOpenConns(1)
+	defer CloseDB(db)
+	if err = LoadTestSQLData(db, ctx, nil); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	dst, err := sqlx.Connect("sqlite", filepath.Join(t.TempDir(), "export.sqlite"))
+	if err != nil {
+		t.Fatalf("failed to create the export database: %v", err)
+	}
+	defer CloseDB(dst)
+	model := NewKBExportModel(ctx, s, db)
+
+	purls := []PurlNameType{
+		{Name: "scanoss/engine", Type: "github"},
+		{Name: "pineappleea/pineapple-src", Type: "github"},
+		{Name: "scanoss/engine", Type: "npm"}, // Same name, but not in the KB
+		{Name: "scanoss/engine", Type: "github"},
+	}
+	summary, err := model.ExportPurls(purls, dst)
+	if err != nil {
+		t.Fatalf("ExportPurls() error = %v", err)
+	}
+	if summary.Purls != 3 || len(summary.Missing) != 1 || summary.Missing[0].Type != "npm" {
+		t.Errorf("ExportPurls() summary = %+v", summary)
+	}
+	expected := map[string]int64{"mines": 43, "all_urls": 7, "versions": 5, "component_crypto": 15,
+		"component_crypto_library": 12, "crypto_libraries": 6, "crypto_library_tags": 12}
+	for _, count := range summary.Tables {
+		var rows int64
+		if err = dst.Get(&rows, "SELECT COUNT(*) FROM "+count.Table); err != nil || rows != count.Rows || rows != expected[count.Table] {
+			t.Errorf("ExportPurls() exported %v rows into %v (reported %v, expected %v): %v",
+				rows, count.Table, count.Rows, expected[count.Table], err)
+		}
+	}
+	if len(summary.Tables) != len(expected) {
+		t.Errorf("ExportPurls() reported %v tables, expected %v", len(summary.Tables), len(expected))
+	}
+
+	// The exported KB can be queried by the service
+	conn := sqliteConn(t, ctx, dst)
+	defer CloseConn(conn)
+	urls, err := NewAllURLModel(ctx, s, database.NewDBSelectContext(s, dst, conn, false)).
+		GetUrlsByPurlNameTypeVersion("pineappleea/pineapple-src", "github", "v5.4.7")
+	if err != nil || urls.URLHash != "c8b5647654826091fb65a97bec820eb9" {
+		t.Errorf("GetUrlsByPurlNameTypeVersion() on the export = %+v, %v", urls, err)
+	}
+
+	if _, err = model.ExportPurls(nil, dst); err == nil {
+		t.Errorf("ExportPurls() expected an error exporting no purls")
+	}
+	if _, err = model.ExportPurls(purls, dst); err == nil {
+		t.Errorf("ExportPurls() expected an error exporting into a KB with tables")
+	}
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// kbSchemaTables lists the KB tables (and their columns) queried by the service.
+// The crypto library tags are optional, so they are not required by the schema check.
+var kbSchemaTables = []kbExportTable{exportMines, exportAllUrls, exportVersions, exportComponentCrypto,
+	exportComponentCryptoLibrary, exportCryptoLibraries}
+
+// kbDataTables lists the KB tables that must hold data for the service to answer requests.
+var kbDataTables = []string{"mines", "all_urls", "versions", "component_crypto"}
+
+type KBHealthModel struct {
+	ctx context.Context
+	s   *zap.SugaredLogger
+	db  *sqlx.DB
+}
+
+// NewKBHealthModel creates a new instance of the KB Health Model.
+func NewKBHealthModel(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB) *KBHealthModel {
+	return &KBHealthModel{ctx: ctx, s: s, db: db}
+}
+
+// Ping checks that the KB database can be reached.
+func (m *KBHealthModel) Ping() error {
+	if err := m.db.PingContext(m.ctx); err != nil {
+		m.s.Warnf("Failed to ping the KB database: %v", err)
+		return fmt.Errorf("failed to ping the database: %v", err)
+	}
+	return nil
+}
+
+// CheckSchema checks that the KB tables have all the columns queried by the service.
+func (m *KBHealthModel) CheckSchema() error {
+	for _, table := range kbSchemaTables {
+		// Table and column names come from a fixed list, so they are safe to build into the statement
+		query := fmt.Sprintf("SELECT %s FROM %s WHERE 1 = 0", strings.Join(table.columns, ", "), table.name)
+		rows, err := m.db.QueryxContext(m.ctx, query)
+		if err != nil {
+			m.s.Warnf("KB table %v does not match the expected schema: %v", table.name, err)
+			return fmt.Errorf("the %v table does not match the expected schema: %v", table.name, err)
+		}
+		if err = rows.Close(); err != nil {
+			return fmt.Errorf("failed to close the %v schema query: %v", table.name, err)
+		}
+	}
+	return nil
+}
+
+// GetEmptyTables returns the KB tables that must hold data, but have no rows.
+func (m *KBHealthModel) GetEmptyTables() ([]string, error) {
+	var empty []string
+	for _, table := range kbDataTables {
+		var rows []int
+		if err := m.db.SelectContext(m.ctx, &rows, "SELECT 1 FROM "+table+" LIMIT 1"); err != nil {
+			m.s.Warnf("Failed to query the %v table: %v", table, err)
+			return nil, fmt.Errorf("failed to query the %v table: %v", table, err)
+		}
+		if len(rows) == 0 {
+			empty = append(empty, table)
+		}
+	}
+	return empty, nil
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestKBHealth(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db := sqliteSetup(t) // Setup SQL Lite DB
+	db.SetMaxOpenConns(1)
+	defer CloseDB(db)
+	model := NewKBHealthModel(ctx, s, db)
+	if err = model.Ping(); err != nil {
+		t.Errorf("Ping() unexpected error: %v", err)
+	}
+	if err = model.CheckSchema(); err == nil {
+		t.Errorf("CheckSchema() expected an error without any KB tables")
+	}
+	if err = LoadTestSQLData(db, ctx, nil); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	if err = model.CheckSchema(); err != nil {
+		t.Errorf("CheckSchema() unexpected error: %v", err)
+	}
+	empty, err := model.GetEmptyTables()
+	if err != nil || len(empty) != 0 {
+		t.Errorf("GetEmptyTables() = %v, %v", empty, err)
+	}
+	if err = RunTestSQL(db, ctx, nil, "DELETE FROM component_crypto"); err != nil {
+		t.Fatalf("failed to clear the component_crypto table: %v", err)
+	}
+	empty, err = model.GetEmptyTables()
+	if err != nil || !slices.Equal(empty, []string{"component_crypto"}) {
+		t.Errorf("GetEmptyTables() = %v, %v", empty, err)
+	}
+	if err = RunTestSQL(db, ctx, nil, "ALTER TABLE versions DROP COLUMN semver"); err != nil {
+		t.Fatalf("failed to alter the versions table: %v", err)
+	}
+	if err = model.CheckSchema(); err == nil {
+		t.Errorf("CheckSchema() expected an error with a missing column")
+	}
+	CloseDB(db)
+	if err = model.Ping(); err == nil {
+		t.Errorf("Ping() expected an error with a closed database")
+	}
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	"go.uber.org/zap"
+)
+
+// kbTables lists the KB tables queried by the service.
+var kbTables = []string{"all_urls", "mines", "versions", "component_crypto", "crypto_libraries", "component_crypto_library"}
+
+type KBStatsModel struct {
+	ctx context.Context
+	s   *zap.SugaredLogger
+	q   *database.DBQueryContext
+}
+
+type TableCount struct {
+	Table string
+	Rows  int64
+}
+
+// NewKBStatsModel creates a new instance of the KB Stats Model.
+func NewKBStatsModel(ctx context.Context, s *zap.SugaredLogger, q *database.DBQueryContext) *KBStatsModel {
+	return &KBStatsModel{ctx: ctx, s: s, q: q}
+}
+
+// GetTableCounts returns the number of rows in each of the KB tables.
+func (m *KBStatsModel) GetTableCounts() ([]TableCount, error) {
+	counts := make([]TableCount, 0, len(kbTables))
+	for _, table := range kbTables {
+		var rows []int64
+		// Table names come from a fixed list, so they are safe to build into the statement
+		err := m.q.SelectContext(m.ctx, &rows, "SELECT COUNT(*) FROM "+table)
+		if err != nil {
+			m.s.Errorf("Failed to count the rows of %v: %v", table, err)
+			return nil, fmt.Errorf("failed to count the rows of the %v table: %v", table, err)
+		}
+		counts = append(counts, TableCount{Table: table, Rows: rows[0]})
+	}
+	return counts, nil
+}
+
+// GetLatestURLDate returns the date of the most recent version loaded into the all_urls table.
+// An empty string is returned if the table has no dates.
+func (m *KBStatsModel) GetLatestURLDate() (string, error) {
+	var dates []sql.NullString
+	err := m.q.SelectContext(m.ctx, &dates, "SELECT MAX(date) FROM all_urls")
+	if err != nil {
+		m.s.Errorf("Failed to query the latest all_urls date: %v", err)
+		return "", fmt.Errorf("failed to query the all urls table: %v", err)
+	}
+	if len(dates) == 0 || !dates[0].Valid {
+		return "", nil
+	}
+	return dates[0].String, nil
+}
@@ -0,0 +1,199 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"crypto/md5"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// syntheticMines are the mines (and purl types) of the synthetic components.
+var syntheticMines = []struct {
+	id        int
+	name      string
+	purlType  string
+	namespace string
+}{
+	{5, "github.com", "github", "synthetic"},
+	{2, "npmjs.org", "npm", "synthetic"},
+	{0, "maven.org", "maven", "org.synthetic"},
+	{3, "pythonhosted.org", "pypi", "synthetic"},
+}
+
+// syntheticAlgorithms are the algorithms (and strengths) picked for the synthetic URL hashes.
+var syntheticAlgorithms = []CryptoItem{
+	{"aes", "128"}, {"aes", "256"}, {"blowfish", "448"}, {"chacha20", "256"}, {"crc32", "32"}, {"des", "56"},
+	{"des", "168"}, {"dsa", "2048"}, {"md5", "128"}, {"rc4", "128"}, {"rsa", "2048"}, {"rsa", "4096"},
+	{"sha1", "160"}, {"sha256", "256"}, {"sha384", "384"}, {"sha512", "512"},
+}
+
+// syntheticTags are assigned in turn to the synthetic crypto libraries.
+var syntheticTags = []string{"fips", "tls", "pqc", "kms"}
+
+// syntheticLibraries is the number of crypto libraries (hint definitions) of a synthetic KB.
+const syntheticLibraries = 24
+
+// SyntheticKBConfig sizes a synthetic KB.
+type SyntheticKBConfig struct {
+	Purls      int    // Number of components
+	Versions   int    // Versions mined per component
+	Algorithms int    // Algorithms per URL hash
+	Hints      int    // Maximum number of hints per URL hash
+	Seed       uint64 // Seed of the random picks, so KBs can be reproduced
+}
+
+// SyntheticKBModel generates synthetic KBs, to measure how the service scales.
+type SyntheticKBModel struct {
+	ctx context.Context
+	s   *zap.SugaredLogger
+	db  *sqlx.DB
+}
+
+// NewSyntheticKBModel creates a new instance of the Synthetic KB Model, writing into the given (empty) database.
+func NewSyntheticKBModel(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB) *SyntheticKBModel {
+	return &SyntheticKBModel{ctx: ctx, s: s, db: db}
+}
+
+// Generate creates the KB tables and fills them with the configured number of components, versions, algorithms and
+// hints, in a single transaction. It returns the components generated and the number of rows of each table.
+func (m *SyntheticKBModel) Generate(cfg SyntheticKBConfig) ([]PurlNameType, []TableCount, error) {
+	if cfg.Purls <= 0 || cfg.Versions <= 0 || cfg.Algorithms < 0 || cfg.Hints < 0 {
+		return nil, nil, fmt.Errorf("invalid synthetic KB size: %+v", cfg)
+	}
+	tx, err := m.db.BeginTxx(m.ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to start a transaction: %v", err)
+	}
+	defer func() {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			m.s.Warnf("Failed to rollback the synthetic KB transaction: %v", rerr)
+		}
+	}()
+	tables := []kbExportTable{exportMines, exportAllUrls, exportVersions, exportComponentCrypto,
+		exportComponentCryptoLibrary, exportCryptoLibraries, exportCryptoLibraryTags}
+	inserts := make(map[string]*sqlx.Stmt, len(tables))
+	counts := make(map[string]int64, len(tables))
+	for _, table := range tables {
+		if _, err = tx.ExecContext(m.ctx, table.schema); err != nil {
+			return nil, nil, fmt.Errorf("failed to create the %v table: %v", table.name, err)
+		}
+		stmt, err := tx.PreparexContext(m.ctx, "INSERT INTO "+table.name+" VALUES ("+bindParams(len(table.columns))+")")
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to prepare the %v inserts: %v", table.name, err)
+		}
+		defer stmt.Close()
+		inserts[table.name] = stmt
+	}
+	insert := func(table string, values ...any) error {
+		if _, err := inserts[table].ExecContext(m.ctx, values...); err != nil {
+			return fmt.Errorf("failed to insert into the %v table: %v", table, err)
+		}
+		counts[table]++
+		return nil
+	}
+	if err = m.generateDefinitions(insert, cfg); err != nil {
+		return nil, nil, err
+	}
+	purls, err := m.generateComponents(insert, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit the synthetic KB: %v", err)
+	}
+	tableCounts := make([]TableCount, 0, len(tables))
+	for _, table := range tables {
+		tableCounts = append(tableCounts, TableCount{Table: table.name, Rows: counts[table.name]})
+	}
+	return purls, tableCounts, nil
+}
+
+// generateDefinitions inserts the mines, versions and crypto libraries (with their tags) of a synthetic KB.
+func (m *SyntheticKBModel) generateDefinitions(insert func(table string, values ...any) error, cfg SyntheticKBConfig) error {
+	for _, mine := range syntheticMines {
+		if err := insert(exportMines.name, mine.id, mine.name, mine.purlType); err != nil {
+			return err
+		}
+	}
+	for v := range cfg.Versions {
+		name := SyntheticVersion(v)
+		if err := insert(exportVersions.name, v+1, name, name); err != nil {
+			return err
+		}
+	}
+	for l := range syntheticLibraries {
+		id := fmt.Sprintf("library/synthetic-%02d", l)
+		err := insert(exportCryptoLibraries.name, id, fmt.Sprintf("Synthetic %02d", l), "Synthetic crypto library",
+			"https://example.com/synthetic-"+strconv.Itoa(l), "library", fmt.Sprintf("pkg:github/synthetic/library-%02d", l))
+		if err != nil {
+			return err
+		}
+		if err = insert(exportCryptoLibraryTags.name, id, syntheticTags[l%len(syntheticTags)]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateComponents inserts the URLs of every version of the synthetic components, along with their algorithms and hints.
+func (m *SyntheticKBModel) generateComponents(insert func(table string, values ...any) error, cfg SyntheticKBConfig) ([]PurlNameType, error) {
+	rng := rand.New(rand.NewPCG(cfg.Seed, cfg.Seed))
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	purls := make([]PurlNameType, 0, cfg.Purls)
+	for p := range cfg.Purls {
+		mine := syntheticMines[p%len(syntheticMines)]
+		component := fmt.Sprintf("component-%06d", p)
+		purl := PurlNameType{Name: mine.namespace + "/" + component, Type: mine.purlType}
+		purls = append(purls, purl)
+		for v := range cfg.Versions {
+			sum := md5.Sum([]byte(fmt.Sprintf("pkg:%v/%v@%v", purl.Type, purl.Name, SyntheticVersion(v))))
+			hash := hex.EncodeToString(sum[:])
+			date := start.AddDate(0, 0, (p+v*7)%2000).Format(time.DateOnly)
+			err := insert(exportAllUrls.name, hash, "https://example.com/"+purl.Name, component, v+1, purl.Name, mine.id, date, true)
+			if err != nil {
+				return nil, err
+			}
+			for _, a := range rng.Perm(len(syntheticAlgorithms))[:min(cfg.Algorithms, len(syntheticAlgorithms))] {
+				if err = insert(exportComponentCrypto.name, hash, syntheticAlgorithms[a].Algorithm, syntheticAlgorithms[a].Strength); err != nil {
+					return nil, err
+				}
+			}
+			for _, l := range rng.Perm(syntheticLibraries)[:min(rng.IntN(cfg.Hints+1), syntheticLibraries)] {
+				if err = insert(exportComponentCryptoLibrary.name, hash, fmt.Sprintf("library/synthetic-%02d", l)); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	m.s.Debugf("Generated %d synthetic components with %d versions each.", cfg.Purls, cfg.Versions)
+	return purls, nil
+}
+
+// SyntheticVersion returns the semver name of the nth synthetic version (1.0.0, 1.0.1, ...).
+func SyntheticVersion(n int) string {
+	return fmt.Sprintf("%d.%d.%d", n/100+1, (n/10)%10, n%10)
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+)
+
+func TestGenerateSyntheticKB(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	cfg := SyntheticKBConfig{Purls: 20, Versions: 12, Algorithms: 3, Hints: 2, Seed: 42}
+	hints := make([]int64, 2)
+	for i := range hints {
+		db := sqliteSetup(t) // Setup SQL Lite DB
+		db.SetMaxOpenConns(1)
+		defer CloseDB(db)
+		purls, counts, err := NewSyntheticKBModel(ctx, s, db).Generate(cfg)
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		if len(purls) != cfg.Purls || purls[1].Type != "npm" {
+			t.Errorf("Generate() purls = %v", purls)
+		}
+		rows := make(map[string]int64, len(counts))
+		for _, c := range counts {
+			rows[c.Table] = c.Rows
+		}
+		if rows["mines"] != 4 || rows["versions"] != 12 || rows["all_urls"] != 240 || rows["component_crypto"] != 720 ||
+			rows["crypto_libraries"] != syntheticLibraries || rows["component_crypto_library"] > 480 {
+			t.Errorf("Generate() row counts = %v", rows)
+		}
+		hints[i] = rows["component_crypto_library"]
+
+		conn := sqliteConn(t, ctx, db)
+		urls, err := NewAllURLModel(ctx, s, database.NewDBSelectContext(s, nil, conn, false)).GetUrlsByPurlNames(purls[:1])
+		if err != nil || len(urls[purls[0]]) != 12 || urls[purls[0]][0].SemVer == "" {
+			t.Errorf("GetUrlsByPurlNames() on the synthetic KB = %v, %v", urls, err)
+		}
+		CloseConn(conn)
+	}
+	if hints[0] != hints[1] {
+		t.Errorf("Generate() is not reproducible with the same seed: %v", hints)
+	}
+	db := sqliteSetup(t)
+	defer CloseDB(db)
+	if _, _, err = NewSyntheticKBModel(ctx, s, db).Generate(SyntheticKBConfig{Purls: 0, Versions: 1}); err == nil {
+		t.Errorf("Generate() expected an error for an empty KB")
+	}
+}
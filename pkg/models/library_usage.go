@@ -20,9 +20,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
@@ -33,13 +33,20 @@ type ECUsageModel struct {
 }
 
 type ECUsage struct {
-	URLHash     string `db:"url_hash"`
-	ID          string `db:"id"`
-	Name        string `db:"name"`
-	Description string `db:"description"`
-	URL         string `db:"url"`
-	Purl        string `db:"purl"`
-	Category    string `db:"category"`
+	URLHash     string   `db:"url_hash"`
+	ID          string   `db:"id"`
+	Name        string   `db:"name"`
+	Description string   `db:"description"`
+	URL         string   `db:"url"`
+	Purl        string   `db:"purl"`
+	Category    string   `db:"category"`
+	Tags        []string `db:"-"` // Filled in from crypto_library_tags, if requested
+}
+
+// ECTag is a tag of a crypto library (i.e. fips, tls or pqc).
+type ECTag struct {
+	ID  string `db:"library_id"`
+	Tag string `db:"tag"`
 }
 
 type ECUsageOnVersion struct {
@@ -62,32 +69,82 @@ func NewECUsageModel(ctx context.Context, s *zap.SugaredLogger, q *database.DBQu
 	return &ECUsageModel{ctx: ctx, s: s, q: q}
 }
 
+// GetLibraryUsageByURLHashes returns the crypto libraries detected in each of the given URL hashes.
 func (m *ECUsageModel) GetLibraryUsageByURLHashes(urlHashes []string) ([]ECUsage, error) {
 	if len(urlHashes) == 0 {
 		m.s.Errorf("Please specify a valid Purl list to query")
 		return []ECUsage{}, errors.New("please specify a valid Purl list to query")
 	}
-	var purlNames []string
-	for p := range urlHashes {
-		if urlHashes[p] != "" {
-			purlNames = append(purlNames, "'"+urlHashes[p]+"'")
-		}
-	}
-	inStmt := strings.Join(purlNames, ",")
-	inStmt = "(" + inStmt + ")"
-
-	if inStmt == "()" {
+	hashes := uniqueValues(urlHashes, true)
+	if len(hashes) == 0 {
 		m.s.Errorf("No hashes to query")
 		return []ECUsage{}, errors.New("no hashes to query")
 	}
-	stmt := "SELECT url_hash AS url_hash, det_id as id ,name,description, url, category, purl " +
-		"FROM crypto_libraries ec, component_crypto_library cc " +
-		"WHERE url_hash in " + inStmt + " and cc.det_id=ec.id;"
-	var usages []ECUsage
-	err := m.q.SelectContext(m.ctx, &usages, stmt)
+	var cache *lruCache[string, []ECUsage]
+	if c := kbCacheInstance.Load(); c != nil {
+		cache = c.libraries
+	}
+	usagesByHash, err := cachedLookup(cache, hashes, m.queryLibraryUsageByURLHashes)
 	if err != nil {
-		m.s.Errorf("Failed to query cryptoUsage:  %v", err)
-		return []ECUsage{}, fmt.Errorf("failed to query the all urls table: %v", err)
+		return []ECUsage{}, err
+	}
+	var usages []ECUsage
+	for _, h := range hashes {
+		usages = append(usages, usagesByHash[h]...)
+	}
+	return usages, nil
+}
+
+// queryLibraryUsageByURLHashes runs the usage query of GetLibraryUsageByURLHashes, grouping the results by URL hash.
+func (m *ECUsageModel) queryLibraryUsageByURLHashes(hashes []string) (map[string][]ECUsage, error) {
+	ctx, query := startQuery(m.ctx, "ECUsageModel.GetLibraryUsageByURLHashes", attribute.Int("hashes", len(hashes)))
+	rows := 0
+	usages := make(map[string][]ECUsage, len(hashes))
+	args := queryArgs(hashes)
+	for start := 0; start < len(args); start += maxQueryParams {
+		chunk := args[start:min(start+maxQueryParams, len(args))]
+		var chunkUsages []ECUsage
+		err := m.q.SelectContext(ctx, &chunkUsages,
+			"SELECT url_hash AS url_hash, det_id as id ,name,description, url, category, purl "+
+				"FROM crypto_libraries ec, component_crypto_library cc "+
+				"WHERE url_hash in ("+bindParams(len(chunk))+") and cc.det_id=ec.id;",
+			chunk...)
+		if err != nil {
+			query.end(rows, err)
+			m.s.Errorf("Failed to query cryptoUsage:  %v", err)
+			return nil, fmt.Errorf("failed to query the all urls table: %v", err)
+		}
+		rows += len(chunkUsages)
+		for _, u := range chunkUsages {
+			usages[u.URLHash] = append(usages[u.URLHash], u)
+		}
 	}
+	query.end(rows, nil)
 	return usages, nil
 }
+
+// GetLibraryTags returns the tags of each of the given crypto library IDs, sorted by name.
+func (m *ECUsageModel) GetLibraryTags(ids []string) (map[string][]string, error) {
+	tags := make(map[string][]string, len(ids))
+	args := queryArgs(uniqueValues(ids, true))
+	ctx, query := startQuery(m.ctx, "ECUsageModel.GetLibraryTags", attribute.Int("ids", len(args)))
+	rows := 0
+	for start := 0; start < len(args); start += maxQueryParams {
+		chunk := args[start:min(start+maxQueryParams, len(args))]
+		var chunkTags []ECTag
+		err := m.q.SelectContext(ctx, &chunkTags,
+			"SELECT library_id, tag FROM crypto_library_tags WHERE library_id IN ("+bindParams(len(chunk))+") ORDER BY library_id, tag",
+			chunk...)
+		if err != nil {
+			query.end(rows, err)
+			m.s.Errorf("Failed to query crypto library tags: %v", err)
+			return nil, fmt.Errorf("failed to query the crypto library tags table: %v", err)
+		}
+		rows += len(chunkTags)
+		for _, t := range chunkTags {
+			tags[t.ID] = append(tags[t.ID], t.Tag)
+		}
+	}
+	query.end(rows, nil)
+	return tags, nil
+}
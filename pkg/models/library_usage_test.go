@@ -65,4 +65,8 @@ func TestECSearchUsage(t *testing.T) {
 	if err == nil {
 		t.Errorf(" Expected to get an error on full list of empty urls")
 	}
+	tags, err := cum.GetLibraryTags([]string{"library/openssl", "sdk/awskms", "protocol/ssh"})
+	if err != nil || len(tags) != 2 || len(tags["library/openssl"]) != 2 || tags["library/openssl"][0] != "fips" {
+		t.Errorf("crypto_library_tags.GetLibraryTags() = %v, %v", tags, err)
+	}
 }
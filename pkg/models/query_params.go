@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// maxQueryParams limits the number of bound parameters used in a single IN clause.
+// Larger lists are split into several queries.
+const maxQueryParams = 1000
+
+// bindParams returns a list of n positional placeholders ($1, $2, ...) for use in an IN clause.
+func bindParams(n int) string {
+	var sb strings.Builder
+	for i := 1; i <= n; i++ {
+		if i > 1 {
+			sb.WriteString(",")
+		}
+		sb.WriteString("$")
+		sb.WriteString(strconv.Itoa(i))
+	}
+	return sb.String()
+}
+
+// uniqueValues returns the distinct values of a list, keeping their order.
+// Empty values are dropped if skipEmpty is set.
+func uniqueValues(values []string, skipEmpty bool) []string {
+	seen := make(map[string]bool, len(values))
+	var unique []string
+	for _, v := range values {
+		if (v != "" || !skipEmpty) && !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
+
+// queryArgs converts a list of values into query arguments.
+func queryArgs(values []string) []any {
+	args := make([]any, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return args
+}
@@ -28,3 +28,12 @@ type QuerySummary struct {
 	PurlsWOSemver      []PurlWOSemver
 	TotalPurls         int
 }
+
+// Merge appends the results of another query summary into this one.
+func (q *QuerySummary) Merge(other QuerySummary) {
+	q.PurlsFailedToParse = append(q.PurlsFailedToParse, other.PurlsFailedToParse...)
+	q.PurlsWOInfo = append(q.PurlsWOInfo, other.PurlsWOInfo...)
+	q.PurlsNotFound = append(q.PurlsNotFound, other.PurlsNotFound...)
+	q.PurlsWOSemver = append(q.PurlsWOSemver, other.PurlsWOSemver...)
+	q.TotalPurls += other.TotalPurls
+}
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQuerySummary_Merge(t *testing.T) {
+	summary := QuerySummary{TotalPurls: 2, PurlsNotFound: []string{"pkg:github/scanoss/engines"}}
+	summary.Merge(QuerySummary{
+		TotalPurls:         3,
+		PurlsFailedToParse: []string{"pkg:githubscanossengine"},
+		PurlsNotFound:      []string{"pkg:github/scanoss/missing"},
+		PurlsWOInfo:        []string{"pkg:github/scanoss/dependencies"},
+		PurlsWOSemver:      []PurlWOSemver{{Purl: "pkg:github/scanoss/engine", Versions: []string{"latest"}}},
+	})
+	want := QuerySummary{
+		TotalPurls:         5,
+		PurlsFailedToParse: []string{"pkg:githubscanossengine"},
+		PurlsNotFound:      []string{"pkg:github/scanoss/engines", "pkg:github/scanoss/missing"},
+		PurlsWOInfo:        []string{"pkg:github/scanoss/dependencies"},
+		PurlsWOSemver:      []PurlWOSemver{{Purl: "pkg:github/scanoss/engine", Versions: []string{"latest"}}},
+	}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("QuerySummary.Merge() = %+v, want %+v", summary, want)
+	}
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// kbQuery times a KB query and traces it as a span of the request.
+type kbQuery struct {
+	ctx   context.Context
+	name  string
+	start time.Time
+	span  trace.Span
+}
+
+// startQuery starts timing and tracing the KB query run by the named model method.
+// The returned context carries the query span, and is the one to run the query with.
+func startQuery(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, *kbQuery) {
+	ctx, span := otel.Tracer("scanoss.com/cryptography").Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	return ctx, &kbQuery{ctx: ctx, name: name, start: time.Now(), span: span}
+}
+
+// end records the rows returned (or the error) on the query span, and the query time in the metrics.
+func (q *kbQuery) end(rows int, err error) {
+	if err != nil {
+		q.span.RecordError(err)
+		q.span.SetStatus(codes.Error, "kb query failed")
+	} else {
+		q.span.SetAttributes(attribute.Int("rows", rows))
+	}
+	q.span.End()
+	recordQueryTime(q.ctx, q.name, q.start)
+}
+
+// queryTimer holds the OTEL metrics recorder of the KB query times.
+type queryTimer struct {
+	once      sync.Once
+	queryTime metric.Float64Histogram // milliseconds
+}
+
+var queryMetrics = queryTimer{}
+
+// recordQueryTime records the time taken by a KB query, broken down by the model method running it.
+func recordQueryTime(ctx context.Context, query string, start time.Time) {
+	queryMetrics.once.Do(func() {
+		meter := otel.Meter("scanoss.com/cryptography")
+		queryMetrics.queryTime, _ = meter.Float64Histogram("kb.query.time",
+			metric.WithDescription("The time taken to run a KB query (ms)"), metric.WithUnit("ms"))
+	})
+	if queryMetrics.queryTime != nil {
+		elapsed := float64(time.Since(start).Microseconds()) / 1000
+		queryMetrics.queryTime.Record(ctx, elapsed, metric.WithAttributes(attribute.String("query", query)))
+	}
+}
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS api_keys (
+    id TEXT PRIMARY KEY,
+    key_hash TEXT NOT NULL UNIQUE,
+    scopes TEXT NOT NULL
+);
+INSERT INTO api_keys VALUES('team-full','5e4ae44febefe4a51f4c5a5d41ff6b8dde525755c876667ea343acd6d13dbe73','*');
+INSERT INTO api_keys VALUES('team-hints','228ffb1849cb86665bb8d7a785a50913c1ba6ea74b5a6a57dc705d38c8c0f6c3','Echo, *Hints*');
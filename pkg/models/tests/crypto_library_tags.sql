@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS crypto_library_tags (
+    library_id TEXT NOT NULL,
+    tag TEXT NOT NULL,
+    PRIMARY KEY (library_id, tag)
+);
+INSERT INTO crypto_library_tags VALUES('library/openssl','fips');
+INSERT INTO crypto_library_tags VALUES('library/openssl','tls');
+INSERT INTO crypto_library_tags VALUES('library/boringssl','fips');
+INSERT INTO crypto_library_tags VALUES('library/boringssl','tls');
+INSERT INTO crypto_library_tags VALUES('library/wolfssl','fips');
+INSERT INTO crypto_library_tags VALUES('library/wolfssl','tls');
+INSERT INTO crypto_library_tags VALUES('protocol/tls','tls');
+INSERT INTO crypto_library_tags VALUES('protocol/dtls','tls');
+INSERT INTO crypto_library_tags VALUES('protocol/ssl','tls');
+INSERT INTO crypto_library_tags VALUES('protocol/https','tls');
+INSERT INTO crypto_library_tags VALUES('sdk/awskms','kms');
+INSERT INTO crypto_library_tags VALUES('sdk/microsoftcng','fips');
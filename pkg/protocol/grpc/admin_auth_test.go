@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"scanoss.com/cryptography/pkg/api/adminv2"
+)
+
+func TestAdminAuthUnaryInterceptor(t *testing.T) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	adminInfo := &grpc.UnaryServerInfo{FullMethod: adminv2.GetConfigFullMethodName}
+	tests := []struct {
+		name     string
+		token    string
+		auth     string
+		info     *grpc.UnaryServerInfo
+		wantCode codes.Code
+	}{
+		{name: "valid token", token: "secret", auth: "Bearer secret", info: adminInfo, wantCode: codes.OK},
+		{name: "invalid token", token: "secret", auth: "Bearer wrong", info: adminInfo, wantCode: codes.Unauthenticated},
+		{name: "missing token", token: "secret", info: adminInfo, wantCode: codes.Unauthenticated},
+		{name: "no token configured", token: "", auth: "Bearer ", info: adminInfo, wantCode: codes.Unauthenticated},
+		{name: "other service", token: "secret", info: &grpc.UnaryServerInfo{FullMethod: "/scanoss.api.cryptography.v2.Cryptography/Echo"}, wantCode: codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if len(tt.auth) > 0 {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.auth))
+			}
+			_, err := adminAuthUnaryInterceptor(tt.token)(ctx, nil, tt.info, handler)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("adminAuthUnaryInterceptor() error = %v, want code %v", err, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	grpcmiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"scanoss.com/cryptography/pkg/api/adminv2"
+	"scanoss.com/cryptography/pkg/auth"
+)
+
+// controlMethod reports whether a method belongs to the admin service (which has its own token) or the gRPC
+// infrastructure services (i.e. reflection), which are exempt from API keys and request limits.
+func controlMethod(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/"+adminv2.ServiceName+"/") || strings.HasPrefix(fullMethod, "/grpc.")
+}
+
+// apiKeyFromMetadata returns the API key of a call, from its x-api-key metadata or its "authorization: Bearer" header.
+// Over REST, the gateway forwards the HTTP Authorization header as grpcgateway-authorization, which is also read.
+func apiKeyFromMetadata(md metadata.MD) string {
+	if keys := md.Get(auth.APIKeyMetadataKey); len(keys) > 0 {
+		return keys[0]
+	}
+	for _, header := range []string{"authorization", "grpcgateway-authorization"} {
+		for _, value := range md.Get(header) {
+			if key, found := strings.CutPrefix(value, "Bearer "); found {
+				return key
+			}
+		}
+	}
+	return ""
+}
+
+// authorizeAPIKey authenticates the API key of a call and checks its scopes allow the method called.
+// The key identity is added to the request logs and returned in the call context.
+func authorizeAPIKey(ctx context.Context, keys *auth.KeyStore, fullMethod string) (context.Context, error) {
+	if controlMethod(fullMethod) {
+		return ctx, nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	key, found := keys.Authenticate(apiKeyFromMetadata(md))
+	if !found {
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid api key")
+	}
+	ctxzap.AddFields(ctx, zap.String("api.key", key.ID))
+	if !auth.Allowed(key, fullMethod) {
+		ctxzap.Extract(ctx).Sugar().Warnf("API key %v is not allowed to call %v", key.ID, fullMethod)
+		return nil, status.Errorf(codes.PermissionDenied, "api key %v is not allowed to call %v", key.ID, fullMethod)
+	}
+	return auth.NewContext(ctx, key.ID), nil
+}
+
+// apiKeyUnaryInterceptor rejects unary calls without a valid API key allowed to call the method.
+func apiKeyUnaryInterceptor(keys *auth.KeyStore) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authorizeAPIKey(ctx, keys, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// apiKeyStreamInterceptor rejects streaming calls without a valid API key allowed to call the method.
+func apiKeyStreamInterceptor(keys *auth.KeyStore) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorizeAPIKey(ss.Context(), keys, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		wrapped := grpcmiddleware.WrapServerStream(ss)
+		wrapped.WrappedContext = ctx
+		return handler(srv, wrapped)
+	}
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"scanoss.com/cryptography/pkg/api/adminv2"
+	"scanoss.com/cryptography/pkg/auth"
+	myconfig "scanoss.com/cryptography/pkg/config"
+)
+
+func TestAPIKeyUnaryInterceptor(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	cfg, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	cfg.Auth.KeysFile = filepath.Join(t.TempDir(), "api_keys.json")
+	keys := `{"keys": [{"id": "team-hints", "hash": "` + auth.HashKey("secret") + `", "scopes": ["*Hints*"]}]}`
+	if err = os.WriteFile(cfg.Auth.KeysFile, []byte(keys), 0o600); err != nil {
+		t.Fatalf("failed to write the keys file: %v", err)
+	}
+	store, err := auth.NewKeyStore(cfg, nil)
+	if err != nil {
+		t.Fatalf("failed to load the api keys: %v", err)
+	}
+	var keyID string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		keyID = auth.KeyIDFromContext(ctx)
+		return "ok", nil
+	}
+	hints := "/scanoss.api.cryptography.v2.Cryptography/GetComponentsEncryptionHints"
+	tests := []struct {
+		name      string
+		md        metadata.MD
+		method    string
+		wantCode  codes.Code
+		wantKeyID string
+	}{
+		{name: "api key", md: metadata.Pairs("x-api-key", "secret"), method: hints, wantCode: codes.OK, wantKeyID: "team-hints"},
+		{name: "bearer", md: metadata.Pairs("authorization", "Bearer secret"), method: hints, wantCode: codes.OK, wantKeyID: "team-hints"},
+		{name: "gateway bearer", md: metadata.Pairs("grpcgateway-authorization", "Bearer secret"), method: hints, wantCode: codes.OK,
+			wantKeyID: "team-hints"},
+		{name: "invalid key", md: metadata.Pairs("x-api-key", "wrong"), method: hints, wantCode: codes.Unauthenticated},
+		{name: "missing key", method: hints, wantCode: codes.Unauthenticated},
+		{name: "out of scope", md: metadata.Pairs("x-api-key", "secret"), method: "/scanoss.api.cryptography.v2.Cryptography/Echo",
+			wantCode: codes.PermissionDenied},
+		{name: "admin service", method: adminv2.GetConfigFullMethodName, wantCode: codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyID = ""
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			_, err := apiKeyUnaryInterceptor(store)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if status.Code(err) != tt.wantCode || keyID != tt.wantKeyID {
+				t.Errorf("apiKeyUnaryInterceptor() error = %v, key %q, want code %v, key %q", err, keyID, tt.wantCode, tt.wantKeyID)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/scanoss/go-grpc-helper/pkg/files"
+	"github.com/scanoss/ipfilter/v2"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc"
+	myconfig "scanoss.com/cryptography/pkg/config"
+)
+
+// FilterSettings holds the Filtering config the IP filter is loaded from.
+type FilterSettings struct {
+	AllowListFile  string
+	DenyListFile   string
+	BlockByDefault bool
+	TrustProxy     bool
+}
+
+// ipFilterState pairs the active filter with the settings it was loaded from, so both are swapped together.
+type ipFilterState struct {
+	settings FilterSettings
+	filter   *ipfilter.IPFilter // No filtering while nil
+}
+
+// IPFilters holds the allow/deny IP filter applied to incoming gRPC connections and REST requests.
+// The lists can be reloaded from the configured files while the server is running.
+type IPFilters struct {
+	mu      sync.Mutex // Serializes the reloads
+	current atomic.Pointer[ipFilterState]
+}
+
+// NewIPFilters creates the IP filters for the given allowed/denied lists, loaded using the Filtering config.
+func NewIPFilters(config *myconfig.ServerConfig, allowedIPs, deniedIPs []string) *IPFilters {
+	f := &IPFilters{}
+	f.set(FilterSettings{AllowListFile: config.Filtering.AllowListFile, DenyListFile: config.Filtering.DenyListFile,
+		BlockByDefault: config.Filtering.BlockByDefault, TrustProxy: config.Filtering.TrustProxy}, allowedIPs, deniedIPs)
+	return f
+}
+
+// set replaces the active filter with one for the given settings and lists.
+func (f *IPFilters) set(settings FilterSettings, allowedIPs, deniedIPs []string) {
+	state := &ipFilterState{settings: settings}
+	if len(allowedIPs) > 0 || len(deniedIPs) > 0 {
+		state.filter = ipfilter.New(ipfilter.Options{AllowedIPs: allowedIPs, BlockedIPs: deniedIPs,
+			BlockByDefault: settings.BlockByDefault, TrustProxy: settings.TrustProxy,
+		})
+	}
+	f.current.Store(state)
+}
+
+// Settings returns the settings the active filter was loaded from.
+func (f *IPFilters) Settings() FilterSettings {
+	return f.current.Load().settings
+}
+
+// ReloadFilters re-reads the allow/deny list files and replaces the active filter.
+// It returns the number of allowed and denied entries loaded. The current filter is kept if the files cannot be read.
+func (f *IPFilters) ReloadFilters() (int, int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.reload(f.Settings())
+}
+
+// ReloadFiltersWith loads the allow/deny lists using the given settings, replacing the active filter (and settings).
+// The current filter is kept if the files cannot be read.
+func (f *IPFilters) ReloadFiltersWith(settings FilterSettings) (int, int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.reload(settings)
+}
+
+// reload loads the allow/deny lists using the given settings and replaces the active filter.
+func (f *IPFilters) reload(settings FilterSettings) (int, int, error) {
+	allowedIPs, deniedIPs, err := files.LoadFiltering(settings.AllowListFile, settings.DenyListFile)
+	if err != nil {
+		zlog.S.Errorf("Failed to reload the IP filtering lists: %v", err)
+		return 0, 0, err
+	}
+	f.set(settings, allowedIPs, deniedIPs)
+	zlog.S.Infof("Reloaded IP filtering lists: %d allowed, %d denied", len(allowedIPs), len(deniedIPs))
+	return len(allowedIPs), len(deniedIPs), nil
+}
+
+// UnaryServerInterceptor filters unary calls using the active filter.
+func (f *IPFilters) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if filter := f.current.Load().filter; filter != nil {
+			return filter.IPFilterUnaryServerInterceptor()(ctx, req, info, handler)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor filters streaming calls using the active filter.
+func (f *IPFilters) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if filter := f.current.Load().filter; filter != nil {
+			return filter.IPFilterStreamServerInterceptor()(srv, ss, info, handler)
+		}
+		return handler(srv, ss)
+	}
+}
+
+// Wrap filters the HTTP requests served by the given handler using the active filter.
+func (f *IPFilters) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if filter := f.current.Load().filter; filter != nil {
+			filter.Wrap(next).ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
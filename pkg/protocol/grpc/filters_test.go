@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	myconfig "scanoss.com/cryptography/pkg/config"
+)
+
+func TestIPFiltersReload(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	filters := NewIPFilters(myConfig, nil, nil)
+	if filters.current.Load().filter != nil {
+		t.Errorf("NewIPFilters() expected no filter without lists")
+	}
+	denyList := filepath.Join(t.TempDir(), "deny_list.txt")
+	if err = os.WriteFile(denyList, []byte("10.0.0.1\n10.0.0.2\n"), 0o600); err != nil {
+		t.Fatalf("failed to write deny list: %v", err)
+	}
+	settings := filters.Settings()
+	settings.DenyListFile = denyList
+	allowed, denied, err := filters.ReloadFiltersWith(settings)
+	if err != nil || allowed != 0 || denied != 2 {
+		t.Errorf("ReloadFiltersWith() = %v, %v, %v", allowed, denied, err)
+	}
+	filter := filters.current.Load().filter
+	if filter == nil || filter.Allowed("10.0.0.1") || !filter.Allowed("10.0.0.3") || filters.Settings() != settings {
+		t.Errorf("ReloadFiltersWith() expected the deny list to be applied")
+	}
+	// The REST gateway is filtered by the same (reloaded) lists
+	handler := filters.Wrap(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }))
+	for ip, want := range map[string]int{"10.0.0.1": http.StatusForbidden, "10.0.0.3": http.StatusOK} {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.RemoteAddr = ip + ":1234"
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		if recorder.Code != want {
+			t.Errorf("Wrap() status for %v = %v, want %v", ip, recorder.Code, want)
+		}
+	}
+	// Reloading re-reads the files of the active settings
+	if err = os.WriteFile(denyList, []byte("10.0.0.3\n"), 0o600); err != nil {
+		t.Fatalf("failed to write deny list: %v", err)
+	}
+	if allowed, denied, err = filters.ReloadFilters(); err != nil || allowed != 0 || denied != 1 {
+		t.Errorf("ReloadFilters() = %v, %v, %v", allowed, denied, err)
+	}
+	filter = filters.current.Load().filter
+	if filter == nil || !filter.Allowed("10.0.0.1") || filter.Allowed("10.0.0.3") {
+		t.Errorf("ReloadFilters() expected the updated deny list to be applied")
+	}
+	settings.DenyListFile = filepath.Join(t.TempDir(), "missing.txt")
+	if _, _, err = filters.ReloadFiltersWith(settings); err == nil {
+		t.Errorf("ReloadFiltersWith() expected an error for a missing file")
+	}
+	if filters.current.Load().filter != filter || filters.Settings().DenyListFile != denyList {
+		t.Errorf("ReloadFiltersWith() expected the current filter to be kept after an error")
+	}
+}
@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"math"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	common "github.com/scanoss/papi/api/commonv2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"scanoss.com/cryptography/pkg/auth"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/protocol/rest"
+)
+
+// maxIdleBuckets is the number of client buckets kept before the full (idle) ones are dropped.
+const maxIdleBuckets = 10000
+
+// tokenBucket holds the requests a client can still make, refilled over time.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter is a token bucket rate limiter keyed by client.
+type rateLimiter struct {
+	rate    float64 // Tokens added per second
+	burst   float64 // Maximum tokens held
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	now     func() time.Time
+}
+
+// newRateLimiter creates a rate limiter allowing rate requests per second to each client, with the given burst.
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst <= 0 {
+		burst = max(int(math.Ceil(rate)), 1)
+	}
+	return &rateLimiter{rate: rate, burst: float64(burst), buckets: make(map[string]*tokenBucket), now: time.Now}
+}
+
+// allow takes a token from the bucket of the client. If there is none left,
+// it returns false and the time until the next token is available.
+func (l *rateLimiter) allow(client string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	bucket, found := l.buckets[client]
+	if !found {
+		if len(l.buckets) >= maxIdleBuckets {
+			l.prune(now)
+		}
+		bucket = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[client] = bucket
+	}
+	bucket.tokens = min(l.burst, bucket.tokens+now.Sub(bucket.last).Seconds()*l.rate)
+	bucket.last = now
+	if bucket.tokens < 1 {
+		return false, time.Duration((1 - bucket.tokens) / l.rate * float64(time.Second))
+	}
+	bucket.tokens--
+	return true, 0
+}
+
+// prune drops the buckets that have refilled completely, as they are no different from new ones.
+func (l *rateLimiter) prune(now time.Time) {
+	for client, bucket := range l.buckets {
+		if bucket.tokens+now.Sub(bucket.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, client)
+		}
+	}
+}
+
+// requestLimits enforces the per client rate limit and the number of components allowed in each request.
+type requestLimits struct {
+	limiter       *rateLimiter // No rate limiting while nil
+	maxComponents int
+	trustProxy    bool // Identify REST clients by the address their proxy forwarded for
+}
+
+// newRequestLimits creates the request limits configured, or returns nil if there are none.
+func newRequestLimits(config *myconfig.ServerConfig) *requestLimits {
+	if config.Limits.RateLimit <= 0 && config.Limits.MaxComponents <= 0 {
+		return nil
+	}
+	limits := &requestLimits{maxComponents: config.Limits.MaxComponents, trustProxy: config.Filtering.TrustProxy}
+	if config.Limits.RateLimit > 0 {
+		limits.limiter = newRateLimiter(config.Limits.RateLimit, config.Limits.Burst)
+	}
+	return limits
+}
+
+// check enforces the limits on a request message, returning a ResourceExhausted error if any is exceeded.
+func (l *requestLimits) check(ctx context.Context, fullMethod string, req interface{}) error {
+	if controlMethod(fullMethod) {
+		return nil
+	}
+	if l.limiter != nil {
+		client := clientID(ctx, l.trustProxy)
+		if allowed, wait := l.limiter.allow(client); !allowed {
+			ctxzap.Extract(ctx).Sugar().Warnf("Rate limit exceeded for %v calling %v", client, fullMethod)
+			return status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry in %v", wait.Round(time.Millisecond))
+		}
+	}
+	if count := countComponents(req); l.maxComponents > 0 && count > l.maxComponents {
+		return status.Errorf(codes.ResourceExhausted, "too many components in request: %d (maximum %d)", count, l.maxComponents)
+	}
+	return nil
+}
+
+// clientID identifies the caller of a request: by its API key if authenticated, or its IP address otherwise.
+// Calls forwarded by the REST gateway come from a loopback address, so the client address is taken from the
+// X-Forwarded-For metadata instead: the address the gateway appended, or the first one if the proxy is trusted.
+// The metadata is only read for calls marked by the gateway of this process, or if the proxy is trusted,
+// as any local client could send it.
+func clientID(ctx context.Context, trustProxy bool) string {
+	if keyID := auth.KeyIDFromContext(ctx); len(keyID) > 0 {
+		return "key:" + keyID
+	}
+	var host string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host = p.Addr.String()
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() && (trustProxy || rest.FromGateway(ctx)) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if forwarded := strings.Split(strings.Join(md.Get("x-forwarded-for"), ","), ","); len(forwarded[0]) > 0 {
+			host = strings.TrimSpace(forwarded[len(forwarded)-1])
+			if trustProxy {
+				host = strings.TrimSpace(forwarded[0])
+			}
+		}
+	}
+	return "ip:" + host
+}
+
+// countComponents returns the number of components (purls or package hashes) in a request message.
+func countComponents(req interface{}) int {
+	switch r := req.(type) {
+	case interface {
+		GetComponents() []*common.ComponentRequest
+	}:
+		return len(r.GetComponents())
+	case interface {
+		GetPurls() []*common.PurlRequest_Purls
+	}:
+		return len(r.GetPurls())
+	case interface {
+		GetHashes() []string
+	}:
+		return len(r.GetHashes())
+	default:
+		return 1
+	}
+}
+
+// UnaryServerInterceptor enforces the request limits on unary calls.
+func (l *requestLimits) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := l.check(ctx, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor enforces the request limits on each message received by streaming calls.
+func (l *requestLimits) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &limitedServerStream{ServerStream: ss, limits: l, method: info.FullMethod})
+	}
+}
+
+// limitedServerStream checks the request limits on every message received.
+type limitedServerStream struct {
+	grpc.ServerStream
+	limits *requestLimits
+	method string
+}
+
+func (s *limitedServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.limits.check(s.Context(), s.method, m)
+}
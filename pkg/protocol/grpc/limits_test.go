@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"scanoss.com/cryptography/pkg/api/adminv2"
+	"scanoss.com/cryptography/pkg/api/packagesv2"
+	"scanoss.com/cryptography/pkg/auth"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/protocol/rest"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Unix(1000, 0)
+	limiter := newRateLimiter(2, 3)
+	limiter.now = func() time.Time { return now }
+	for i := 0; i < 3; i++ {
+		if allowed, _ := limiter.allow("a"); !allowed {
+			t.Fatalf("allow() request %d within the burst was rejected", i)
+		}
+	}
+	if allowed, wait := limiter.allow("a"); allowed || wait != 500*time.Millisecond {
+		t.Errorf("allow() = %v, %v, want a rejection for 500ms", allowed, wait)
+	}
+	if allowed, _ := limiter.allow("b"); !allowed {
+		t.Errorf("allow() rejected a different client")
+	}
+	now = now.Add(500 * time.Millisecond)
+	if allowed, _ := limiter.allow("a"); !allowed {
+		t.Errorf("allow() rejected a request after the bucket refilled")
+	}
+	now = now.Add(time.Hour)
+	limiter.prune(now)
+	if len(limiter.buckets) != 0 {
+		t.Errorf("prune() kept %d full buckets", len(limiter.buckets))
+	}
+	if limiter = newRateLimiter(0.5, 0); limiter.burst != 1 {
+		t.Errorf("newRateLimiter() burst = %v, want 1", limiter.burst)
+	}
+}
+
+func TestClientID(t *testing.T) {
+	withPeer := func(addr string, md metadata.MD) context.Context {
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		return peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(addr), Port: 1234}})
+	}
+	// gateway builds the metadata of a call forwarded by the REST gateway
+	gateway := func(kv ...string) metadata.MD {
+		md, _ := metadata.FromOutgoingContext(rest.MarkGatewayCall(metadata.AppendToOutgoingContext(context.Background(), kv...)))
+		return md
+	}
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		trustProxy bool
+		want       string
+	}{
+		{name: "remote", ctx: withPeer("10.0.0.1", metadata.Pairs("x-forwarded-for", "10.0.0.9")), want: "ip:10.0.0.1"},
+		{name: "gateway", ctx: withPeer("127.0.0.1", gateway("x-forwarded-for", "10.0.0.9")), want: "ip:10.0.0.9"},
+		{name: "gateway behind proxy", ctx: withPeer("127.0.0.1", gateway("x-forwarded-for", "10.0.0.5, 10.0.0.9")), want: "ip:10.0.0.9"},
+		{name: "local client", ctx: withPeer("127.0.0.1", metadata.Pairs("x-forwarded-for", "10.0.0.9")), want: "ip:127.0.0.1"},
+		{name: "forged marker", ctx: withPeer("127.0.0.1", metadata.Pairs("x-crypto-gateway", "guess", "x-forwarded-for", "10.0.0.9")),
+			want: "ip:127.0.0.1"},
+		{name: "trusted proxy", ctx: withPeer("127.0.0.1", metadata.Pairs("x-forwarded-for", "10.0.0.5, 10.0.0.9")), trustProxy: true,
+			want: "ip:10.0.0.5"},
+		{name: "local", ctx: withPeer("::1", nil), want: "ip:::1"},
+		{name: "api key", ctx: auth.NewContext(withPeer("10.0.0.1", nil), "team-a"), want: "key:team-a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := clientID(tt.ctx, tt.trustProxy); got != tt.want {
+				t.Errorf("clientID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestLimitsUnaryInterceptor(t *testing.T) {
+	cfg, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	if newRequestLimits(cfg) != nil {
+		t.Errorf("newRequestLimits() expected no limits by default")
+	}
+	cfg.Limits.RateLimit, cfg.Limits.Burst, cfg.Limits.MaxComponents = 1, 2, 2
+	interceptor := newRequestLimits(cfg).UnaryServerInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	info := &grpc.UnaryServerInfo{FullMethod: "/scanoss.api.cryptography.v2.Cryptography/GetComponentsAlgorithms"}
+	components := func(n int) *common.ComponentsRequest {
+		return &common.ComponentsRequest{Components: make([]*common.ComponentRequest, n)}
+	}
+	hashes := &packagesv2.PackagesRequest{Hashes: []string{"a", "b", "c"}}
+	tests := []struct {
+		name     string
+		client   string
+		req      interface{}
+		info     *grpc.UnaryServerInfo
+		wantCode codes.Code
+	}{
+		{name: "within limits", client: "a", req: components(2), info: info, wantCode: codes.OK},
+		{name: "too many components", client: "b", req: components(3), info: info, wantCode: codes.ResourceExhausted},
+		{name: "too many purls", client: "c", req: &common.PurlRequest{Purls: make([]*common.PurlRequest_Purls, 3)}, info: info,
+			wantCode: codes.ResourceExhausted},
+		{name: "too many hashes", client: "d", req: hashes, info: info, wantCode: codes.ResourceExhausted},
+		{name: "burst", client: "a", req: components(1), info: info, wantCode: codes.OK},
+		{name: "rate limited", client: "a", req: components(1), info: info, wantCode: codes.ResourceExhausted},
+		{name: "admin service", client: "a", req: components(3), info: &grpc.UnaryServerInfo{FullMethod: adminv2.GetConfigFullMethodName},
+			wantCode: codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := auth.NewContext(context.Background(), tt.client)
+			if _, err := interceptor(ctx, tt.req, tt.info, handler); status.Code(err) != tt.wantCode {
+				t.Errorf("UnaryServerInterceptor() error = %v, want code %v", err, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"scanoss.com/cryptography/pkg/auth"
+)
+
+// rpcMetrics records the time taken to serve every RPC.
+type rpcMetrics struct {
+	reqTime metric.Int64Histogram // milliseconds
+}
+
+// newRPCMetrics creates the RPC metrics recorders.
+func newRPCMetrics() *rpcMetrics {
+	meter := otel.Meter("scanoss.com/cryptography")
+	reqTime, _ := meter.Int64Histogram("crypto.rpc.req_time",
+		metric.WithDescription("The time taken to serve an RPC (ms)"), metric.WithUnit("ms"))
+	return &rpcMetrics{reqTime: reqTime}
+}
+
+// record adds the time taken by an RPC, broken down by RPC name, gRPC code, response status and API key.
+func (m *rpcMetrics) record(ctx context.Context, fullMethod string, start time.Time, response any, err error) {
+	attrs := []attribute.KeyValue{
+		attribute.String("rpc", fullMethod[strings.LastIndex(fullMethod, ".")+1:]), // i.e. Cryptography/GetComponentsAlgorithms
+		attribute.String("grpc.code", status.Code(err).String()),
+	}
+	if responseStatus := statusOf(response); len(responseStatus) > 0 {
+		attrs = append(attrs, attribute.String("status", responseStatus))
+	}
+	if keyID := auth.KeyIDFromContext(ctx); len(keyID) > 0 {
+		attrs = append(attrs, attribute.String("api.key", keyID))
+	}
+	m.reqTime.Record(ctx, time.Since(start).Milliseconds(), metric.WithAttributes(attrs...))
+}
+
+// statusOf returns the status (i.e. SUCCESS) reported in a response, if any.
+func statusOf(response any) string {
+	switch r := response.(type) {
+	case interface{ GetStatus() *common.StatusResponse }:
+		if r.GetStatus() != nil {
+			return r.GetStatus().GetStatus().String()
+		}
+	}
+	return ""
+}
+
+// UnaryServerInterceptor records the time taken by unary calls.
+func (m *rpcMetrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.record(ctx, info.FullMethod, start, resp, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor records the time taken by streaming calls, with the status of the last message sent.
+func (m *rpcMetrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		stream := &measuredServerStream{ServerStream: ss}
+		err := handler(srv, stream)
+		m.record(ss.Context(), info.FullMethod, start, stream.last, err)
+		return err
+	}
+}
+
+// measuredServerStream keeps the last message sent, which carries the status of the whole stream.
+type measuredServerStream struct {
+	grpc.ServerStream
+	last interface{}
+}
+
+func (s *measuredServerStream) SendMsg(m interface{}) error {
+	s.last = m
+	return s.ServerStream.SendMsg(m)
+}
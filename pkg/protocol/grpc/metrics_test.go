@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	common "github.com/scanoss/papi/api/commonv2"
+	pb "github.com/scanoss/papi/api/cryptographyv2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"scanoss.com/cryptography/pkg/auth"
+)
+
+// collectHistogram returns the data points recorded by the named histogram.
+func collectHistogram(t *testing.T, reader *sdkmetric.ManualReader, name string) []metricdata.HistogramDataPoint[int64] {
+	t.Helper()
+	var data metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &data); err != nil {
+		t.Fatalf("failed to collect the metrics: %v", err)
+	}
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if histogram, ok := m.Data.(metricdata.Histogram[int64]); ok && m.Name == name {
+				return histogram.DataPoints
+			}
+		}
+	}
+	return nil
+}
+
+func TestRPCMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	previous := otel.GetMeterProvider()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	defer otel.SetMeterProvider(previous)
+	interceptor := newRPCMetrics().UnaryServerInterceptor()
+
+	ctx := auth.NewContext(context.Background(), "team-a")
+	info := &grpc.UnaryServerInfo{FullMethod: "/scanoss.api.cryptography.v2.Cryptography/GetComponentsAlgorithms"}
+	response := &pb.ComponentsAlgorithmsResponse{Status: &common.StatusResponse{Status: common.StatusCode_SUCCEEDED_WITH_WARNINGS}}
+	_, _ = interceptor(ctx, nil, info, func(context.Context, interface{}) (interface{}, error) { return response, nil })
+	_, _ = interceptor(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return nil, status.Error(codes.ResourceExhausted, "too many requests")
+	})
+
+	points := collectHistogram(t, reader, "crypto.rpc.req_time")
+	if len(points) != 2 {
+		t.Fatalf("recorded %d data points, want 2: %+v", len(points), points)
+	}
+	want := map[string]attribute.Set{
+		"OK": attribute.NewSet(attribute.String("rpc", "Cryptography/GetComponentsAlgorithms"), attribute.String("grpc.code", "OK"),
+			attribute.String("status", "SUCCEEDED_WITH_WARNINGS"), attribute.String("api.key", "team-a")),
+		"ResourceExhausted": attribute.NewSet(attribute.String("rpc", "Cryptography/GetComponentsAlgorithms"),
+			attribute.String("grpc.code", "ResourceExhausted")),
+	}
+	for _, point := range points {
+		code, _ := point.Attributes.Value("grpc.code")
+		wantSet := want[code.AsString()]
+		if !point.Attributes.Equals(&wantSet) || point.Count != 1 {
+			t.Errorf("data point %v (count %d), want %v", point.Attributes.Encoded(attribute.DefaultEncoder()), point.Count,
+				wantSet.Encoded(attribute.DefaultEncoder()))
+		}
+	}
+}
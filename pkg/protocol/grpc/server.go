@@ -19,37 +19,56 @@
 package grpc
 
 import (
-	"github.com/scanoss/go-grpc-helper/pkg/grpc/otel"
+	"errors"
+
 	gs "github.com/scanoss/go-grpc-helper/pkg/grpc/server"
 	myconfig "scanoss.com/cryptography/pkg/config"
 
 	pb "github.com/scanoss/papi/api/cryptographyv2"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"scanoss.com/cryptography/pkg/api/adminv2"
+	"scanoss.com/cryptography/pkg/api/packagesv2"
+	"scanoss.com/cryptography/pkg/api/streamv2"
+	"scanoss.com/cryptography/pkg/auth"
 )
 
 // RunServer runs gRPC service to publish.
-func RunServer(config *myconfig.ServerConfig, v2API pb.CryptographyServer, port string,
-	allowedIPs, deniedIPs []string, startTLS bool, version string) (*grpc.Server, error) {
-	// Start up Open Telemetry is requested
-	var oltpShutdown = func() {}
-	if config.Telemetry.Enabled {
-		var err error
-		oltpShutdown, err = otel.InitTelemetryProviders(config.App.Name, "scanoss-cryptography", version,
-			config.Telemetry.OltpExporter, otel.GetTraceSampler(config.App.Mode), false)
-		if err != nil {
-			return nil, err
-		}
+// The admin service is only registered if enabled in the config, and requires an admin token to be set.
+func RunServer(config *myconfig.ServerConfig, v2API pb.CryptographyServer, streamAPI streamv2.CryptographyStreamServer,
+	packagesAPI packagesv2.CryptographyPackagesServer, adminAPI adminv2.CryptographyAdminServer, healthAPI healthpb.HealthServer,
+	port string, filters *IPFilters, keys *auth.KeyStore, startTLS bool, version string) (*grpc.Server, error) {
+	if config.Admin.Enabled && len(config.Admin.Token) == 0 {
+		return nil, errors.New("the admin service requires an admin token to be configured")
+	}
+	if config.Auth.Enabled && keys == nil {
+		return nil, errors.New("api key authentication requires the api keys to be loaded")
+	}
+	// Start up Open Telemetry (OLTP and/or Prometheus) if requested
+	oltpShutdown, err := initTelemetryProviders(config, version)
+	if err != nil {
+		return nil, err
 	}
 	// Configure the port, interceptors, TLS and register the service
-	listen, server, err := gs.SetupGrpcServer(port, config.TLS.CertFile, config.TLS.KeyFile,
-		allowedIPs, deniedIPs, startTLS, config.Filtering.BlockByDefault, config.Filtering.TrustProxy,
-		config.Telemetry.Enabled, config.App.GRPCReflection)
+	listen, server, err := setupGrpcServer(config, port, filters, keys, startTLS)
 	if err != nil {
 		oltpShutdown()
 		return nil, err
 	}
 	// Register the service API and start the server in the background
 	pb.RegisterCryptographyServer(server, v2API)
+	if streamAPI != nil {
+		streamv2.RegisterCryptographyStreamServer(server, streamAPI)
+	}
+	if packagesAPI != nil {
+		packagesv2.RegisterCryptographyPackagesServer(server, packagesAPI)
+	}
+	if config.Admin.Enabled && adminAPI != nil {
+		adminv2.RegisterCryptographyAdminServer(server, adminAPI)
+	}
+	if healthAPI != nil {
+		healthpb.RegisterHealthServer(server, healthAPI)
+	}
 	go func() {
 		gs.StartGrpcServer(listen, server, startTLS)
 		oltpShutdown()
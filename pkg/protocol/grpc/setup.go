@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpc
+
+import (
+	"errors"
+	"net"
+
+	grpcmiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpczap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/utils"
+	"github.com/scanoss/zap-logging-helper/pkg/grpc/interceptor"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/reflection"
+	"scanoss.com/cryptography/pkg/auth"
+	myconfig "scanoss.com/cryptography/pkg/config"
+)
+
+// setupGrpcServer configures the port, filtering, logging interceptors, TLS & reflection for the gRPC Server.
+// It mirrors the go-grpc-helper setup, but also installs the interceptor chain for streaming RPCs.
+func setupGrpcServer(config *myconfig.ServerConfig, port string, filters *IPFilters, keys *auth.KeyStore, startTLS bool) (net.Listener, *grpc.Server, error) {
+	listen, err := net.Listen("tcp", utils.SetupPort(port))
+	if err != nil {
+		return nil, nil, err
+	}
+	var unaryInterceptors []grpc.UnaryServerInterceptor
+	var streamInterceptors []grpc.StreamServerInterceptor
+	// Configure the list of allowed/denied IPs to connect (reloadable at runtime)
+	if filters != nil {
+		unaryInterceptors = append(unaryInterceptors, filters.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, filters.StreamServerInterceptor())
+	}
+	unaryInterceptors = append(unaryInterceptors, grpczap.UnaryServerInterceptor(zlog.L))
+	streamInterceptors = append(streamInterceptors, grpczap.StreamServerInterceptor(zlog.L))
+	// Needs to be called after the logging interceptors to make sure the logger is set
+	unaryInterceptors = append(unaryInterceptors, interceptor.ContextPropagationUnaryServerInterceptor())
+	streamInterceptors = append(streamInterceptors, interceptor.ContextPropagationStreamServerInterceptor())
+	// Authenticate the callers by API key, once the request logger is set to record the key identity
+	if keys != nil {
+		unaryInterceptors = append(unaryInterceptors, apiKeyUnaryInterceptor(keys))
+		streamInterceptors = append(streamInterceptors, apiKeyStreamInterceptor(keys))
+	}
+	// Record the time taken by every RPC, including those rejected by the request limits
+	metricsEnabled := config.Telemetry.Enabled || config.Telemetry.PrometheusEnabled
+	if metricsEnabled {
+		metrics := newRPCMetrics()
+		unaryInterceptors = append(unaryInterceptors, metrics.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, metrics.StreamServerInterceptor())
+	}
+	// Enforce the rate and size limits per client, once the API key (if any) is known
+	if limits := newRequestLimits(config); limits != nil {
+		unaryInterceptors = append(unaryInterceptors, limits.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, limits.StreamServerInterceptor())
+	}
+	// The admin service only exposes unary methods, so it only needs authenticating there
+	if config.Admin.Enabled {
+		unaryInterceptors = append(unaryInterceptors, adminAuthUnaryInterceptor(config.Admin.Token))
+	}
+	var opts []grpc.ServerOption
+	if startTLS {
+		creds, err := credentials.NewServerTLSFromFile(config.TLS.CertFile, config.TLS.KeyFile)
+		if err != nil {
+			zlog.S.Errorf("Problem loading TLS file: %s - %v", config.TLS.CertFile, err)
+			_ = listen.Close()
+			return nil, nil, errors.New("failed to load TLS credentials from file")
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+	if config.Limits.MaxRequestSize > 0 { // Larger messages are rejected with ResourceExhausted
+		opts = append(opts, grpc.MaxRecvMsgSize(config.Limits.MaxRequestSize))
+	}
+	if metricsEnabled {
+		opts = append(opts, grpc.StatsHandler(otelgrpc.NewServerHandler()))
+	}
+	opts = append(opts, grpc.UnaryInterceptor(grpcmiddleware.ChainUnaryServer(unaryInterceptors...)))
+	opts = append(opts, grpc.StreamInterceptor(grpcmiddleware.ChainStreamServer(streamInterceptors...)))
+	server := grpc.NewServer(opts...)
+	if config.App.GRPCReflection {
+		reflection.Register(server)
+	}
+	return listen, server, nil
+}
@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	helperotel "github.com/scanoss/go-grpc-helper/pkg/grpc/otel"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	myconfig "scanoss.com/cryptography/pkg/config"
+)
+
+const serviceNamespace = "scanoss-cryptography"
+
+// initTelemetryProviders sets up the Open Telemetry providers requested in the config, and returns the function to shut them down.
+// The OLTP only setup is left to the gRPC helper. With the Prometheus exporter enabled, the providers are set up here instead,
+// as the metric instruments are bound to the first meter provider set, which must then hold both metric readers.
+func initTelemetryProviders(config *myconfig.ServerConfig, version string) (func(), error) {
+	if !config.Telemetry.PrometheusEnabled {
+		if !config.Telemetry.Enabled {
+			return func() {}, nil
+		}
+		return helperotel.InitTelemetryProviders(config.App.Name, serviceNamespace, version,
+			config.Telemetry.OltpExporter, helperotel.GetTraceSampler(config.App.Mode), false)
+	}
+	zlog.S.Info("Setting up Open Telemetry providers with the Prometheus exporter.")
+	ctx := context.Background()
+	res, err := resource.New(ctx, resource.WithHost(), resource.WithAttributes(
+		semconv.ServiceName(config.App.Name),
+		semconv.ServiceNamespace(serviceNamespace),
+		semconv.ServiceVersion(strings.TrimSpace(version)),
+	))
+	if err != nil {
+		zlog.S.Errorf("Failed to create telemetry resource: %v", err)
+		return nil, err
+	}
+	registry := prometheus.NewRegistry()
+	exporter, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+	if err != nil {
+		zlog.S.Errorf("Failed to setup the Prometheus exporter: %v", err)
+		return nil, err
+	}
+	listen, err := net.Listen("tcp", config.Telemetry.PrometheusPort)
+	if err != nil {
+		zlog.S.Errorf("Failed to listen for Prometheus on %v: %v", config.Telemetry.PrometheusPort, err)
+		return nil, err
+	}
+	opts := []sdkmetric.Option{sdkmetric.WithResource(res), sdkmetric.WithReader(exporter)}
+	var tracerProvider *sdktrace.TracerProvider
+	if config.Telemetry.Enabled {
+		metricExp, err := otlpmetricgrpc.New(ctx, otlpmetricgrpc.WithInsecure(), otlpmetricgrpc.WithEndpoint(config.Telemetry.OltpExporter))
+		if err != nil {
+			zlog.S.Errorf("Failed to setup oltp metric grpc: %v", err)
+			_ = listen.Close()
+			return nil, err
+		}
+		opts = append(opts, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExp, sdkmetric.WithInterval(2*time.Second))))
+		if tracerProvider, err = newTracerProvider(ctx, config, res); err != nil {
+			_ = listen.Close()
+			return nil, err
+		}
+	}
+	meterProvider := sdkmetric.NewMeterProvider(opts...)
+	otel.SetMeterProvider(meterProvider)
+	metricsServer := servePrometheus(listen, registry)
+	return func() {
+		cxt, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		if err := metricsServer.Shutdown(cxt); err != nil {
+			otel.Handle(err)
+		}
+		if tracerProvider != nil {
+			if err := tracerProvider.Shutdown(cxt); err != nil {
+				otel.Handle(err)
+			}
+		}
+		if err := meterProvider.Shutdown(cxt); err != nil { // pushes any last exports to the OLTP receiver
+			otel.Handle(err)
+		}
+	}, nil
+}
+
+// newTracerProvider sets up the global trace provider, exporting the spans to the OLTP exporter.
+func newTracerProvider(ctx context.Context, config *myconfig.ServerConfig, res *resource.Resource) (*sdktrace.TracerProvider, error) {
+	traceExp, err := otlptracegrpc.New(ctx, otlptracegrpc.WithInsecure(), otlptracegrpc.WithEndpoint(config.Telemetry.OltpExporter))
+	if err != nil {
+		zlog.S.Errorf("Failed to create collector trace exporter: %v", err)
+		return nil, err
+	}
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(helperotel.GetTraceSampler(config.App.Mode)),
+		sdktrace.WithResource(res),
+		sdktrace.WithBatcher(traceExp),
+	)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	otel.SetTracerProvider(tracerProvider)
+	return tracerProvider, nil
+}
+
+// servePrometheus serves the metrics gathered by the registry on /metrics in the background, for Prometheus to scrape.
+func servePrometheus(listen net.Listener, registry prometheus.Gatherer) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		zlog.S.Infof("Serving Prometheus metrics on %v/metrics", listen.Addr())
+		if err := server.Serve(listen); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			zlog.S.Errorf("Prometheus metrics server failed: %v", err)
+		}
+	}()
+	return server
+}
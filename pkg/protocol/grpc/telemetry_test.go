@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package grpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/grpc"
+	myconfig "scanoss.com/cryptography/pkg/config"
+)
+
+func TestPrometheusExporter(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	free, err := net.Listen("tcp", "127.0.0.1:0") // Find a free port to serve the metrics on
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	addr := free.Addr().String()
+	_ = free.Close()
+	config, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	config.Telemetry.PrometheusEnabled = true
+	config.Telemetry.PrometheusPort = addr
+	previous := otel.GetMeterProvider()
+	defer otel.SetMeterProvider(previous)
+	shutdown, err := initTelemetryProviders(config, "v0.0.0")
+	if err != nil {
+		t.Fatalf("initTelemetryProviders() unexpected error: %v", err)
+	}
+	defer shutdown()
+	interceptor := newRPCMetrics().UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/scanoss.api.cryptography.v2.Cryptography/GetComponentsAlgorithms"}
+	_, _ = interceptor(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) { return nil, nil })
+
+	resp, err := http.Get("http://" + addr + "/metrics")
+	if err != nil {
+		t.Fatalf("failed to scrape the metrics: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read the metrics: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("scrape status = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	metrics := string(body)
+	for _, want := range []string{"crypto_rpc_req_time_milliseconds_count{", `rpc="Cryptography/GetComponentsAlgorithms"`, `service_name="`} {
+		if !strings.Contains(metrics, want) {
+			t.Errorf("scraped metrics are missing %v:\n%v", want, metrics)
+		}
+	}
+}
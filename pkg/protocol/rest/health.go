@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/cryptography/pkg/dtos"
+)
+
+const (
+	HealthPath = "/health"
+	ReadyPath  = "/ready"
+)
+
+// HealthReporter reports the results of the latest KB health checks.
+type HealthReporter interface {
+	HealthReport() dtos.HealthOutput
+}
+
+// registerHealthHandlers adds the liveness (database reachable) and readiness (all KB checks passing) probes to the gateway.
+// Both return the latest health report, with a 503 status if the probe fails.
+func registerHealthHandlers(mux *runtime.ServeMux, reporter HealthReporter) error {
+	if err := mux.HandlePath(http.MethodGet, HealthPath, func(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+		report := reporter.HealthReport()
+		writeHealthReport(w, report, report.Live)
+	}); err != nil {
+		return err
+	}
+	return mux.HandlePath(http.MethodGet, ReadyPath, func(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+		report := reporter.HealthReport()
+		writeHealthReport(w, report, report.Ready)
+	})
+}
+
+// writeHealthReport writes the health report as JSON, with a status code reflecting the probe result.
+func writeHealthReport(w http.ResponseWriter, report dtos.HealthOutput, ok bool) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if ok {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		zlog.S.Warnf("Failed to write the health report: %v", err)
+	}
+}
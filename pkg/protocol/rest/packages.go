@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package rest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"scanoss.com/cryptography/pkg/api/packagesv2"
+)
+
+// PackagesHashesPath is the REST path of the package hash lookup.
+const PackagesHashesPath = "/v2/cryptography/packages/hashes"
+
+// registerPackagesHandler registers the package hash lookup route on the gateway, forwarding requests to the gRPC endpoint.
+// The packages service is not part of the PAPI protobuf, so the route is registered by hand following the generated handlers.
+func registerPackagesHandler(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) error {
+	conn, err := grpc.NewClient(endpoint, opts...)
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		if cerr := conn.Close(); cerr != nil {
+			zlog.S.Errorf("Failed to close conn to %s: %v", endpoint, cerr)
+		}
+	}()
+	return RegisterPackagesHandlerClient(mux, packagesv2.NewCryptographyPackagesClient(conn))
+}
+
+// RegisterPackagesHandlerClient registers the package hash lookup route on the gateway, forwarding requests to the given client.
+func RegisterPackagesHandlerClient(mux *runtime.ServeMux, client *packagesv2.CryptographyPackagesClient) error {
+	return mux.HandlePath(http.MethodPost, PackagesHashesPath, func(w http.ResponseWriter, req *http.Request, _ map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		annotatedContext, err := runtime.AnnotateContext(ctx, mux, req, packagesv2.GetPackagesCryptographyFullMethodName,
+			runtime.WithHTTPPathPattern(PackagesHashesPath))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		var metadata runtime.ServerMetadata
+		protoReq := &packagesv2.PackagesRequest{}
+		if err = inboundMarshaler.NewDecoder(req.Body).Decode(protoReq); err != nil && !errors.Is(err, io.EOF) {
+			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, status.Errorf(codes.InvalidArgument, "%v", err))
+			return
+		}
+		resp, err := client.GetPackagesCryptography(annotatedContext, protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
+		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, metadata)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		runtime.ForwardResponseMessage(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+	})
+}
@@ -20,24 +20,50 @@ package rest
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
 	"net/http"
 
 	gw "github.com/scanoss/go-grpc-helper/pkg/grpc/gateway"
 	pb "github.com/scanoss/papi/api/cryptographyv2"
 	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	myconfig "scanoss.com/cryptography/pkg/config"
 )
 
+// gatewayMetadataKey is the gRPC metadata key the gateway marks the calls it forwards with.
+const gatewayMetadataKey = "x-crypto-gateway"
+
+// gatewayToken is the value of the gateway marker. It is generated at startup, so only this process knows it.
+var gatewayToken = rand.Text()
+
+// IPFilter filters the requests allowed to reach the gateway, using the (reloadable) allow/deny IP lists.
+type IPFilter interface {
+	Wrap(next http.Handler) http.Handler
+}
+
 // RunServer runs REST grpc gateway to forward requests onto the gRPC server.
+// The /health and /ready probes are also served if a health reporter is supplied.
 func RunServer(config *myconfig.ServerConfig, ctx context.Context, grpcPort, httpPort string,
-	allowedIPs, deniedIPs []string, startTLS bool) (*http.Server, error) {
-	// configure the gateway for forwarding to gRPC
+	filters IPFilter, startTLS bool, health HealthReporter) (*http.Server, error) {
+	// configure the gateway for forwarding to gRPC. The IP filtering is left to the shared filters, so it follows their reloads
 	srv, mux, grpcGateway, opts, err := gw.SetupGateway(grpcPort, httpPort, config.TLS.CertFile, config.TLS.CN,
-		allowedIPs, deniedIPs, config.Filtering.BlockByDefault, config.Filtering.TrustProxy,
+		nil, nil, config.Filtering.BlockByDefault, config.Filtering.TrustProxy,
 		startTLS)
 	if err != nil {
 		return nil, err
 	}
+	opts = append(opts, grpc.WithChainUnaryInterceptor(markGatewayCall))
+	if filters != nil {
+		srv.Handler = filters.Wrap(srv.Handler)
+	}
+	// Expose the KB health probes (if requested)
+	if health != nil {
+		if err = registerHealthHandlers(mux, health); err != nil {
+			return nil, err
+		}
+	}
 	// Open TCP port (in the background) and listen for requests
 	go func() {
 		ctx2, cancel := context.WithCancel(ctx)
@@ -45,7 +71,33 @@ func RunServer(config *myconfig.ServerConfig, ctx context.Context, grpcPort, htt
 		if err := pb.RegisterCryptographyHandlerFromEndpoint(ctx2, mux, grpcGateway, opts); err != nil {
 			zlog.S.Panicf("Failed to start HTTP gateway %v", err)
 		}
+		if err := registerPackagesHandler(ctx2, mux, grpcGateway, opts); err != nil {
+			zlog.S.Panicf("Failed to register the packages HTTP gateway %v", err)
+		}
 		gw.StartGateway(srv, config.TLS.CertFile, config.TLS.KeyFile, startTLS)
 	}()
 	return srv, nil
 }
+
+// markGatewayCall adds the gateway marker to the calls forwarded onto the gRPC server.
+func markGatewayCall(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(MarkGatewayCall(ctx), method, req, reply, cc, opts...)
+}
+
+// MarkGatewayCall returns a copy of the outgoing context, marked as a call forwarded by the gateway.
+func MarkGatewayCall(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, gatewayMetadataKey, gatewayToken)
+}
+
+// FromGateway reports whether an incoming gRPC call was forwarded by the gateway of this process.
+// Clients can send the marker metadata themselves, but not its value.
+func FromGateway(ctx context.Context) bool {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, token := range md.Get(gatewayMetadataKey) {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(gatewayToken)) == 1 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package scanner
+
+// DefaultAlgorithms returns the built-in set of algorithm keywords. Strengths are the usual key or digest sizes (in bits).
+func DefaultAlgorithms() []AlgorithmDefinition {
+	return []AlgorithmDefinition{
+		{Algorithm: "md4", Strength: "128", Keywords: []string{"md4"}},
+		{Algorithm: "md5", Strength: "128", Keywords: []string{"md5"}},
+		{Algorithm: "sha1", Strength: "160", Keywords: []string{"sha1", "sha-1"}},
+		{Algorithm: "sha224", Strength: "224", Keywords: []string{"sha224", "sha-224"}},
+		{Algorithm: "sha256", Strength: "256", Keywords: []string{"sha256", "sha-256"}},
+		{Algorithm: "sha384", Strength: "384", Keywords: []string{"sha384", "sha-384"}},
+		{Algorithm: "sha512", Strength: "512", Keywords: []string{"sha512", "sha-512"}},
+		{Algorithm: "sha3", Strength: "256", Keywords: []string{"sha3", "sha3-256", "sha3-512", "keccak"}},
+		{Algorithm: "ripemd160", Strength: "160", Keywords: []string{"ripemd160", "ripemd-160"}},
+		{Algorithm: "blake2", Strength: "256", Keywords: []string{"blake2", "blake2b", "blake2s"}},
+		{Algorithm: "crc32", Strength: "32", Keywords: []string{"crc32"}},
+		{Algorithm: "hmac", Strength: "256", Keywords: []string{"hmac"}},
+		{Algorithm: "des", Strength: "56", Keywords: []string{"des", "des-cbc", "des_ecb_encrypt", "des_cbc_encrypt"}},
+		{Algorithm: "3des", Strength: "168", Keywords: []string{"3des", "tripledes", "triple-des", "desede", "des-ede3", "des3"}},
+		{Algorithm: "aes", Strength: "128", Keywords: []string{"aes", "aes128", "aes-128", "aes_128", "rijndael"}},
+		{Algorithm: "aes256", Strength: "256", Keywords: []string{"aes256", "aes-256", "aes_256"}},
+		{Algorithm: "blowfish", Strength: "128", Keywords: []string{"blowfish", "bf_encrypt"}},
+		{Algorithm: "twofish", Strength: "256", Keywords: []string{"twofish"}},
+		{Algorithm: "rc4", Strength: "128", Keywords: []string{"rc4", "arcfour"}},
+		{Algorithm: "chacha20", Strength: "256", Keywords: []string{"chacha20", "chacha20-poly1305", "xchacha20"}},
+		{Algorithm: "rsa", Strength: "2048", Keywords: []string{"rsa", "rsa-oaep", "rsa_pkcs1", "rsassa-pss"}},
+		{Algorithm: "dsa", Strength: "2048", Keywords: []string{"dsa"}},
+		{Algorithm: "ecdsa", Strength: "256", Keywords: []string{"ecdsa"}},
+		{Algorithm: "ed25519", Strength: "256", Keywords: []string{"ed25519"}},
+		{Algorithm: "ecdh", Strength: "256", Keywords: []string{"ecdh", "x25519", "curve25519"}},
+		{Algorithm: "dh", Strength: "2048", Keywords: []string{"diffie-hellman", "diffiehellman"}},
+		{Algorithm: "pbkdf2", Strength: "256", Keywords: []string{"pbkdf2"}},
+		{Algorithm: "bcrypt", Strength: "184", Keywords: []string{"bcrypt"}},
+		{Algorithm: "scrypt", Strength: "256", Keywords: []string{"scrypt"}},
+		{Algorithm: "argon2", Strength: "256", Keywords: []string{"argon2", "argon2id"}},
+	}
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package scanner
+
+import (
+	"cmp"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// keywordMatcher finds whole keyword matches (case-insensitive) in lines of text.
+type keywordMatcher struct {
+	re     *regexp.Regexp
+	owners map[string][]int // Indexes of the definitions (targets) owning each lower-case keyword
+}
+
+// keywordMatch is a keyword found in a line, along with the targets it belongs to.
+type keywordMatch struct {
+	keyword string
+	targets []int
+}
+
+// newKeywordMatcher builds a matcher for the keywords of each target. Empty keywords are ignored.
+// It returns nil if there are no keywords to match.
+func newKeywordMatcher(keywords [][]string) *keywordMatcher {
+	owners := make(map[string][]int)
+	for target, list := range keywords {
+		for _, k := range list {
+			k = strings.ToLower(strings.TrimSpace(k))
+			if len(k) > 0 && !slices.Contains(owners[k], target) {
+				owners[k] = append(owners[k], target)
+			}
+		}
+	}
+	if len(owners) == 0 {
+		return nil
+	}
+	patterns := make([]string, 0, len(owners))
+	for k := range owners {
+		patterns = append(patterns, k)
+	}
+	// Longer keywords first, so the longest keyword starting at a position is the one matched
+	slices.SortFunc(patterns, func(a, b string) int { return cmp.Or(cmp.Compare(len(b), len(a)), strings.Compare(a, b)) })
+	for i, p := range patterns {
+		patterns[i] = regexp.QuoteMeta(p)
+	}
+	return &keywordMatcher{re: regexp.MustCompile(strings.Join(patterns, "|")), owners: owners}
+}
+
+// match returns the keywords found in the line. A keyword only matches if it is not part of a longer
+// alphanumeric word (i.e. "des" matches "des_encrypt" or "DES-CBC" but not "describe").
+// Keywords may overlap (i.e. "openssl/md5.h" and "md5"), but only the longest one starting at each position is reported.
+func (m *keywordMatcher) match(line string) []keywordMatch {
+	lower := strings.ToLower(line)
+	var matches []keywordMatch
+	for pos := 0; pos < len(lower); {
+		loc := m.re.FindStringIndex(lower[pos:])
+		if loc == nil {
+			break
+		}
+		loc[0], loc[1] = loc[0]+pos, loc[1]+pos
+		pos = loc[0] + 1
+		keyword := lower[loc[0]:loc[1]]
+		if isAlphanumeric(keyword[0]) && loc[0] > 0 && isAlphanumeric(lower[loc[0]-1]) {
+			continue
+		}
+		if isAlphanumeric(keyword[len(keyword)-1]) && loc[1] < len(lower) && isAlphanumeric(lower[loc[1]]) {
+			continue
+		}
+		matches = append(matches, keywordMatch{keyword: keyword, targets: m.owners[keyword]})
+	}
+	return matches
+}
+
+func isAlphanumeric(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,268 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package scanner searches local source trees for the cryptographic algorithms and the crypto libraries,
+// SDKs and frameworks (hints) known to the KB, so first-party code can be assessed like third-party components.
+package scanner
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"scanoss.com/cryptography/pkg/dtos"
+)
+
+const (
+	defaultMaxFileSize = 5 * 1024 * 1024 // Files larger than this are skipped
+	defaultMaxEvidence = 100             // Evidence kept per algorithm/hint
+	binaryCheckSize    = 8000            // Bytes inspected for NUL characters to detect binary files
+)
+
+// DefaultIgnore lists the patterns skipped by default: version control metadata and installed dependencies.
+var DefaultIgnore = []string{".git/", ".svn/", ".hg/", "node_modules/"}
+
+// AlgorithmDefinition describes an algorithm and the keywords identifying it.
+type AlgorithmDefinition struct {
+	Algorithm string
+	Strength  string
+	Keywords  []string
+}
+
+// HintDefinition describes a crypto library, SDK or framework and the keywords identifying it.
+type HintDefinition struct {
+	Hint     dtos.ECDetectedItem
+	Keywords []string
+}
+
+// Config holds the settings of a local scan.
+type Config struct {
+	Algorithms  []AlgorithmDefinition
+	Hints       []HintDefinition
+	Ignore      []string // Glob patterns matched against the base name and relative path. A trailing '/' only matches directories
+	Workers     int      // Number of files scanned concurrently (defaults to the number of CPUs)
+	MaxFileSize int64    // Maximum size of the files scanned, in bytes
+	MaxEvidence int      // Maximum evidence kept per algorithm/hint
+}
+
+// Scanner searches local files for algorithm and hint keywords.
+type Scanner struct {
+	s       *zap.SugaredLogger
+	config  Config
+	matcher *keywordMatcher
+}
+
+// match is a keyword found in a file for a single algorithm or hint (target).
+type match struct {
+	target   int // Index of the algorithm, or of the hint after all the algorithms
+	evidence dtos.ScanEvidence
+}
+
+// fileResult holds the keyword matches of a single file.
+type fileResult struct {
+	matches []match
+	skipped bool
+}
+
+// NewScanner creates a scanner for the given configuration, applying defaults to unset limits.
+func NewScanner(s *zap.SugaredLogger, config Config) (*Scanner, error) {
+	keywords := make([][]string, 0, len(config.Algorithms)+len(config.Hints))
+	for _, a := range config.Algorithms {
+		keywords = append(keywords, a.Keywords)
+	}
+	for _, h := range config.Hints {
+		keywords = append(keywords, h.Keywords)
+	}
+	matcher := newKeywordMatcher(keywords)
+	if matcher == nil {
+		return nil, errors.New("no keywords to scan for")
+	}
+	if config.Workers <= 0 {
+		config.Workers = runtime.NumCPU()
+	}
+	if config.MaxFileSize <= 0 {
+		config.MaxFileSize = defaultMaxFileSize
+	}
+	if config.MaxEvidence <= 0 {
+		config.MaxEvidence = defaultMaxEvidence
+	}
+	return &Scanner{s: s, config: config, matcher: matcher}, nil
+}
+
+// Scan walks the given directory (or file), returning the algorithms and hints found in it.
+func (sc *Scanner) Scan(ctx context.Context, root string) (dtos.LocalScanOutput, error) {
+	if _, err := os.Stat(root); err != nil {
+		return dtos.LocalScanOutput{}, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	paths := make(chan string)
+	results := make(chan fileResult)
+	var wg sync.WaitGroup
+	for range sc.config.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range paths {
+				results <- sc.scanFile(root, p)
+			}
+		}()
+	}
+	var walkErr error
+	go func() {
+		walkErr = sc.walk(ctx, root, paths)
+		close(paths)
+		wg.Wait()
+		close(results)
+	}()
+	output := sc.aggregate(results)
+	if walkErr != nil {
+		return dtos.LocalScanOutput{}, walkErr
+	}
+	return output, nil
+}
+
+// walk sends the regular files under root that are not ignored to the paths channel.
+func (sc *Scanner) walk(ctx context.Context, root string, paths chan<- string) error {
+	return filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			sc.s.Warnf("Skipping %v: %v", p, err)
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if p != root && sc.ignored(relativePath(root, p), d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Type().IsRegular() {
+			select {
+			case paths <- p:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	})
+}
+
+// ignored reports whether the path (relative to the scan root) matches any of the ignore patterns.
+func (sc *Scanner) ignored(rel string, isDir bool) bool {
+	base := path.Base(rel)
+	for _, pattern := range sc.config.Ignore {
+		dirOnly := strings.HasSuffix(pattern, "/")
+		if pattern = strings.TrimSuffix(pattern, "/"); dirOnly && !isDir {
+			continue
+		}
+		if ok, _ := path.Match(pattern, base); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// scanFile searches a file for keywords, line by line. Binary, oversized and unreadable files are skipped.
+func (sc *Scanner) scanFile(root, p string) fileResult {
+	info, err := os.Stat(p)
+	if err != nil || info.Size() > sc.config.MaxFileSize {
+		sc.s.Debugf("Skipping %v (size limit or error: %v)", p, err)
+		return fileResult{skipped: true}
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		sc.s.Warnf("Failed to read %v: %v", p, err)
+		return fileResult{skipped: true}
+	}
+	if bytes.IndexByte(data[:min(len(data), binaryCheckSize)], 0) >= 0 {
+		sc.s.Debugf("Skipping binary file %v", p)
+		return fileResult{skipped: true}
+	}
+	rel := relativePath(root, p)
+	var result fileResult
+	for number := 1; len(data) > 0; number++ {
+		line := data
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			line, data = data[:i], data[i+1:]
+		} else {
+			data = nil
+		}
+		for _, m := range sc.matcher.match(string(line)) {
+			for _, target := range m.targets {
+				result.matches = append(result.matches, match{target: target,
+					evidence: dtos.ScanEvidence{File: rel, Line: number, Keyword: m.keyword}})
+			}
+		}
+	}
+	return result
+}
+
+// aggregate collects the matches of all the files into the scan output, sorted by name and evidence location.
+func (sc *Scanner) aggregate(results <-chan fileResult) dtos.LocalScanOutput {
+	evidence := make(map[int][]dtos.ScanEvidence)
+	output := dtos.LocalScanOutput{Algorithms: []dtos.LocalCryptoUsageItem{}, Hints: []dtos.LocalDetectedItem{}}
+	for r := range results {
+		if r.skipped {
+			output.FilesSkipped++
+			continue
+		}
+		output.FilesScanned++
+		for _, m := range r.matches {
+			evidence[m.target] = append(evidence[m.target], m.evidence)
+		}
+	}
+	for target, found := range evidence {
+		slices.SortFunc(found, func(a, b dtos.ScanEvidence) int {
+			return cmp.Or(strings.Compare(a.File, b.File), cmp.Compare(a.Line, b.Line), strings.Compare(a.Keyword, b.Keyword))
+		})
+		kept := found[:min(len(found), sc.config.MaxEvidence)]
+		if target < len(sc.config.Algorithms) {
+			a := sc.config.Algorithms[target]
+			output.Algorithms = append(output.Algorithms, dtos.LocalCryptoUsageItem{
+				CryptoUsageItem: dtos.CryptoUsageItem{Algorithm: a.Algorithm, Strength: a.Strength}, Matches: len(found), Evidence: kept})
+		} else {
+			h := sc.config.Hints[target-len(sc.config.Algorithms)]
+			output.Hints = append(output.Hints, dtos.LocalDetectedItem{ECDetectedItem: h.Hint, Matches: len(found), Evidence: kept})
+		}
+	}
+	slices.SortFunc(output.Algorithms, func(a, b dtos.LocalCryptoUsageItem) int { return strings.Compare(a.Algorithm, b.Algorithm) })
+	slices.SortFunc(output.Hints, func(a, b dtos.LocalDetectedItem) int { return strings.Compare(a.ID, b.ID) })
+	return output
+}
+
+// relativePath returns the slash separated path of p relative to the scan root (or its base name if root is p).
+func relativePath(root, p string) string {
+	rel, err := filepath.Rel(root, p)
+	if err != nil || rel == "." {
+		rel = filepath.Base(p)
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"scanoss.com/cryptography/pkg/dtos"
+)
+
+func TestKeywordMatcher(t *testing.T) {
+	matcher := newKeywordMatcher([][]string{{"des", "des-cbc"}, {"AES"}, {"openssl/evp.h", ""}, {"aes"}})
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{line: "DES_cbc_encrypt(in, out)", want: []string{"des"}},
+		{line: "cipher := \"DES-CBC\"", want: []string{"des-cbc"}},
+		{line: "describe the aes256 and codes", want: nil},
+		{line: "#include <openssl/evp.h> // AES", want: []string{"openssl/evp.h", "aes"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, m := range matcher.match(tt.line) {
+			got = append(got, m.keyword)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("keywordMatcher.match(%q) = %v, want %v", tt.line, got, tt.want)
+		}
+	}
+	if m := matcher.match("aes"); len(m) != 1 || !slices.Equal(m[0].targets, []int{1, 3}) {
+		t.Errorf("keywordMatcher.match() expected aes to belong to two targets, got %+v", m)
+	}
+	if newKeywordMatcher([][]string{{""}}) != nil {
+		t.Errorf("newKeywordMatcher() expected nil without keywords")
+	}
+}
+
+func TestScan(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	root := t.TempDir()
+	files := map[string]string{
+		"src/hash.c":          "#include <openssl/md5.h>\n\nMD5_Init(&ctx);\n/* describe */ MD5_Final(digest, &ctx);\n",
+		"src/cipher.go":       "block, _ := aes.NewCipher(key)\n",
+		"docs/notes.md":       "Uses AES and MD5",
+		"node_modules/x.js":   "crypto.createHash('md5')",
+		"src/data.bin":        "md5\x00\x01\x02",
+		"src/large/empty.txt": "",
+	}
+	for name, content := range files {
+		p := filepath.Join(root, name)
+		if err = os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err = os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	config := Config{
+		Algorithms: DefaultAlgorithms(),
+		Hints: []HintDefinition{{Hint: dtos.ECDetectedItem{ID: "library/openssl", Name: "OpenSSL", Category: "library"},
+			Keywords: []string{"openssl/md5.h", "openssl/evp.h"}}},
+		Ignore:  append(slices.Clone(DefaultIgnore), "*.md"),
+		Workers: 2,
+	}
+	sc, err := NewScanner(zlog.S, config)
+	if err != nil {
+		t.Fatalf("NewScanner() unexpected error: %v", err)
+	}
+	output, err := sc.Scan(context.Background(), root)
+	if err != nil {
+		t.Fatalf("Scanner.Scan() unexpected error: %v", err)
+	}
+	if output.FilesScanned != 3 || output.FilesSkipped != 1 {
+		t.Errorf("Scanner.Scan() scanned %d and skipped %d files, want 3 and 1", output.FilesScanned, output.FilesSkipped)
+	}
+	var algorithms []string
+	for _, a := range output.Algorithms {
+		algorithms = append(algorithms, a.Algorithm)
+	}
+	if !slices.Equal(algorithms, []string{"aes", "md5"}) {
+		t.Fatalf("Scanner.Scan() found algorithms %v, want [aes md5]", algorithms)
+	}
+	md5 := output.Algorithms[1]
+	want := []dtos.ScanEvidence{{File: "src/hash.c", Line: 1, Keyword: "md5"}, {File: "src/hash.c", Line: 3, Keyword: "md5"}, {File: "src/hash.c", Line: 4, Keyword: "md5"}}
+	if md5.Matches != 3 || !slices.Equal(md5.Evidence, want) {
+		t.Errorf("Scanner.Scan() md5 evidence = %+v, want %+v", md5.Evidence, want)
+	}
+	if len(output.Hints) != 1 || output.Hints[0].ID != "library/openssl" || output.Hints[0].Evidence[0].Line != 1 {
+		t.Errorf("Scanner.Scan() unexpected hints: %+v", output.Hints)
+	}
+	if _, err = NewScanner(zlog.S, Config{}); err == nil {
+		t.Errorf("NewScanner() expected an error without keywords")
+	}
+	if _, err = sc.Scan(context.Background(), filepath.Join(root, "missing")); err == nil {
+		t.Errorf("Scanner.Scan() expected an error for a missing directory")
+	}
+}
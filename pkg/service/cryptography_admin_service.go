@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	gd "github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"scanoss.com/cryptography/pkg/api/adminv2"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/models"
+	"scanoss.com/cryptography/pkg/usecase"
+)
+
+// redacted replaces the value of secret config options.
+const redacted = "*****"
+
+// FilterReloader reloads the allow/deny IP lists applied to incoming connections.
+type FilterReloader interface {
+	ReloadFilters() (allowed int, denied int, err error)
+}
+
+type cryptographyAdminServer struct {
+	db      *sqlx.DB
+	config  *myconfig.ServerConfig
+	filters FilterReloader
+}
+
+// NewCryptographyAdminServer creates a new instance of the Cryptography Admin Server.
+func NewCryptographyAdminServer(db *sqlx.DB, config *myconfig.ServerConfig, filters FilterReloader) adminv2.CryptographyAdminServer {
+	return &cryptographyAdminServer{db: db, config: config, filters: filters}
+}
+
+// GetKBStats returns the row counts of the KB tables and the date of the latest version loaded.
+func (c cryptographyAdminServer) GetKBStats(ctx context.Context, _ *emptypb.Empty) (*structpb.Struct, error) {
+	s := ctxzap.Extract(ctx).Sugar()
+	s.Info("Processing admin KB stats request...")
+	conn, err := c.db.Connx(ctx) // Get a connection from the pool
+	if err != nil {
+		s.Errorf("Failed to get a database connection from the pool: %v", err)
+		return nil, status.Error(codes.Unavailable, "problem getting database pool connection")
+	}
+	defer gd.CloseSQLConnection(conn)
+	stats, err := usecase.NewKBStats(ctx, s, conn, c.config).GetKBStats()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "problem querying the KB stats")
+	}
+	return toStruct(s, stats)
+}
+
+// FlushCache drops every entry of the KB lookup cache, returning the cache metrics from before the flush.
+func (c cryptographyAdminServer) FlushCache(ctx context.Context, _ *emptypb.Empty) (*structpb.Struct, error) {
+	s := ctxzap.Extract(ctx).Sugar()
+	stats := models.GetCacheStats()
+	models.InvalidateCache()
+	s.Infof("Flushed the KB cache (enabled: %v)", stats != nil)
+	return toStruct(s, struct {
+		Enabled bool                `json:"enabled"`
+		Caches  []models.CacheStats `json:"caches"`
+	}{Enabled: stats != nil, Caches: stats})
+}
+
+// ReloadFilters re-reads the allow/deny IP list files configured in Filtering.
+func (c cryptographyAdminServer) ReloadFilters(ctx context.Context, _ *emptypb.Empty) (*structpb.Struct, error) {
+	s := ctxzap.Extract(ctx).Sugar()
+	if c.filters == nil {
+		return nil, status.Error(codes.FailedPrecondition, "IP filtering reload is not supported by this server")
+	}
+	allowed, denied, err := c.filters.ReloadFilters()
+	if err != nil {
+		s.Errorf("Failed to reload the IP filtering lists: %v", err)
+		return nil, status.Errorf(codes.FailedPrecondition, "problem reloading the IP filtering lists: %v", err)
+	}
+	return toStruct(s, struct {
+		Allowed int `json:"allowed"`
+		Denied  int `json:"denied"`
+	}{Allowed: allowed, Denied: denied})
+}
+
+// SetLogLevel changes the logging level of the server.
+func (c cryptographyAdminServer) SetLogLevel(ctx context.Context, request *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+	level, err := zapcore.ParseLevel(request.GetValue())
+	if err != nil || len(request.GetValue()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid log level: '%v'", request.GetValue())
+	}
+	zlog.SetLevel(level.String())
+	return wrapperspb.String(level.String()), nil
+}
+
+// GetConfig returns the running server configuration, with secrets redacted.
+func (c cryptographyAdminServer) GetConfig(ctx context.Context, _ *emptypb.Empty) (*structpb.Struct, error) {
+	s := ctxzap.Extract(ctx).Sugar()
+	return toStruct(s, redactConfig(c.config))
+}
+
+// redactConfig returns a copy of the config with the passwords, tokens and DSN (which can embed credentials) masked.
+func redactConfig(config *myconfig.ServerConfig) myconfig.ServerConfig {
+	cfg := *config
+	for _, secret := range []*string{&cfg.Database.Passwd, &cfg.Database.Dsn, &cfg.Admin.Token} {
+		if len(*secret) > 0 {
+			*secret = redacted
+		}
+	}
+	return cfg
+}
+
+// toStruct converts the given value into a protobuf Struct, using its JSON representation.
+func toStruct(s *zap.SugaredLogger, value any) (*structpb.Struct, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		s.Errorf("Problem marshalling output: %v", err)
+		return nil, status.Error(codes.Internal, "problem marshalling output")
+	}
+	var fields map[string]any
+	if err = json.Unmarshal(data, &fields); err != nil {
+		s.Errorf("Problem unmarshalling output: %v", err)
+		return nil, status.Error(codes.Internal, "problem unmarshalling output")
+	}
+	result, err := structpb.NewStruct(fields)
+	if err != nil {
+		s.Errorf("Problem converting output: %v", err)
+		return nil, status.Error(codes.Internal, "problem converting output")
+	}
+	return result, nil
+}
@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	_ "modernc.org/sqlite"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+// stubFilterReloader records filter reloads, returning a fixed result.
+type stubFilterReloader struct {
+	reloads int
+	err     error
+}
+
+func (f *stubFilterReloader) ReloadFilters() (int, int, error) {
+	f.reloads++
+	return 2, 1, f.err
+}
+
+func TestCryptographyAdminServer(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	db.SetMaxOpenConns(1) // Keep a single in-memory database shared by every request
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, ctx, nil); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	myConfig.Database.Passwd = "secret"
+	myConfig.Admin.Token = "admin-secret"
+	filters := &stubFilterReloader{}
+	server := NewCryptographyAdminServer(db, myConfig, filters)
+
+	kbStats, err := server.GetKBStats(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("GetKBStats() unexpected error: %v", err)
+	}
+	tables := kbStats.GetFields()["tables"].GetListValue().GetValues()
+	if len(tables) == 0 || tables[0].GetStructValue().GetFields()["rows"].GetNumberValue() == 0 {
+		t.Errorf("GetKBStats() expected table row counts, got %v", kbStats)
+	}
+
+	models.ConfigureCache(zlog.S, 10, time.Minute)
+	defer models.ConfigureCache(zlog.S, 0, 0)
+	flushed, err := server.FlushCache(ctx, &emptypb.Empty{})
+	if err != nil || !flushed.GetFields()["enabled"].GetBoolValue() {
+		t.Errorf("FlushCache() = %v, %v", flushed, err)
+	}
+
+	reloaded, err := server.ReloadFilters(ctx, &emptypb.Empty{})
+	if err != nil || filters.reloads != 1 || reloaded.GetFields()["allowed"].GetNumberValue() != 2 {
+		t.Errorf("ReloadFilters() = %v, %v", reloaded, err)
+	}
+	filters.err = errors.New("file not found")
+	if _, err = server.ReloadFilters(ctx, &emptypb.Empty{}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("ReloadFilters() expected a failed precondition error, got %v", err)
+	}
+
+	level, err := server.SetLogLevel(ctx, wrapperspb.String("WARN"))
+	if err != nil || level.GetValue() != "warn" {
+		t.Errorf("SetLogLevel() = %v, %v", level, err)
+	}
+	for _, invalid := range []string{"", "verbose"} {
+		if _, err = server.SetLogLevel(ctx, wrapperspb.String(invalid)); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("SetLogLevel(%q) expected an invalid argument error, got %v", invalid, err)
+		}
+	}
+	zlog.SetLevel("debug")
+
+	cfg, err := server.GetConfig(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("GetConfig() unexpected error: %v", err)
+	}
+	database := cfg.GetFields()["Database"].GetStructValue().GetFields()
+	if database["Passwd"].GetStringValue() != redacted || database["User"].GetStringValue() != myConfig.Database.User {
+		t.Errorf("GetConfig() expected the password to be redacted, got %v", database)
+	}
+	if cfg.GetFields()["Admin"].GetStructValue().GetFields()["Token"].GetStringValue() != redacted {
+		t.Errorf("GetConfig() expected the admin token to be redacted")
+	}
+	if myConfig.Database.Passwd != "secret" {
+		t.Errorf("GetConfig() should not modify the running config")
+	}
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	gd "github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	common "github.com/scanoss/papi/api/commonv2"
+	pb "github.com/scanoss/papi/api/cryptographyv2"
+	"scanoss.com/cryptography/pkg/api/packagesv2"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/usecase"
+)
+
+type cryptographyPackagesServer struct {
+	db     *sqlx.DB
+	config *myconfig.ServerConfig
+}
+
+// NewCryptographyPackagesServer creates a new instance of the Cryptography Packages Server.
+func NewCryptographyPackagesServer(db *sqlx.DB, config *myconfig.ServerConfig) packagesv2.CryptographyPackagesServer {
+	setupMetrics()
+	return &cryptographyPackagesServer{db: db, config: config}
+}
+
+// GetPackagesCryptography retrieves the algorithms and hints of the packages matching the requested package hashes.
+func (c cryptographyPackagesServer) GetPackagesCryptography(ctx context.Context, request *packagesv2.PackagesRequest) (*packagesv2.PackagesResponse, error) {
+	requestStartTime := time.Now() // Capture the scan start time
+	s := ctxzap.Extract(ctx).Sugar()
+	s.Info("Processing packages cryptography request...")
+	if len(request.GetHashes()) == 0 {
+		s.Warn("Invalid packages request: no package hashes supplied")
+		statusResp := common.StatusResponse{Status: common.StatusCode_FAILED,
+			Message: "'hashes' array cannot be empty, at least one package hash must be provided"}
+		return &packagesv2.PackagesResponse{Status: &statusResp}, nil
+	}
+	conn, err := c.db.Connx(ctx) // Get a connection from the pool
+	if err != nil {
+		s.Errorf("Failed to get a database connection from the pool: %v", err)
+		statusResp := common.StatusResponse{Status: common.StatusCode_FAILED, Message: "Failed to get database pool connection"}
+		return &packagesv2.PackagesResponse{Status: &statusResp}, errors.New("problem getting database pool connection")
+	}
+	defer gd.CloseSQLConnection(conn)
+	results, summary, err := usecase.NewPackages(ctx, s, conn, c.config).GetPackagesCryptography(request.GetHashes())
+	if err != nil {
+		s.Errorf("Failed to get packages cryptography: %v", err)
+		statusResp := common.StatusResponse{Status: common.StatusCode_FAILED, Message: "Problems encountered extracting Cryptography data"}
+		return &packagesv2.PackagesResponse{Status: &statusResp}, nil
+	}
+	response := &packagesv2.PackagesResponse{Packages: convertPackagesOutput(results), Status: buildStatusResponse(ctx, s, summary, true)}
+	telemetryRequestTime(ctx, c.config, requestStartTime)
+	return response, nil
+}
+
+// convertPackagesOutput converts the packages found into their protobuf messages.
+func convertPackagesOutput(output dtos.PackagesOutput) []*packagesv2.PackageCryptography {
+	packages := make([]*packagesv2.PackageCryptography, 0, len(output.Packages))
+	for _, p := range output.Packages {
+		algorithms := make([]*pb.Algorithm, 0, len(p.Algorithms))
+		for _, a := range p.Algorithms {
+			algorithms = append(algorithms, &pb.Algorithm{Algorithm: a.Algorithm, Strength: a.Strength})
+		}
+		hints := make([]*packagesv2.PackageHint, 0, len(p.Hints))
+		for _, h := range p.Hints {
+			hints = append(hints, &packagesv2.PackageHint{Id: h.ID, Name: h.Name, Description: h.Description,
+				Category: h.Category, Url: h.URL, Purl: h.Purl, Tags: h.Tags})
+		}
+		packages = append(packages, &packagesv2.PackageCryptography{PackageHash: p.PackageHash, Purl: p.Purl,
+			Version: p.Version, Algorithms: algorithms, Hints: hints})
+	}
+	return packages
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	common "github.com/scanoss/papi/api/commonv2"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+	"scanoss.com/cryptography/pkg/api/packagesv2"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+func TestCryptographyPackagesServer(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	db.SetMaxOpenConns(1) // Keep a single in-memory database shared by every request
+	defer models.CloseDB(db)
+	if err = models.LoadTestSQLData(db, ctx, nil); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	myConfig.Database.Trace = true
+	server := NewCryptographyPackagesServer(db, myConfig)
+
+	tests := []struct {
+		name         string
+		hashes       []string
+		wantStatus   common.StatusCode
+		wantPackages int
+	}{
+		{
+			name:         "found",
+			hashes:       []string{"2c2ae45c192df28dcfd1caab7e2b12db", "00000000000000000000000000000000"},
+			wantStatus:   common.StatusCode_SUCCEEDED_WITH_WARNINGS,
+			wantPackages: 1,
+		},
+		{
+			name:       "missing hashes",
+			wantStatus: common.StatusCode_FAILED,
+		},
+		{
+			name:       "invalid hashes",
+			hashes:     []string{"not-a-hash"},
+			wantStatus: common.StatusCode_FAILED,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := server.GetPackagesCryptography(ctx, &packagesv2.PackagesRequest{Hashes: tt.hashes})
+			if err != nil {
+				t.Fatalf("GetPackagesCryptography() unexpected error: %v", err)
+			}
+			if got := response.GetStatus().GetStatus(); got != tt.wantStatus {
+				t.Errorf("GetPackagesCryptography() status = %v, want %v", got, tt.wantStatus)
+			}
+			if got := len(response.GetPackages()); got != tt.wantPackages {
+				t.Errorf("GetPackagesCryptography() packages = %v, want %v", got, tt.wantPackages)
+			}
+		})
+	}
+}
@@ -191,7 +191,7 @@ func (c cryptographyServer) GetAlgorithmsInRange(ctx context.Context, request *c
 	}
 	defer gd.CloseSQLConnection(conn)
 	// Search the KB for information about each Cryptography
-	cryptoUc := usecase.NewCryptoMajor(ctx, s, conn, c.config)
+	cryptoUc := usecase.NewCryptoMajor(ctx, s, c.db, conn, c.config)
 	dtoCrypto, summary, err := cryptoUc.GetCryptoInRange(dtoRequest)
 	if err != nil {
 		s.Errorf("Failed to get cryptographic algorithms: %v", err)
@@ -236,7 +236,7 @@ func (c cryptographyServer) GetComponentsAlgorithmsInRange(ctx context.Context,
 	}
 	defer gd.CloseSQLConnection(conn)
 	// Search the KB for information about each Cryptography
-	cryptoUc := usecase.NewCryptoMajor(ctx, s, conn, c.config)
+	cryptoUc := usecase.NewCryptoMajor(ctx, s, c.db, conn, c.config)
 	dtoCrypto, summary, err := cryptoUc.GetCryptoInRange(componentDTOS)
 	if err != nil {
 		s.Errorf("Failed to get cryptographic algorithms: %v", err)
@@ -247,7 +247,7 @@ func (c cryptographyServer) GetComponentsAlgorithmsInRange(ctx context.Context,
 	// Set the status and respond with the data
 	statusRep := buildStatusResponse(ctx, s, summary, true)
 	if dtoCrypto.Cryptography == nil {
-		return &pb.ComponentsAlgorithmsInRangeResponse{Status: buildStatusResponse(ctx, s, summary, true)}, nil
+		return &pb.ComponentsAlgorithmsInRangeResponse{Status: statusRep}, nil
 	}
 	response, err := convertComponentsCryptoInRangeOutput(s, dtoCrypto) // Convert the internal data into a response object
 	if err != nil {
@@ -316,7 +316,7 @@ func (c cryptographyServer) GetVersionsInRange(ctx context.Context, request *com
 	}
 	defer gd.CloseSQLConnection(conn)
 	// Search the KB for information about each Cryptography
-	cryptoUc := usecase.NewVersionsUsingCrypto(ctx, s, conn, c.config)
+	cryptoUc := usecase.NewVersionsUsingCrypto(ctx, s, c.db, conn, c.config)
 	dtoCrypto, summary, err := cryptoUc.GetVersionsInRangeUsingCrypto(componentDTOS)
 	if err != nil {
 		s.Errorf("Failed to get cryptographic algorithms: %v", err)
@@ -361,7 +361,7 @@ func (c cryptographyServer) GetComponentsVersionsInRange(ctx context.Context, re
 	}
 	defer gd.CloseSQLConnection(conn)
 	// Search the KB for information about each Cryptography
-	cryptoUc := usecase.NewVersionsUsingCrypto(ctx, s, conn, c.config)
+	cryptoUc := usecase.NewVersionsUsingCrypto(ctx, s, c.db, conn, c.config)
 	dtoCrypto, summary, err := cryptoUc.GetVersionsInRangeUsingCrypto(componentDTOS)
 	if err != nil {
 		s.Errorf("Failed to get cryptographic algorithms: %v", err)
@@ -441,7 +441,7 @@ func (c cryptographyServer) GetHintsInRange(ctx context.Context, request *common
 	}
 	defer gd.CloseSQLConnection(conn)
 	// Search the KB for information about each Cryptography
-	ecDetectionUC := usecase.NewECDetection(ctx, s, conn, c.config)
+	ecDetectionUC := usecase.NewECDetection(ctx, s, c.db, conn, c.config).FilterByTags(hintTagsFromContext(ctx))
 	dtoEC, summary, err := ecDetectionUC.GetDetectionsInRange(dtoRequest)
 	if err != nil {
 		s.Errorf("Failed to get cryptographic algorithms: %v", err)
@@ -486,7 +486,7 @@ func (c cryptographyServer) GetComponentsHintsInRange(ctx context.Context, reque
 	}
 	defer gd.CloseSQLConnection(conn)
 	// Search the KB for information about each Cryptography
-	ecDetectionUC := usecase.NewECDetection(ctx, s, conn, c.config)
+	ecDetectionUC := usecase.NewECDetection(ctx, s, c.db, conn, c.config).FilterByTags(hintTagsFromContext(ctx))
 	dtoEC, summary, err := ecDetectionUC.GetDetectionsInRange(componentDTOS)
 	if err != nil {
 		s.Errorf("Failed to get hints in range: %v", err)
@@ -564,7 +564,7 @@ func (c cryptographyServer) GetEncryptionHints(ctx context.Context, request *com
 	}
 	defer gd.CloseSQLConnection(conn)
 	// Search the KB for information about each Cryptography
-	ecDetectionUC := usecase.NewECDetection(ctx, s, conn, c.config)
+	ecDetectionUC := usecase.NewECDetection(ctx, s, c.db, conn, c.config).FilterByTags(hintTagsFromContext(ctx))
 	dtoEC, summary, err := ecDetectionUC.GetDetections(componentDTOS)
 	if err != nil {
 		s.Errorf("Failed to get encryption hints: %v", err)
@@ -609,7 +609,7 @@ func (c cryptographyServer) GetComponentsEncryptionHints(ctx context.Context, re
 	}
 	defer gd.CloseSQLConnection(conn)
 	// Search the KB for information about each Cryptography
-	ecDetectionUC := usecase.NewECDetection(ctx, s, conn, c.config)
+	ecDetectionUC := usecase.NewECDetection(ctx, s, c.db, conn, c.config).FilterByTags(hintTagsFromContext(ctx))
 	encryptionHints, summary, err := ecDetectionUC.GetDetections(componentDTOS)
 	if err != nil {
 		s.Errorf("Failed to get encryption hints: %v", err)
@@ -31,6 +31,7 @@ import (
 	common "github.com/scanoss/papi/api/commonv2"
 	pb "github.com/scanoss/papi/api/cryptographyv2"
 	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc/metadata"
 	_ "modernc.org/sqlite"
 	myconfig "scanoss.com/cryptography/pkg/config"
 	"scanoss.com/cryptography/pkg/models"
@@ -1573,3 +1574,47 @@ func TestCryptographyServer_GetComponentEncryptionHints(t *testing.T) {
 		})
 	}
 }
+
+func TestCryptographyServer_HintTags(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	db.SetMaxOpenConns(1) // Keep a single in-memory database shared by every request
+	defer models.CloseDB(db)
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	if err = models.LoadTestSQLData(db, ctx, nil); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	server := NewCryptographyServer(db, myConfig)
+	request := &common.ComponentsRequest{Components: []*common.ComponentRequest{
+		{Purl: "pkg:github/pineappleea/pineapple-src", Requirement: "5.4.7"},
+	}}
+	all, err := server.GetComponentsEncryptionHints(ctx, request)
+	if err != nil || len(all.GetComponents()) != 1 {
+		t.Fatalf("GetComponentsEncryptionHints() = %v, %v", all, err)
+	}
+	tagged := metadata.NewIncomingContext(ctx, metadata.Pairs(HintTagsMetadataKey, "FIPS, pqc"))
+	fips, err := server.GetComponentsEncryptionHints(tagged, request)
+	if err != nil || len(fips.GetComponents()) != 1 {
+		t.Fatalf("GetComponentsEncryptionHints() = %v, %v", fips, err)
+	}
+	if got, total := len(fips.GetComponents()[0].GetHints()), len(all.GetComponents()[0].GetHints()); got == 0 || got >= total {
+		t.Errorf("GetComponentsEncryptionHints() expected a subset of the %d hints to be tagged fips, got %d", total, got)
+	}
+	inRange, err := server.GetComponentsHintsInRange(tagged, &common.ComponentsRequest{Components: []*common.ComponentRequest{
+		{Purl: "pkg:github/pineappleea/pineapple-src", Requirement: ">=0.0.0"},
+	}})
+	if err != nil || len(inRange.GetComponents()) != 1 || len(inRange.GetComponents()[0].GetVersions()) != 1 {
+		t.Errorf("GetComponentsHintsInRange() expected only the versions with fips hints, got %v, %v", inRange, err)
+	}
+}
@@ -0,0 +1,388 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	gd "github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	common "github.com/scanoss/papi/api/commonv2"
+	pb "github.com/scanoss/papi/api/cryptographyv2"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"scanoss.com/cryptography/pkg/api/streamv2"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/models"
+	"scanoss.com/cryptography/pkg/usecase"
+)
+
+type cryptographyStreamServer struct {
+	streamv2.CryptographyStreamServer
+	db     *sqlx.DB
+	config *myconfig.ServerConfig
+}
+
+// NewCryptographyStreamServer creates a new instance of the Cryptography streaming Server.
+func NewCryptographyStreamServer(db *sqlx.DB, config *myconfig.ServerConfig) streamv2.CryptographyStreamServer {
+	return &cryptographyStreamServer{db: db, config: config}
+}
+
+// componentLookup searches the KB for a set of components and returns one response message per component found.
+// The database pool (db) lets the use cases spread the components over their workers.
+type componentLookup[T any] func(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB, conn *sqlx.Conn, config *myconfig.ServerConfig,
+	components []dtos.ComponentDTO) ([]*T, models.QuerySummary, error)
+
+// streamSender is the subset of a gRPC server stream used to emit results.
+type streamSender[T any] interface {
+	Send(*T) error
+	Context() context.Context
+}
+
+// componentStream holds the state shared by every message of a streaming request.
+type componentStream[T any] struct {
+	s          *zap.SugaredLogger
+	db         *sqlx.DB
+	conn       *sqlx.Conn
+	config     *myconfig.ServerConfig
+	lookup     componentLookup[T]
+	withStatus func(*common.StatusResponse) *T
+	summary    models.QuerySummary
+}
+
+// GetComponentsAlgorithmsStream streams the cryptographic algorithms of each requested component.
+func (c cryptographyStreamServer) GetComponentsAlgorithmsStream(request *common.ComponentsRequest,
+	stream grpc.ServerStreamingServer[pb.ComponentAlgorithmsResponse]) error {
+	return serveComponents(c, request, stream, "crypto algorithms", lookupAlgorithms,
+		func(status *common.StatusResponse) *pb.ComponentAlgorithmsResponse {
+			return &pb.ComponentAlgorithmsResponse{Status: status}
+		})
+}
+
+// GetComponentsAlgorithmsInRangeStream streams the cryptographic algorithms in range of each requested component.
+func (c cryptographyStreamServer) GetComponentsAlgorithmsInRangeStream(request *common.ComponentsRequest,
+	stream grpc.ServerStreamingServer[pb.ComponentAlgorithmsInRangeResponse]) error {
+	return serveComponents(c, request, stream, "crypto algorithms in range", lookupAlgorithmsInRange,
+		func(status *common.StatusResponse) *pb.ComponentAlgorithmsInRangeResponse {
+			return &pb.ComponentAlgorithmsInRangeResponse{Status: status}
+		})
+}
+
+// GetComponentsVersionsInRangeStream streams the versions with/without cryptography of each requested component.
+func (c cryptographyStreamServer) GetComponentsVersionsInRangeStream(request *common.ComponentsRequest,
+	stream grpc.ServerStreamingServer[pb.ComponentVersionsInRangeResponse]) error {
+	return serveComponents(c, request, stream, "versions in range", lookupVersionsInRange,
+		func(status *common.StatusResponse) *pb.ComponentVersionsInRangeResponse {
+			return &pb.ComponentVersionsInRangeResponse{Status: status}
+		})
+}
+
+// GetComponentsHintsInRangeStream streams the encryption hints in range of each requested component.
+func (c cryptographyStreamServer) GetComponentsHintsInRangeStream(request *common.ComponentsRequest,
+	stream grpc.ServerStreamingServer[pb.ComponentHintsInRangeResponse]) error {
+	return serveComponents(c, request, stream, "hints in range", lookupHintsInRange,
+		func(status *common.StatusResponse) *pb.ComponentHintsInRangeResponse {
+			return &pb.ComponentHintsInRangeResponse{Status: status}
+		})
+}
+
+// GetComponentsEncryptionHintsStream streams the encryption hints of each requested component.
+func (c cryptographyStreamServer) GetComponentsEncryptionHintsStream(request *common.ComponentsRequest,
+	stream grpc.ServerStreamingServer[pb.ComponentEncryptionHintsResponse]) error {
+	return serveComponents(c, request, stream, "encryption hints", lookupEncryptionHints,
+		func(status *common.StatusResponse) *pb.ComponentEncryptionHintsResponse {
+			return &pb.ComponentEncryptionHintsResponse{Status: status}
+		})
+}
+
+// StreamComponentsAlgorithms streams the cryptographic algorithms of each component received from the client.
+func (c cryptographyStreamServer) StreamComponentsAlgorithms(
+	stream grpc.BidiStreamingServer[common.ComponentsRequest, pb.ComponentAlgorithmsResponse]) error {
+	return serveComponentChunks(c, stream, "crypto algorithms", lookupAlgorithms,
+		func(status *common.StatusResponse) *pb.ComponentAlgorithmsResponse {
+			return &pb.ComponentAlgorithmsResponse{Status: status}
+		})
+}
+
+// StreamComponentsAlgorithmsInRange streams the cryptographic algorithms in range of each component received from the client.
+func (c cryptographyStreamServer) StreamComponentsAlgorithmsInRange(
+	stream grpc.BidiStreamingServer[common.ComponentsRequest, pb.ComponentAlgorithmsInRangeResponse]) error {
+	return serveComponentChunks(c, stream, "crypto algorithms in range", lookupAlgorithmsInRange,
+		func(status *common.StatusResponse) *pb.ComponentAlgorithmsInRangeResponse {
+			return &pb.ComponentAlgorithmsInRangeResponse{Status: status}
+		})
+}
+
+// StreamComponentsVersionsInRange streams the versions with/without cryptography of each component received from the client.
+func (c cryptographyStreamServer) StreamComponentsVersionsInRange(
+	stream grpc.BidiStreamingServer[common.ComponentsRequest, pb.ComponentVersionsInRangeResponse]) error {
+	return serveComponentChunks(c, stream, "versions in range", lookupVersionsInRange,
+		func(status *common.StatusResponse) *pb.ComponentVersionsInRangeResponse {
+			return &pb.ComponentVersionsInRangeResponse{Status: status}
+		})
+}
+
+// StreamComponentsHintsInRange streams the encryption hints in range of each component received from the client.
+func (c cryptographyStreamServer) StreamComponentsHintsInRange(
+	stream grpc.BidiStreamingServer[common.ComponentsRequest, pb.ComponentHintsInRangeResponse]) error {
+	return serveComponentChunks(c, stream, "hints in range", lookupHintsInRange,
+		func(status *common.StatusResponse) *pb.ComponentHintsInRangeResponse {
+			return &pb.ComponentHintsInRangeResponse{Status: status}
+		})
+}
+
+// StreamComponentsEncryptionHints streams the encryption hints of each component received from the client.
+func (c cryptographyStreamServer) StreamComponentsEncryptionHints(
+	stream grpc.BidiStreamingServer[common.ComponentsRequest, pb.ComponentEncryptionHintsResponse]) error {
+	return serveComponentChunks(c, stream, "encryption hints", lookupEncryptionHints,
+		func(status *common.StatusResponse) *pb.ComponentEncryptionHintsResponse {
+			return &pb.ComponentEncryptionHintsResponse{Status: status}
+		})
+}
+
+// serveComponents handles a server streaming request. The components are looked up (in parallel groups) and sent as soon
+// as they are resolved, followed by a final message containing the status of the whole request.
+func serveComponents[T any](c cryptographyStreamServer, request *common.ComponentsRequest, stream streamSender[T], name string,
+	lookup componentLookup[T], withStatus func(*common.StatusResponse) *T) error {
+	requestStartTime := time.Now() // Capture the scan start time
+	ctx := stream.Context()
+	s := ctxzap.Extract(ctx).Sugar()
+	s.Infof("Processing %s stream request...", name)
+	componentDTOS, errorResp := rejectIfInvalidComponents(ctx, s, request, withStatus)
+	if errorResp != nil {
+		return stream.Send(errorResp)
+	}
+	cs, err := newComponentStream(c, ctx, s, lookup, withStatus)
+	if err != nil {
+		return err
+	}
+	defer gd.CloseSQLConnection(cs.conn)
+	if err = cs.process(ctx, stream, componentDTOS); err != nil {
+		return err
+	}
+	if err = cs.finish(ctx, stream); err != nil {
+		return err
+	}
+	telemetryRequestTime(ctx, c.config, requestStartTime)
+	return nil
+}
+
+// serveComponentChunks handles a bidirectional streaming request. Every chunk of components received is processed
+// and streamed back straight away. Once the client closes its side, the status of the whole request is sent.
+func serveComponentChunks[T any](c cryptographyStreamServer, stream grpc.BidiStreamingServer[common.ComponentsRequest, T], name string,
+	lookup componentLookup[T], withStatus func(*common.StatusResponse) *T) error {
+	requestStartTime := time.Now() // Capture the scan start time
+	ctx := stream.Context()
+	s := ctxzap.Extract(ctx).Sugar()
+	s.Infof("Processing %s bidirectional stream request...", name)
+	cs, err := newComponentStream(c, ctx, s, lookup, withStatus)
+	if err != nil {
+		return err
+	}
+	defer gd.CloseSQLConnection(cs.conn)
+	for {
+		request, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		componentDTOS, err := convertComponentsRequestToComponentDTO(request)
+		if err != nil {
+			s.Warnf("Rejecting invalid chunk of %d component(s): %v", len(request.GetComponents()), err)
+			for _, component := range request.GetComponents() {
+				cs.summary.PurlsFailedToParse = append(cs.summary.PurlsFailedToParse, component.GetPurl())
+			}
+			cs.summary.TotalPurls += len(request.GetComponents())
+			continue
+		}
+		if err = cs.process(ctx, stream, componentDTOS); err != nil {
+			return err
+		}
+	}
+	if err = cs.finish(ctx, stream); err != nil {
+		return err
+	}
+	telemetryRequestTime(ctx, c.config, requestStartTime)
+	return nil
+}
+
+// newComponentStream reserves a database connection for the lifetime of the stream.
+func newComponentStream[T any](c cryptographyStreamServer, ctx context.Context, s *zap.SugaredLogger,
+	lookup componentLookup[T], withStatus func(*common.StatusResponse) *T) (*componentStream[T], error) {
+	conn, err := c.db.Connx(ctx) // Get a connection from the pool
+	if err != nil {
+		s.Errorf("Failed to get a database connection from the pool: %v", err)
+		return nil, status.Error(codes.Unavailable, "problem getting database pool connection")
+	}
+	return &componentStream[T]{s: s, db: c.db, conn: conn, config: c.config, lookup: lookup, withStatus: withStatus}, nil
+}
+
+// process looks up the components in groups, one component per DB worker so the group is resolved in parallel,
+// and sends the results of each group as soon as they are found.
+func (cs *componentStream[T]) process(ctx context.Context, stream streamSender[T], components []dtos.ComponentDTO) error {
+	size := max(cs.config.Database.Workers, 1)
+	for start := 0; start < len(components); start += size {
+		if err := ctx.Err(); err != nil {
+			cs.s.Warnf("Stream cancelled by the client: %v", err)
+			return status.FromContextError(err).Err()
+		}
+		group := components[start:min(start+size, len(components))]
+		results, summary, err := cs.lookup(ctx, cs.s, cs.db, cs.conn, cs.config, group)
+		if err != nil { // The KB failed to answer, so the remaining components (and the summary) cannot be trusted either
+			cs.s.Errorf("Failed to search components %v: %v", purlsOf(group), err)
+			return status.Errorf(codes.Internal, "problem searching components %v", purlsOf(group))
+		}
+		cs.summary.Merge(summary)
+		for _, result := range results {
+			if err = stream.Send(result); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// purlsOf lists the purls of the given components.
+func purlsOf(components []dtos.ComponentDTO) []string {
+	purls := make([]string, 0, len(components))
+	for _, component := range components {
+		purls = append(purls, component.Purl)
+	}
+	return purls
+}
+
+// finish sends the final message of the stream, carrying the status of the whole request.
+func (cs *componentStream[T]) finish(ctx context.Context, stream streamSender[T]) error {
+	return stream.Send(cs.withStatus(buildStatusResponse(ctx, cs.s, cs.summary, true)))
+}
+
+// lookupAlgorithms searches the cryptographic algorithms used by the given components.
+func lookupAlgorithms(ctx context.Context, s *zap.SugaredLogger, _ *sqlx.DB, conn *sqlx.Conn, config *myconfig.ServerConfig,
+	components []dtos.ComponentDTO) ([]*pb.ComponentAlgorithmsResponse, models.QuerySummary, error) {
+	results, summary, err := usecase.NewCrypto(ctx, s, conn, config).GetComponentsAlgorithms(components)
+	if err != nil || results.Cryptography == nil {
+		return nil, summary, err
+	}
+	response, err := convertCryptoOutputToComponents(s, results)
+	if err != nil {
+		return nil, summary, err
+	}
+	messages := make([]*pb.ComponentAlgorithmsResponse, 0, len(response.Components))
+	for _, component := range response.Components {
+		messages = append(messages, &pb.ComponentAlgorithmsResponse{Component: component})
+	}
+	return messages, summary, nil
+}
+
+// lookupAlgorithmsInRange searches the cryptographic algorithms used by the given component ranges.
+func lookupAlgorithmsInRange(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB, conn *sqlx.Conn, config *myconfig.ServerConfig,
+	components []dtos.ComponentDTO) ([]*pb.ComponentAlgorithmsInRangeResponse, models.QuerySummary, error) {
+	results, summary, err := usecase.NewCryptoMajor(ctx, s, db, conn, config).GetCryptoInRange(components)
+	if err != nil || results.Cryptography == nil {
+		return nil, summary, err
+	}
+	response, err := convertComponentsCryptoInRangeOutput(s, results)
+	if err != nil {
+		return nil, summary, err
+	}
+	messages := make([]*pb.ComponentAlgorithmsInRangeResponse, 0, len(response.Components))
+	for _, component := range response.Components {
+		messages = append(messages, &pb.ComponentAlgorithmsInRangeResponse{
+			Component: &pb.ComponentAlgorithmsInRangeResponse_Component{
+				Purl:       component.Purl,
+				Versions:   component.Versions,
+				Algorithms: component.Algorithms,
+			},
+		})
+	}
+	return messages, summary, nil
+}
+
+// lookupVersionsInRange searches the versions with/without cryptography of the given component ranges.
+func lookupVersionsInRange(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB, conn *sqlx.Conn, config *myconfig.ServerConfig,
+	components []dtos.ComponentDTO) ([]*pb.ComponentVersionsInRangeResponse, models.QuerySummary, error) {
+	results, summary, err := usecase.NewVersionsUsingCrypto(ctx, s, db, conn, config).GetVersionsInRangeUsingCrypto(components)
+	if err != nil || results.Versions == nil {
+		return nil, summary, err
+	}
+	response, err := convertToComponentsVersionInRangeOutput(s, results)
+	if err != nil {
+		return nil, summary, err
+	}
+	messages := make([]*pb.ComponentVersionsInRangeResponse, 0, len(response.Components))
+	for _, component := range response.Components {
+		messages = append(messages, &pb.ComponentVersionsInRangeResponse{
+			Component: &pb.ComponentVersionsInRangeResponse_Component{
+				Purl:            component.Purl,
+				VersionsWith:    component.VersionsWith,
+				VersionsWithout: component.VersionsWithout,
+			},
+		})
+	}
+	return messages, summary, nil
+}
+
+// lookupHintsInRange searches the encryption hints of the given component ranges.
+func lookupHintsInRange(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB, conn *sqlx.Conn, config *myconfig.ServerConfig,
+	components []dtos.ComponentDTO) ([]*pb.ComponentHintsInRangeResponse, models.QuerySummary, error) {
+	results, summary, err := usecase.NewECDetection(ctx, s, db, conn, config).FilterByTags(hintTagsFromContext(ctx)).GetDetectionsInRange(components)
+	if err != nil || results.Hints == nil {
+		return nil, summary, err
+	}
+	response, err := convertToComponentsHintsInRangeOutput(s, results)
+	if err != nil {
+		return nil, summary, err
+	}
+	messages := make([]*pb.ComponentHintsInRangeResponse, 0, len(response.Components))
+	for _, component := range response.Components {
+		messages = append(messages, &pb.ComponentHintsInRangeResponse{
+			Component: &pb.ComponentHintsInRangeResponse_Component{
+				Purl:     component.Purl,
+				Versions: component.Versions,
+				Hints:    component.Hints,
+			},
+		})
+	}
+	return messages, summary, nil
+}
+
+// lookupEncryptionHints searches the encryption hints of the given components.
+func lookupEncryptionHints(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB, conn *sqlx.Conn, config *myconfig.ServerConfig,
+	components []dtos.ComponentDTO) ([]*pb.ComponentEncryptionHintsResponse, models.QuerySummary, error) {
+	results, summary, err := usecase.NewECDetection(ctx, s, db, conn, config).FilterByTags(hintTagsFromContext(ctx)).GetDetections(components)
+	if err != nil || results.Hints == nil {
+		return nil, summary, err
+	}
+	response, err := convertEncryptionHintsToComponentsEncryptionOutput(results)
+	if err != nil {
+		return nil, summary, err
+	}
+	messages := make([]*pb.ComponentEncryptionHintsResponse, 0, len(response.Components))
+	for _, component := range response.Components {
+		messages = append(messages, &pb.ComponentEncryptionHintsResponse{Component: component})
+	}
+	return messages, summary, nil
+}
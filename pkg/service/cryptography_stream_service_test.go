@@ -0,0 +1,284 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	common "github.com/scanoss/papi/api/commonv2"
+	pb "github.com/scanoss/papi/api/cryptographyv2"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	_ "modernc.org/sqlite"
+	"scanoss.com/cryptography/pkg/api/streamv2"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+// setupStreamClient starts an in-memory gRPC server hosting the streaming service and returns a client connected to it.
+func setupStreamClient(t *testing.T) *streamv2.CryptographyStreamClient {
+	t.Helper()
+	db := openStreamDB(t)
+	if err := models.LoadTestSQLData(db, context.Background(), nil); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	return setupStreamClientWithDB(t, db)
+}
+
+// openStreamDB opens an empty in-memory database (and the logger) for the streaming service tests.
+func openStreamDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	t.Cleanup(zlog.SyncZap)
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	db.SetMaxOpenConns(1) // Keep a single in-memory database shared by every request
+	t.Cleanup(func() { models.CloseDB(db) })
+	return db
+}
+
+// setupStreamClientWithDB starts an in-memory gRPC server hosting the streaming service on the given database.
+func setupStreamClientWithDB(t *testing.T, db *sqlx.DB) *streamv2.CryptographyStreamClient {
+	t.Helper()
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	return setupStreamClientWithConfig(t, db, myConfig)
+}
+
+// setupStreamClientWithConfig starts an in-memory gRPC server hosting the streaming service on the given database and config.
+func setupStreamClientWithConfig(t *testing.T, db *sqlx.DB, myConfig *myconfig.ServerConfig) *streamv2.CryptographyStreamClient {
+	t.Helper()
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	streamv2.RegisterCryptographyStreamServer(server, NewCryptographyStreamServer(db, myConfig))
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to dial stream server: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return streamv2.NewCryptographyStreamClient(conn)
+}
+
+// receiveAll reads every message of a stream until the server closes it.
+func receiveAll[T any](t *testing.T, recv func() (*T, error)) []*T {
+	t.Helper()
+	var messages []*T
+	for {
+		msg, err := recv()
+		if errors.Is(err, io.EOF) {
+			return messages
+		}
+		if err != nil {
+			t.Fatalf("unexpected stream error: %v", err)
+		}
+		messages = append(messages, msg)
+	}
+}
+
+func TestCryptographyStreamServer_GetComponentsAlgorithmsStream(t *testing.T) {
+	client := setupStreamClient(t)
+	tests := []struct {
+		name           string
+		components     []*common.ComponentRequest
+		wantComponents int
+		wantStatus     common.StatusCode
+	}{
+		{
+			name:           "Should_Stream_Component_And_Status",
+			components:     []*common.ComponentRequest{{Purl: "pkg:github/scanoss/engine", Requirement: "v5.4.5"}},
+			wantComponents: 1,
+			wantStatus:     common.StatusCode_SUCCESS,
+		},
+		{
+			name: "Should_Stream_Found_Components_Only",
+			components: []*common.ComponentRequest{
+				{Purl: "pkg:github/scanoss/engine", Requirement: "v5.4.5"},
+				{Purl: "pkg:github/scanoss/engines", Requirement: "v5.4.5"},
+			},
+			wantComponents: 1,
+			wantStatus:     common.StatusCode_SUCCEEDED_WITH_WARNINGS,
+		},
+		{
+			name:       "Should_Return_Status_For_Invalid_Request",
+			components: []*common.ComponentRequest{{Purl: ""}},
+			wantStatus: common.StatusCode_FAILED,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := client.GetComponentsAlgorithmsStream(context.Background(), &common.ComponentsRequest{Components: tt.components})
+			if err != nil {
+				t.Fatalf("failed to open stream: %v", err)
+			}
+			messages := receiveAll(t, stream.Recv)
+			if len(messages) != tt.wantComponents+1 {
+				t.Fatalf("expected %d messages, got %d: %v", tt.wantComponents+1, len(messages), messages)
+			}
+			for _, msg := range messages[:tt.wantComponents] {
+				if msg.Component == nil || msg.Status != nil {
+					t.Errorf("expected a component message, got %v", msg)
+				}
+			}
+			last := messages[len(messages)-1]
+			if last.Component != nil || last.Status.GetStatus() != tt.wantStatus {
+				t.Errorf("expected final status %v, got %v", tt.wantStatus, last)
+			}
+		})
+	}
+}
+
+func TestCryptographyStreamServer_StreamComponentsAlgorithmsInRange(t *testing.T) {
+	client := setupStreamClient(t)
+	stream, err := client.StreamComponentsAlgorithmsInRange(context.Background())
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	chunks := []*common.ComponentsRequest{
+		{Components: []*common.ComponentRequest{{Purl: "pkg:github/scanoss/engine", Requirement: ">=v5.0.0"}}},
+		{Components: []*common.ComponentRequest{{Purl: ""}}},
+		{Components: []*common.ComponentRequest{{Purl: "pkg:github/scanoss/engines", Requirement: ">=v5.0.0"}}},
+	}
+	for _, chunk := range chunks {
+		if err = stream.Send(chunk); err != nil {
+			t.Fatalf("failed to send chunk: %v", err)
+		}
+	}
+	if err = stream.CloseSend(); err != nil {
+		t.Fatalf("failed to close stream: %v", err)
+	}
+	messages := receiveAll(t, stream.Recv)
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d: %v", len(messages), messages)
+	}
+	if messages[0].Component.GetPurl() != "pkg:github/scanoss/engine" {
+		t.Errorf("expected engine component first, got %v", messages[0])
+	}
+	if messages[1].Status.GetStatus() != common.StatusCode_SUCCEEDED_WITH_WARNINGS {
+		t.Errorf("expected final warning status, got %v", messages[1].Status)
+	}
+}
+
+func TestCryptographyStreamServer_WorkerPool(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	t.Cleanup(zlog.SyncZap)
+	// A file database, so the connections of every worker see the same data
+	db, err := sqlx.Connect("sqlite", filepath.Join(t.TempDir(), "kb.sqlite"))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database", err)
+	}
+	t.Cleanup(func() { models.CloseDB(db) })
+	if err = models.LoadTestSQLData(db, context.Background(), nil); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	myConfig.Database.Workers = 3
+	client := setupStreamClientWithConfig(t, db, myConfig)
+	var components []*common.ComponentRequest
+	for _, requirement := range []string{">=v5.0.0", ">=v5.4.0", "<v6.0.0", ">=v1.0.0"} {
+		components = append(components, &common.ComponentRequest{Purl: "pkg:github/scanoss/engine", Requirement: requirement})
+	}
+	components = append(components, &common.ComponentRequest{Purl: "pkg:github/scanoss/engines", Requirement: ">=v5.0.0"})
+	stream, err := client.GetComponentsAlgorithmsInRangeStream(context.Background(), &common.ComponentsRequest{Components: components})
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	messages := receiveAll(t, stream.Recv)
+	if len(messages) != 5 {
+		t.Fatalf("expected 5 messages, got %d: %v", len(messages), messages)
+	}
+	for _, msg := range messages[:4] {
+		if msg.Component.GetPurl() != "pkg:github/scanoss/engine" || len(msg.Component.GetAlgorithms()) == 0 {
+			t.Errorf("expected an engine component with algorithms, got %v", msg)
+		}
+	}
+	if messages[4].Status.GetStatus() != common.StatusCode_SUCCEEDED_WITH_WARNINGS {
+		t.Errorf("expected final warning status, got %v", messages[4].Status)
+	}
+}
+
+func TestCryptographyStreamServer_Cancelled(t *testing.T) {
+	client := setupStreamClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	stream, err := client.GetComponentsEncryptionHintsStream(ctx, &common.ComponentsRequest{
+		Components: []*common.ComponentRequest{{Purl: "pkg:github/scanoss/engine"}},
+	})
+	if err == nil {
+		var msg *pb.ComponentEncryptionHintsResponse
+		msg, err = stream.Recv()
+		if err == nil {
+			t.Errorf("expected cancelled stream, got %v", msg)
+		}
+	}
+}
+
+func TestCryptographyStreamServer_LookupFailure(t *testing.T) {
+	db := openStreamDB(t)
+	if err := models.LoadTestSQLData(db, context.Background(), nil); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	// The component URLs are still found, but searching their algorithms fails
+	if err := models.RunTestSQL(db, context.Background(), nil, "DROP TABLE component_crypto;"); err != nil {
+		t.Fatalf("failed to drop the component_crypto table: %v", err)
+	}
+	client := setupStreamClientWithDB(t, db)
+	stream, err := client.GetComponentsAlgorithmsStream(context.Background(),
+		&common.ComponentsRequest{Components: []*common.ComponentRequest{{Purl: "pkg:github/scanoss/engine", Requirement: "v5.4.5"}}})
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	for {
+		msg, err := stream.Recv()
+		if err == nil {
+			if msg.Status != nil {
+				t.Fatalf("expected the stream to fail, got final status %v", msg.Status)
+			}
+			continue
+		}
+		if status.Code(err) != codes.Internal {
+			t.Errorf("expected an %v stream error, got %v", codes.Internal, err)
+		}
+		return
+	}
+}
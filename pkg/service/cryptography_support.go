@@ -33,6 +33,10 @@ import (
 // Structure for storing OTEL metrics.
 type metricsCounters struct {
 	cryptoAlgorithmsHistogram metric.Int64Histogram // milliseconds
+	purlsRequested            metric.Int64Counter
+	purlsNotFound             metric.Int64Counter
+	purlsWOInfo               metric.Int64Counter
+	purlsFailedToParse        metric.Int64Counter
 }
 
 var oltpMetrics = metricsCounters{}
@@ -41,6 +45,10 @@ var oltpMetrics = metricsCounters{}
 func setupMetrics() {
 	meter := otel.Meter("scanoss.com/cryptography")
 	oltpMetrics.cryptoAlgorithmsHistogram, _ = meter.Int64Histogram("crypto.algorithms.req_time", metric.WithDescription("The time taken to run a crypto algorithms request (ms)"))
+	oltpMetrics.purlsRequested, _ = meter.Int64Counter("crypto.purls.requested", metric.WithDescription("The number of purls requested"))
+	oltpMetrics.purlsNotFound, _ = meter.Int64Counter("crypto.purls.not_found", metric.WithDescription("The number of purls requested that are not in the KB"))
+	oltpMetrics.purlsWOInfo, _ = meter.Int64Counter("crypto.purls.without_info", metric.WithDescription("The number of purls requested without cryptographic information"))
+	oltpMetrics.purlsFailedToParse, _ = meter.Int64Counter("crypto.purls.failed_to_parse", metric.WithDescription("The number of purls requested that could not be parsed"))
 }
 
 // convertPurlRequestInput converts a Purl Request structure into an internal Crypto Input struct. TODO: Remove this method when legacy request be removed.
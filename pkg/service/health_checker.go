@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	pb "github.com/scanoss/papi/api/cryptographyv2"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"scanoss.com/cryptography/pkg/api/packagesv2"
+	"scanoss.com/cryptography/pkg/api/streamv2"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/usecase"
+)
+
+// HealthChecker runs the KB health checks in the background and publishes the results on the standard gRPC health service.
+// Until the first round of checks completes, every service is reported as not serving.
+type HealthChecker struct {
+	db       *sqlx.DB
+	config   *myconfig.ServerConfig
+	services []string
+	server   *health.Server
+	mu       sync.RWMutex
+	last     dtos.HealthOutput
+}
+
+// NewHealthChecker creates a new instance of the Health Checker.
+// The KB backed services are reported individually, and the overall server health under the empty service name.
+func NewHealthChecker(db *sqlx.DB, config *myconfig.ServerConfig) *HealthChecker {
+	services := []string{"", pb.Cryptography_ServiceDesc.ServiceName, streamv2.ServiceName, packagesv2.ServiceName}
+	c := &HealthChecker{db: db, config: config, services: services, server: health.NewServer()}
+	c.setServingStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	return c
+}
+
+// HealthServer returns the gRPC health service reflecting the latest KB health checks.
+func (c *HealthChecker) HealthServer() healthpb.HealthServer {
+	return c.server
+}
+
+// HealthReport returns the results of the latest KB health checks.
+func (c *HealthChecker) HealthReport() dtos.HealthOutput {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.last
+}
+
+// Check runs a round of KB health checks (within the configured timeout) and updates the serving status with the results.
+func (c *HealthChecker) Check(ctx context.Context) dtos.HealthOutput {
+	if c.config.Health.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(c.config.Health.Timeout)*time.Second)
+		defer cancel()
+	}
+	output := usecase.NewKBHealth(ctx, zlog.S, c.db).CheckHealth()
+	c.mu.Lock()
+	previous := c.last
+	c.last = output
+	c.mu.Unlock()
+	if output.Ready != previous.Ready || len(previous.CheckedAt) == 0 {
+		if output.Ready {
+			zlog.S.Info("KB health checks passed. Service ready")
+		} else {
+			zlog.S.Warnf("KB health checks failed. Service not ready: %+v", output.Checks)
+		}
+	}
+	if output.Ready {
+		c.setServingStatus(healthpb.HealthCheckResponse_SERVING)
+	} else {
+		c.setServingStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	return output
+}
+
+// Run repeats the KB health checks at the configured interval until the context is cancelled.
+// Nothing is run if no interval is configured.
+func (c *HealthChecker) Run(ctx context.Context) {
+	if c.config.Health.Interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(time.Duration(c.config.Health.Interval) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Check(ctx)
+		}
+	}
+}
+
+// setServingStatus sets the status of all the services reported by the health service.
+func (c *HealthChecker) setServingStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	for _, service := range c.services {
+		c.server.SetServingStatus(service, status)
+	}
+}
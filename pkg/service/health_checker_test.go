@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	pb "github.com/scanoss/papi/api/cryptographyv2"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	_ "modernc.org/sqlite"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+func TestHealthChecker(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := context.Background()
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	db.SetMaxOpenConns(1) // Keep a single in-memory database shared by every check
+	defer models.CloseDB(db)
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	checker := NewHealthChecker(db, myConfig)
+	servingStatus := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := checker.HealthServer().Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("Check(%v) unexpected error: %v", service, err)
+		}
+		return resp.GetStatus()
+	}
+	if got := servingStatus(""); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("status before the first check = %v, want NOT_SERVING", got)
+	}
+	// An empty KB is reachable, but not ready
+	report := checker.Check(ctx)
+	if !report.Live || report.Ready || len(report.Checks) != 3 || checker.HealthReport().CheckedAt != report.CheckedAt {
+		t.Errorf("Check() on an empty KB = %+v", report)
+	}
+	if err = models.LoadTestSQLData(db, ctx, nil); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	if report = checker.Check(ctx); !report.Live || !report.Ready {
+		t.Errorf("Check() on the test KB = %+v", report)
+	}
+	for _, service := range []string{"", pb.Cryptography_ServiceDesc.ServiceName} {
+		if got := servingStatus(service); got != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("status of '%v' = %v, want SERVING", service, got)
+		}
+	}
+	// No background checks are run without an interval
+	myConfig.Health.Interval = 0
+	done := make(chan struct{})
+	go func() {
+		checker.Run(ctx)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Errorf("Run() did not return without an interval")
+	}
+}
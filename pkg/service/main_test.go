@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package service
+
+import (
+	"os"
+	"testing"
+)
+
+// TestMain limits every config loaded by this package's tests to a single DB worker.
+// In-memory SQLite databases cannot be shared across connections, so extra workers would query an empty database.
+func TestMain(m *testing.M) {
+	if err := os.Setenv("DB_WORKERS", "1"); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
@@ -3,14 +3,21 @@ package service
 
 import (
 	"context"
+	"strings"
 
 	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+	"scanoss.com/cryptography/pkg/models"
 	"scanoss.com/cryptography/pkg/protocol/rest"
 
 	common "github.com/scanoss/papi/api/commonv2"
 	"scanoss.com/cryptography/pkg/dtos"
 )
 
+// HintTagsMetadataKey is the request metadata holding the (comma separated) tags to filter the hints by.
+// Over the REST gateway, it is supplied in the "Grpc-Metadata-X-Hint-Tags" header.
+const HintTagsMetadataKey = "x-hint-tags"
+
 // rejectIfInvalidComponents processes multiple components requests with generic response handling.
 // It converts the request to ComponentDTO format and handles errors appropriately.
 // Returns the converted DTOs, the response (if error occurred), and any error.
@@ -43,3 +50,13 @@ func rejectIfInvalid[T any](ctx context.Context, s *zap.SugaredLogger, request *
 	}
 	return zero
 }
+
+// hintTagsFromContext returns the tags requested to filter the hints by, if any.
+func hintTagsFromContext(ctx context.Context) []string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var tags []string
+	for _, value := range md.Get(HintTagsMetadataKey) {
+		tags = append(tags, strings.Split(value, ",")...)
+	}
+	return models.NormalizeTags(tags)
+}
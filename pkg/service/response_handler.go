@@ -10,6 +10,8 @@ import (
 	"scanoss.com/cryptography/pkg/protocol/rest"
 
 	common "github.com/scanoss/papi/api/commonv2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
@@ -99,6 +101,7 @@ func buildStatusResponse(ctx context.Context, s *zap.SugaredLogger, summary mode
 	}
 	status, httpStatusCode := determineStatusAndHTTPCode(s, summary, isBatchResponse)
 	setHTTPCodeOnTrailer(ctx, s, httpStatusCode)
+	telemetryPurlCounts(ctx, summary)
 	statusResp.Status = status
 	return &statusResp
 }
@@ -113,13 +116,32 @@ func setHTTPCodeOnTrailer(ctx context.Context, s *zap.SugaredLogger, code string
 }
 
 // telemetryRequestTime records the crypto algorithms request time to telemetry.
+// The per RPC and API key breakdown is left to the crypto.rpc.req_time histogram of the gRPC interceptor.
 func telemetryRequestTime(ctx context.Context, config *myconfig.ServerConfig, requestStartTime time.Time) {
-	if config.Telemetry.Enabled {
+	if config.Telemetry.Enabled || config.Telemetry.PrometheusEnabled {
 		elapsedTime := time.Since(requestStartTime).Milliseconds()     // Time taken to run the component name request
 		oltpMetrics.cryptoAlgorithmsHistogram.Record(ctx, elapsedTime) // Record algorithm request time
 	}
 }
 
+// telemetryPurlCounts adds the number of purls requested, and of those that could not be answered, to telemetry.
+func telemetryPurlCounts(ctx context.Context, summary models.QuerySummary) {
+	if oltpMetrics.purlsRequested == nil {
+		return
+	}
+	opts := metric.WithAttributes(attribute.String("rpc", rpcName(ctx)))
+	oltpMetrics.purlsRequested.Add(ctx, int64(summary.TotalPurls), opts)
+	oltpMetrics.purlsNotFound.Add(ctx, int64(len(summary.PurlsNotFound)), opts)
+	oltpMetrics.purlsWOInfo.Add(ctx, int64(len(summary.PurlsWOInfo)), opts)
+	oltpMetrics.purlsFailedToParse.Add(ctx, int64(len(summary.PurlsFailedToParse)), opts)
+}
+
+// rpcName returns the name of the RPC being served (i.e. Cryptography/GetComponentsAlgorithms).
+func rpcName(ctx context.Context) string {
+	method, _ := grpc.Method(ctx) // i.e. /scanoss.api.cryptography.v2.Cryptography/GetComponentsAlgorithms
+	return method[strings.LastIndex(method, ".")+1:]
+}
+
 // resolveResponseStatus safely extracts status from a response interface, providing a fallback
 // when the response is nil. This prevents nil pointer dereferences in error handling.
 // This method is mainly used by single component calls that delegate to batch calls and need
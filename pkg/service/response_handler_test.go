@@ -18,11 +18,16 @@ package service
 
 import (
 	"context"
+	"testing"
+
 	common "github.com/scanoss/papi/api/commonv2"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 	"scanoss.com/cryptography/pkg/models"
-	"testing"
 )
 
 func Test_buildErrorMessages(t *testing.T) {
@@ -185,7 +190,7 @@ func Test_determineStatusAndHTTPCode(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotStatus, gotHTTPCode := determineStatusAndHTTPCode(sugar, tt.summary)
+			gotStatus, gotHTTPCode := determineStatusAndHTTPCode(sugar, tt.summary, false)
 
 			if gotStatus != tt.wantStatus {
 				t.Errorf("determineStatusAndHTTPCode() status = %v, want %v", gotStatus, tt.wantStatus)
@@ -245,7 +250,7 @@ func Test_buildStatusResponse(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
-			got := buildStatusResponse(ctx, sugar, tt.summary)
+			got := buildStatusResponse(ctx, sugar, tt.summary, false)
 
 			if got.Status != tt.wantStatus {
 				t.Errorf("buildStatusResponse() status = %v, want %v", got.Status, tt.wantStatus)
@@ -293,3 +298,55 @@ func Test_setHTTPCodeOnTrailer(t *testing.T) {
 		})
 	}
 }
+
+// stubTransportStream provides the method name of a server call, ignoring any headers/trailers set.
+type stubTransportStream struct {
+	method string
+}
+
+func (s stubTransportStream) Method() string               { return s.method }
+func (s stubTransportStream) SetHeader(metadata.MD) error  { return nil }
+func (s stubTransportStream) SendHeader(metadata.MD) error { return nil }
+func (s stubTransportStream) SetTrailer(metadata.MD) error { return nil }
+
+func Test_telemetryPurlCounts(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	previous := otel.GetMeterProvider()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	setupMetrics()
+	defer func() {
+		otel.SetMeterProvider(previous)
+		setupMetrics()
+	}()
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(),
+		stubTransportStream{method: "/scanoss.api.cryptography.v2.Cryptography/GetComponentsAlgorithms"})
+	summary := models.QuerySummary{TotalPurls: 5, PurlsFailedToParse: []string{"invalid-purl"},
+		PurlsNotFound: []string{"pkg:npm/missing", "pkg:npm/gone"}, PurlsWOInfo: []string{}}
+	telemetryPurlCounts(ctx, summary)
+	telemetryPurlCounts(ctx, summary)
+
+	var data metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &data); err != nil {
+		t.Fatalf("failed to collect the metrics: %v", err)
+	}
+	want := map[string]int64{"crypto.purls.requested": 10, "crypto.purls.not_found": 4, "crypto.purls.without_info": 0,
+		"crypto.purls.failed_to_parse": 2}
+	got := make(map[string]int64)
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok {
+				for _, point := range sum.DataPoints {
+					if rpc, _ := point.Attributes.Value("rpc"); rpc.AsString() != "Cryptography/GetComponentsAlgorithms" {
+						t.Errorf("%v recorded for rpc '%v'", m.Name, rpc.AsString())
+					}
+					got[m.Name] += point.Value
+				}
+			}
+		}
+	}
+	for name, count := range want {
+		if got[name] != count {
+			t.Errorf("%v = %d, want %d", name, got[name], count)
+		}
+	}
+}
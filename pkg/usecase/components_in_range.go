@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+// componentInRange tracks a validated component of a range request while its batch is being resolved.
+type componentInRange struct {
+	index     int                  // Position of the component inside its batch
+	component dtos.ComponentDTO    // Component as requested
+	key       models.PurlNameType  // Purl Name/Type to search
+	summary   *models.QuerySummary // Summary of this component
+	urls      []models.AllURL      // URLs of the versions inside the requested range
+	err       error                // Set if the URLs could not be filtered by the requested range
+}
+
+// fetchUrlsInRange searches the URLs of all the given components with a single query (per chunk of purls)
+// and keeps, for each component, those inside its requested range. An error is returned if the URLs cannot be searched.
+func fetchUrlsInRange(s *zap.SugaredLogger, allUrls *models.AllUrlsModel, components []*componentInRange) error {
+	if len(components) == 0 {
+		return nil
+	}
+	keys := make([]models.PurlNameType, 0, len(components))
+	for _, c := range components {
+		keys = append(keys, c.key)
+	}
+	urlsByPurl, err := allUrls.GetUrlsByPurlNames(keys)
+	if err != nil {
+		s.Warnf("Failed to get list of urls for %d purls: %v", len(keys), err)
+		return fmt.Errorf("failed to get the urls of %d purls: %v", len(keys), err)
+	}
+	for _, c := range components {
+		c.urls, c.err = models.FilterUrlsInRange(s, urlsByPurl[c.key], c.key.Name, c.key.Type, c.component.Requirement, c.summary)
+	}
+	return nil
+}
+
+// collectURLHashesInRange returns the distinct URL hashes found for all the given components.
+func collectURLHashesInRange(components []*componentInRange) []string {
+	seen := make(map[string]bool)
+	var hashes []string
+	for _, c := range components {
+		for _, u := range c.urls {
+			if u.URLHash != "" && !seen[u.URLHash] {
+				seen[u.URLHash] = true
+				hashes = append(hashes, u.URLHash)
+			}
+		}
+	}
+	return hashes
+}
+
+// groupByURLHash indexes a list of usages by their URL hash.
+func groupByURLHash[T any](usages []T, hash func(T) string) map[string][]T {
+	grouped := make(map[string][]T)
+	for _, u := range usages {
+		grouped[hash(u)] = append(grouped[hash(u)], u)
+	}
+	return grouped
+}
+
+// usagesOf returns the usages of each distinct URL hash of a component, in URL order.
+func usagesOf[T any](urls []models.AllURL, usages map[string][]T) []T {
+	seen := make(map[string]bool)
+	var result []T
+	for _, u := range urls {
+		if !seen[u.URLHash] {
+			seen[u.URLHash] = true
+			result = append(result, usages[u.URLHash]...)
+		}
+	}
+	return result
+}
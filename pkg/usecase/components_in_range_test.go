@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+func TestRangeUseCasesKBFailure(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	components := []dtos.ComponentDTO{{Purl: "pkg:github/scanoss/engine", Requirement: ">5.3.0"}}
+	// KB query failures fail the request, rather than reporting the purls as not found or without info
+	for _, table := range []string{"all_urls", "component_crypto"} {
+		t.Run(table, func(t *testing.T) {
+			db, err := sqlx.Connect("sqlite", ":memory:")
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer models.CloseDB(db)
+			conn, err := db.Connx(ctx) // Get a connection from the pool
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer models.CloseConn(conn)
+			if err = models.LoadTestSQLData(db, ctx, conn); err != nil {
+				t.Fatalf("failed to load SQL test data: %v", err)
+			}
+			if err = models.RunTestSQL(db, ctx, conn, "DROP TABLE "+table+";"); err != nil {
+				t.Fatalf("failed to drop the %v table: %v", table, err)
+			}
+			if _, summary, err := NewCryptoMajor(ctx, s, nil, conn, myConfig).GetCryptoInRange(components); err == nil {
+				t.Errorf("GetCryptoInRange() expected an error, got summary %+v", summary)
+			}
+			if _, summary, err := NewVersionsUsingCrypto(ctx, s, nil, conn, myConfig).GetVersionsInRangeUsingCrypto(components); err == nil {
+				t.Errorf("GetVersionsInRangeUsingCrypto() expected an error, got summary %+v", summary)
+			}
+		})
+	}
+}
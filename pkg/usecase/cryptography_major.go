@@ -27,6 +27,7 @@ import (
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 	myconfig "scanoss.com/cryptography/pkg/config"
 
@@ -40,96 +41,146 @@ type CryptoMajorUseCase struct {
 	ctx         context.Context
 	s           *zap.SugaredLogger
 	conn        *sqlx.Conn
+	config      *myconfig.ServerConfig
+	pool        workerPool
 	allUrls     *models.AllUrlsModel
 	cryptoUsage *models.CryptoUsageModel
 }
 
-func NewCryptoMajor(ctx context.Context, s *zap.SugaredLogger, conn *sqlx.Conn, config *myconfig.ServerConfig) *CryptoMajorUseCase {
-	return &CryptoMajorUseCase{ctx: ctx, s: s, conn: conn,
+// NewCryptoMajor creates a new instance of the Crypto in range use case.
+// The optional db pool is used to process the requested components concurrently, otherwise they are processed on conn.
+func NewCryptoMajor(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB, conn *sqlx.Conn, config *myconfig.ServerConfig) *CryptoMajorUseCase {
+	return &CryptoMajorUseCase{ctx: ctx, s: s, conn: conn, config: config,
+		pool:        newWorkerPool(s, db, conn, config.Database.Workers),
 		allUrls:     models.NewAllURLModel(ctx, s, database.NewDBSelectContext(s, nil, conn, config.Database.Trace)),
 		cryptoUsage: models.NewCryptoUsageModel(ctx, s, database.NewDBSelectContext(s, nil, conn, config.Database.Trace)),
 	}
 }
 
+// withConn returns a copy of the use case whose models run on the given connection.
+func (d CryptoMajorUseCase) withConn(ctx context.Context, conn *sqlx.Conn) CryptoMajorUseCase {
+	d.ctx = ctx
+	d.conn = conn
+	d.allUrls = models.NewAllURLModel(ctx, d.s, database.NewDBSelectContext(d.s, nil, conn, d.config.Database.Trace))
+	d.cryptoUsage = models.NewCryptoUsageModel(ctx, d.s, database.NewDBSelectContext(d.s, nil, conn, d.config.Database.Trace))
+	return d
+}
+
 // GetCryptoInRange takes the Crypto Input request, searches for Cryptographic usages and returns a CryptoOutput struct.
 func (d CryptoMajorUseCase) GetCryptoInRange(components []dtos.ComponentDTO) (dtos.CryptoInRangeOutput, models.QuerySummary, error) {
 	if len(components) == 0 {
 		d.s.Info("Empty List of Purls supplied")
 		return dtos.CryptoInRangeOutput{}, models.QuerySummary{}, errors.New("empty list of purls")
 	}
-	out := dtos.CryptoInRangeOutput{}
-	summary := models.QuerySummary{}
+	ctx, span := startSpan(d.ctx, "CryptoMajorUseCase.GetCryptoInRange", attribute.Int("purls", len(components)))
+	items, summary, err := processComponents(ctx, d.pool, components,
+		func(ctx context.Context, conn *sqlx.Conn, batch []dtos.ComponentDTO) ([]componentResult[dtos.CryptoInRangeOutputItem], error) {
+			return d.withConn(ctx, conn).processBatch(batch)
+		})
+	endSpan(span, summary, err)
+	if err != nil {
+		return dtos.CryptoInRangeOutput{}, models.QuerySummary{}, err
+	}
 	summary.TotalPurls = len(components)
-	// Prepare purls to query
-	for _, c := range components {
-		purl, err := purlhelper.PurlFromString(c.Purl)
+	return dtos.CryptoInRangeOutput{Cryptography: items}, summary, nil
+}
+
+// processBatch searches the algorithms used by all the versions in range of a batch of components.
+// The URLs of every component are fetched in one query, and the algorithms of all their hashes in another.
+// KB query failures are returned, rather than reporting the components as not found or without info.
+func (d CryptoMajorUseCase) processBatch(components []dtos.ComponentDTO) ([]componentResult[dtos.CryptoInRangeOutputItem], error) {
+	results := make([]componentResult[dtos.CryptoInRangeOutputItem], len(components))
+	queries := make([]*componentInRange, 0, len(components))
+	for i, c := range components {
+		query, err := d.prepareComponent(i, c, &results[i].summary)
 		if err != nil {
-			d.s.Errorf("Failed to parse purl '%s': %s", c.Purl, err)
-			summary.PurlsFailedToParse = append(summary.PurlsFailedToParse, c.Purl)
-			continue
+			return nil, err
 		}
-		if c.Requirement == "*" || strings.HasPrefix(c.Requirement, "v*") {
-			return dtos.CryptoInRangeOutput{}, models.QuerySummary{}, errors.New("requirement should include version range or major and wildcard")
-		}
-
-		if c.Requirement != "" {
-			if !utils.IsValidRequirement(c.Requirement) {
-				summary.PurlsFailedToParse = append(summary.PurlsFailedToParse, fmt.Sprintf("purl: %s , requirement: %s", c.Purl, c.Requirement))
-				continue
-			}
+		if query != nil {
+			queries = append(queries, query)
 		}
-
-		purlName, err := purlhelper.PurlNameFromString(c.Purl) // Make sure we just have the bare minimum for a Purl Name
+	}
+	if err := fetchUrlsInRange(d.s, d.allUrls, queries); err != nil {
+		return nil, err
+	}
+	usages := make(map[string][]models.CryptoUsage)
+	if hashes := collectURLHashesInRange(queries); len(hashes) > 0 {
+		uses, err := d.cryptoUsage.GetCryptoUsageByURLHashes(hashes)
 		if err != nil {
-			d.s.Errorf("Failed to parse purl '%s': %s", c.Purl, err)
-			summary.PurlsFailedToParse = append(summary.PurlsFailedToParse, c.Purl)
-			continue
+			d.s.Errorf("error getting algorithms usage for %d purls: %s", len(queries), err)
+			return nil, fmt.Errorf("failed to get the algorithms of %d purls: %v", len(queries), err)
 		}
-		res, errQ := d.allUrls.GetUrlsByPurlNameTypeInRange(purlName, purl.Type, c.Requirement, &summary)
-		if len(res) == 0 {
-			summary.PurlsNotFound = append(summary.PurlsNotFound, purlName)
+		usages = groupByURLHash(uses, func(u models.CryptoUsage) string { return u.URLHash })
+	}
+	for _, q := range queries {
+		r := &results[q.index]
+		if len(q.urls) == 0 {
+			r.summary.PurlsNotFound = append(r.summary.PurlsNotFound, q.key.Name)
 			continue
 		}
-		_ = errQ
-		item := dtos.CryptoInRangeOutputItem{Purl: c.Purl, Versions: []string{}}
-		var hashes []string
-		nonDupVersions := make(map[string]bool)
-
-		mapVersionHash := make(map[string]string)
-		for _, url := range res {
-			hashes = append(hashes, url.URLHash)
-			mapVersionHash[url.URLHash] = url.SemVer
-		}
-		uses, err1 := d.cryptoUsage.GetCryptoUsageByURLHashes(hashes)
-		if err1 != nil {
-			d.s.Errorf("error getting algorithms usage for purl '%s': %s", c.Purl, err)
-		}
-		// avoid duplicate algorithms
-		fmt.Printf("USES %v", uses)
-		nonDupAlgorithms := make(map[models.CryptoItem]bool)
-		for _, alg := range uses {
-			nonDupVersions[mapVersionHash[alg.URLHash]] = true
-			if _, exist := nonDupAlgorithms[models.CryptoItem{Algorithm: alg.Algorithm, Strength: alg.Strength}]; !exist {
-				nonDupAlgorithms[models.CryptoItem{Algorithm: alg.Algorithm, Strength: alg.Strength}] = true
-				item.Algorithms = append(item.Algorithms, dtos.CryptoUsageItem{Algorithm: alg.Algorithm, Strength: alg.Strength})
-			}
-		}
-		for k := range nonDupVersions {
-			item.Versions = append(item.Versions, k)
-		}
+		r.item, r.ok = d.buildItem(q, usagesOf(q.urls, usages)), true
+	}
+	return results, nil
+}
 
-		sort.Slice(item.Versions, func(i, j int) bool {
-			versionA, _ := semver.NewVersion(item.Versions[i])
-			versionB, _ := semver.NewVersion(item.Versions[j])
+// prepareComponent validates a component of the request, returning nil if it cannot be searched.
+func (d CryptoMajorUseCase) prepareComponent(index int, c dtos.ComponentDTO, summary *models.QuerySummary) (*componentInRange, error) {
+	purl, err := purlhelper.PurlFromString(c.Purl)
+	if err != nil {
+		d.s.Errorf("Failed to parse purl '%s': %s", c.Purl, err)
+		summary.PurlsFailedToParse = append(summary.PurlsFailedToParse, c.Purl)
+		return nil, nil
+	}
+	if c.Requirement == "*" || strings.HasPrefix(c.Requirement, "v*") {
+		return nil, errors.New("requirement should include version range or major and wildcard")
+	}
 
-			return versionA.LessThan(versionB)
-		})
+	if c.Requirement != "" {
+		if !utils.IsValidRequirement(c.Requirement) {
+			summary.PurlsFailedToParse = append(summary.PurlsFailedToParse, fmt.Sprintf("purl: %s , requirement: %s", c.Purl, c.Requirement))
+			return nil, nil
+		}
+	}
 
-		if len(uses) == 0 {
-			summary.PurlsWOInfo = append(summary.PurlsWOInfo, c.Purl)
+	purlName, err := purlhelper.PurlNameFromString(c.Purl) // Make sure we just have the bare minimum for a Purl Name
+	if err != nil {
+		d.s.Errorf("Failed to parse purl '%s': %s", c.Purl, err)
+		summary.PurlsFailedToParse = append(summary.PurlsFailedToParse, c.Purl)
+		return nil, nil
+	}
+	return &componentInRange{index: index, component: c, key: models.PurlNameType{Name: purlName, Type: purl.Type}, summary: summary}, nil
+}
+
+// buildItem lists the distinct algorithms used by a component and the versions in which they were found.
+func (d CryptoMajorUseCase) buildItem(q *componentInRange, uses []models.CryptoUsage) dtos.CryptoInRangeOutputItem {
+	item := dtos.CryptoInRangeOutputItem{Purl: q.component.Purl, Versions: []string{}}
+	nonDupVersions := make(map[string]bool)
+	mapVersionHash := make(map[string]string)
+	for _, url := range q.urls {
+		mapVersionHash[url.URLHash] = url.SemVer
+	}
+	// avoid duplicate algorithms
+	nonDupAlgorithms := make(map[models.CryptoItem]bool)
+	for _, alg := range uses {
+		nonDupVersions[mapVersionHash[alg.URLHash]] = true
+		if _, exist := nonDupAlgorithms[models.CryptoItem{Algorithm: alg.Algorithm, Strength: alg.Strength}]; !exist {
+			nonDupAlgorithms[models.CryptoItem{Algorithm: alg.Algorithm, Strength: alg.Strength}] = true
+			item.Algorithms = append(item.Algorithms, dtos.CryptoUsageItem{Algorithm: alg.Algorithm, Strength: alg.Strength})
 		}
+	}
+	for k := range nonDupVersions {
+		item.Versions = append(item.Versions, k)
+	}
+
+	sort.Slice(item.Versions, func(i, j int) bool {
+		versionA, _ := semver.NewVersion(item.Versions[i])
+		versionB, _ := semver.NewVersion(item.Versions[j])
+
+		return versionA.LessThan(versionB)
+	})
 
-		out.Cryptography = append(out.Cryptography, item)
+	if len(uses) == 0 {
+		q.summary.PurlsWOInfo = append(q.summary.PurlsWOInfo, q.component.Purl)
 	}
-	return out, summary, nil
+	return item
 }
@@ -63,7 +63,7 @@ func TestAlgorithmsInRangeUseCase(t *testing.T) {
 			Requirement: ">5.3.0",
 		},
 	}
-	cryptoUc := NewCryptoMajor(ctx, s, conn, myConfig)
+	cryptoUc := NewCryptoMajor(ctx, s, nil, conn, myConfig)
 	algorithms, summary, err := cryptoUc.GetCryptoInRange(componentDTOS)
 	if err != nil {
 		t.Fatalf("the error '%v' was not expected when getting cryptography", err)
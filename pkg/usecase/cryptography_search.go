@@ -25,6 +25,7 @@ import (
 	"github.com/package-url/packageurl-go"
 	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
 	purlhelper "github.com/scanoss/go-purl-helper/pkg"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 	myconfig "scanoss.com/cryptography/pkg/config"
 	"scanoss.com/cryptography/pkg/dtos"
@@ -36,14 +37,10 @@ type CryptoUseCase struct {
 	ctx         context.Context
 	s           *zap.SugaredLogger
 	conn        *sqlx.Conn
+	config      *myconfig.ServerConfig
 	allUrls     *models.AllUrlsModel
 	cryptoUsage *models.CryptoUsageModel
 }
-type CryptoWorkerStruct struct {
-	URLMd5  string
-	Purl    string
-	Version string
-}
 type InternalQuery struct {
 	CompletePurl    string
 	PurlName        string
@@ -53,14 +50,30 @@ type InternalQuery struct {
 }
 
 func NewCrypto(ctx context.Context, s *zap.SugaredLogger, conn *sqlx.Conn, config *myconfig.ServerConfig) *CryptoUseCase {
-	return &CryptoUseCase{ctx: ctx, s: s, conn: conn,
+	return &CryptoUseCase{ctx: ctx, s: s, conn: conn, config: config,
 		allUrls:     models.NewAllURLModel(ctx, s, database.NewDBSelectContext(s, nil, conn, config.Database.Trace)),
 		cryptoUsage: models.NewCryptoUsageModel(ctx, s, database.NewDBSelectContext(s, nil, conn, config.Database.Trace)),
 	}
 }
 
+// withContext returns a copy of the use case whose models run with the given context.
+func (d CryptoUseCase) withContext(ctx context.Context) CryptoUseCase {
+	d.ctx = ctx
+	d.allUrls = models.NewAllURLModel(ctx, d.s, database.NewDBSelectContext(d.s, nil, d.conn, d.config.Database.Trace))
+	d.cryptoUsage = models.NewCryptoUsageModel(ctx, d.s, database.NewDBSelectContext(d.s, nil, d.conn, d.config.Database.Trace))
+	return d
+}
+
 // GetComponentsAlgorithms takes a list of ComponentDTO objects, searches for cryptographic usages and returns a CryptoOutput struct.
 func (d CryptoUseCase) GetComponentsAlgorithms(components []dtos.ComponentDTO) (dtos.CryptoOutput, models.QuerySummary, error) {
+	ctx, span := startSpan(d.ctx, "CryptoUseCase.GetComponentsAlgorithms", attribute.Int("purls", len(components)))
+	output, summary, err := d.withContext(ctx).getComponentsAlgorithms(components)
+	endSpan(span, summary, err)
+	return output, summary, err
+}
+
+// getComponentsAlgorithms runs the algorithms search of GetComponentsAlgorithms.
+func (d CryptoUseCase) getComponentsAlgorithms(components []dtos.ComponentDTO) (dtos.CryptoOutput, models.QuerySummary, error) {
 	if len(components) == 0 {
 		d.s.Info("Empty List of Purls supplied")
 		return dtos.CryptoOutput{}, models.QuerySummary{}, errors.New("empty list of purls")
@@ -26,6 +26,7 @@ import (
 	"scanoss.com/cryptography/pkg/utils"
 
 	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 	myconfig "scanoss.com/cryptography/pkg/config"
 
@@ -39,87 +40,138 @@ type VersionsUsingCrypto struct {
 	ctx         context.Context
 	s           *zap.SugaredLogger
 	conn        *sqlx.Conn
+	config      *myconfig.ServerConfig
+	pool        workerPool
 	allUrls     *models.AllUrlsModel
 	cryptoUsage *models.CryptoUsageModel
 }
 
-func NewVersionsUsingCrypto(ctx context.Context, s *zap.SugaredLogger, conn *sqlx.Conn, config *myconfig.ServerConfig) *VersionsUsingCrypto {
-	return &VersionsUsingCrypto{ctx: ctx, s: s, conn: conn,
+// NewVersionsUsingCrypto creates a new instance of the Versions using Crypto use case.
+// The optional db pool is used to process the requested components concurrently, otherwise they are processed on conn.
+func NewVersionsUsingCrypto(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB, conn *sqlx.Conn, config *myconfig.ServerConfig) *VersionsUsingCrypto {
+	return &VersionsUsingCrypto{ctx: ctx, s: s, conn: conn, config: config,
+		pool:        newWorkerPool(s, db, conn, config.Database.Workers),
 		allUrls:     models.NewAllURLModel(ctx, s, database.NewDBSelectContext(s, nil, conn, config.Database.Trace)),
 		cryptoUsage: models.NewCryptoUsageModel(ctx, s, database.NewDBSelectContext(s, nil, conn, config.Database.Trace)),
 	}
 }
 
+// withConn returns a copy of the use case whose models run on the given connection.
+func (d VersionsUsingCrypto) withConn(ctx context.Context, conn *sqlx.Conn) VersionsUsingCrypto {
+	d.ctx = ctx
+	d.conn = conn
+	d.allUrls = models.NewAllURLModel(ctx, d.s, database.NewDBSelectContext(d.s, nil, conn, d.config.Database.Trace))
+	d.cryptoUsage = models.NewCryptoUsageModel(ctx, d.s, database.NewDBSelectContext(d.s, nil, conn, d.config.Database.Trace))
+	return d
+}
+
 // GetVersionsInRangeUsingCrypto takes the Crypto Input request, searches for Cryptographic and return versions that use and does not use crypto.
 func (d VersionsUsingCrypto) GetVersionsInRangeUsingCrypto(components []dtos.ComponentDTO) (dtos.VersionsInRangeOutput, models.QuerySummary, error) {
 	if len(components) == 0 {
 		d.s.Info("Empty List of Purls supplied")
 		return dtos.VersionsInRangeOutput{}, models.QuerySummary{}, errors.New("empty list of purls")
 	}
-	out := dtos.VersionsInRangeOutput{}
-	summary := models.QuerySummary{}
+	ctx, span := startSpan(d.ctx, "VersionsUsingCrypto.GetVersionsInRangeUsingCrypto", attribute.Int("purls", len(components)))
+	items, summary, err := processComponents(ctx, d.pool, components,
+		func(ctx context.Context, conn *sqlx.Conn, batch []dtos.ComponentDTO) ([]componentResult[dtos.VersionsInRangeUsingCryptoItem], error) {
+			return d.withConn(ctx, conn).processBatch(batch)
+		})
+	endSpan(span, summary, err)
+	if err != nil {
+		return dtos.VersionsInRangeOutput{}, models.QuerySummary{}, err
+	}
 	summary.TotalPurls = len(components)
-	// Prepare purls to query
-	for _, component := range components {
-		purl, err := purlhelper.PurlFromString(component.Purl)
+	return dtos.VersionsInRangeOutput{Versions: items}, summary, nil
+}
+
+// processBatch splits the versions in range of a batch of components into those with and without cryptography.
+// The URLs of every component are fetched in one query, and the algorithms of all their hashes in another.
+// KB query failures are returned, rather than reporting the components as not found or without info.
+func (d VersionsUsingCrypto) processBatch(components []dtos.ComponentDTO) ([]componentResult[dtos.VersionsInRangeUsingCryptoItem], error) {
+	results := make([]componentResult[dtos.VersionsInRangeUsingCryptoItem], len(components))
+	queries := make([]*componentInRange, 0, len(components))
+	for i, component := range components {
+		query, err := d.prepareComponent(i, component, &results[i].summary)
 		if err != nil {
-			summary.PurlsFailedToParse = append(summary.PurlsFailedToParse, component.Purl)
-			continue
-		}
-		if component.Requirement == "*" || strings.HasPrefix(component.Requirement, "v*") {
-			return dtos.VersionsInRangeOutput{}, models.QuerySummary{}, errors.New("requirement should include version range or major and wildcard")
+			return nil, err
 		}
-
-		if component.Requirement != "" {
-			if !utils.IsValidRequirement(component.Requirement) {
-				summary.PurlsFailedToParse = append(summary.PurlsFailedToParse, fmt.Sprintf("purl: %s , requirement: %s", component.Purl, component.Requirement))
-				continue
-			}
+		if query != nil {
+			queries = append(queries, query)
 		}
-
-		purlName, err := purlhelper.PurlNameFromString(component.Purl) // Make sure we just have the bare minimum for a Purl Name
+	}
+	if err := fetchUrlsInRange(d.s, d.allUrls, queries); err != nil {
+		return nil, err
+	}
+	usages := make(map[string][]models.CryptoUsage)
+	if hashes := collectURLHashesInRange(queries); len(hashes) > 0 {
+		uses, err := d.cryptoUsage.GetCryptoUsageByURLHashes(hashes)
 		if err != nil {
-			summary.PurlsFailedToParse = append(summary.PurlsFailedToParse, purl.Name)
-			continue
+			d.s.Errorf("error getting algorithms usage for %d purls: %s", len(queries), err)
+			return nil, fmt.Errorf("failed to get the algorithms of %d purls: %v", len(queries), err)
 		}
-		res, errQ := d.allUrls.GetUrlsByPurlNameTypeInRange(purlName, purl.Type, component.Requirement, &summary)
-		if len(res) == 0 {
-			summary.PurlsNotFound = append(summary.PurlsNotFound, purlName)
+		usages = groupByURLHash(uses, func(u models.CryptoUsage) string { return u.URLHash })
+	}
+	for _, q := range queries {
+		r := &results[q.index]
+		if len(q.urls) == 0 {
+			r.summary.PurlsNotFound = append(r.summary.PurlsNotFound, q.key.Name)
 			continue
 		}
+		r.item, r.ok = d.buildItem(q, usagesOf(q.urls, usages)), true
+	}
+	return results, nil
+}
 
-		_ = errQ
-		item := dtos.VersionsInRangeUsingCryptoItem{Purl: component.Purl, VersionsWith: []string{}, VersionsWithout: []string{}}
-		var hashes []string
-		nonDupVersions := make(map[string]bool)
-		mapVersionHash := make(map[string]string)
-		for _, url := range res {
-			hashes = append(hashes, url.URLHash)
-			mapVersionHash[url.URLHash] = url.SemVer
-			nonDupVersions[url.SemVer] = false
-		}
-		uses, err1 := d.cryptoUsage.GetCryptoUsageByURLHashes(hashes)
-		if err1 != nil {
-			d.s.Infof("error getting algorithms usage for purl '%s': %s", component.Purl, err)
-		}
+// prepareComponent validates a component of the request, returning nil if it cannot be searched.
+func (d VersionsUsingCrypto) prepareComponent(index int, component dtos.ComponentDTO, summary *models.QuerySummary) (*componentInRange, error) {
+	purl, err := purlhelper.PurlFromString(component.Purl)
+	if err != nil {
+		summary.PurlsFailedToParse = append(summary.PurlsFailedToParse, component.Purl)
+		return nil, nil
+	}
+	if component.Requirement == "*" || strings.HasPrefix(component.Requirement, "v*") {
+		return nil, errors.New("requirement should include version range or major and wildcard")
+	}
 
-		for _, alg := range uses {
-			nonDupVersions[mapVersionHash[alg.URLHash]] = true
-		}
-		for k, v := range nonDupVersions {
-			if v {
-				item.VersionsWith = append(item.VersionsWith, k)
-			} else {
-				item.VersionsWithout = append(item.VersionsWithout, k)
-			}
+	if component.Requirement != "" {
+		if !utils.IsValidRequirement(component.Requirement) {
+			summary.PurlsFailedToParse = append(summary.PurlsFailedToParse, fmt.Sprintf("purl: %s , requirement: %s", component.Purl, component.Requirement))
+			return nil, nil
 		}
-		sort.Strings(item.VersionsWith)
-		sort.Strings(item.VersionsWithout)
+	}
 
-		if len(uses) == 0 {
-			summary.PurlsWOInfo = append(summary.PurlsWOInfo, component.Purl)
+	purlName, err := purlhelper.PurlNameFromString(component.Purl) // Make sure we just have the bare minimum for a Purl Name
+	if err != nil {
+		summary.PurlsFailedToParse = append(summary.PurlsFailedToParse, purl.Name)
+		return nil, nil
+	}
+	return &componentInRange{index: index, component: component, key: models.PurlNameType{Name: purlName, Type: purl.Type}, summary: summary}, nil
+}
+
+// buildItem splits the versions of a component depending on whether any algorithm was found in them.
+func (d VersionsUsingCrypto) buildItem(q *componentInRange, uses []models.CryptoUsage) dtos.VersionsInRangeUsingCryptoItem {
+	item := dtos.VersionsInRangeUsingCryptoItem{Purl: q.component.Purl, VersionsWith: []string{}, VersionsWithout: []string{}}
+	nonDupVersions := make(map[string]bool)
+	mapVersionHash := make(map[string]string)
+	for _, url := range q.urls {
+		mapVersionHash[url.URLHash] = url.SemVer
+		nonDupVersions[url.SemVer] = false
+	}
+	for _, alg := range uses {
+		nonDupVersions[mapVersionHash[alg.URLHash]] = true
+	}
+	for k, v := range nonDupVersions {
+		if v {
+			item.VersionsWith = append(item.VersionsWith, k)
+		} else {
+			item.VersionsWithout = append(item.VersionsWithout, k)
 		}
-		out.Versions = append(out.Versions, item)
 	}
-	return out, summary, nil
+	sort.Strings(item.VersionsWith)
+	sort.Strings(item.VersionsWithout)
+
+	if len(uses) == 0 {
+		q.summary.PurlsWOInfo = append(q.summary.PurlsWOInfo, q.component.Purl)
+	}
+	return item
 }
@@ -66,7 +66,7 @@ func TestVersionsUsingCryptoUseCase(t *testing.T) {
 			Requirement: ">v0.0.0",
 		},
 	}
-	versionsUc := NewVersionsUsingCrypto(ctx, s, conn, myConfig)
+	versionsUc := NewVersionsUsingCrypto(ctx, s, nil, conn, myConfig)
 
 	versions, summary, err := versionsUc.GetVersionsInRangeUsingCrypto(componentDTOS)
 	if err != nil {
@@ -191,7 +191,7 @@ func TestVersionInRangeUsingCryptoUseCase(t *testing.T) {
 		},
 	}
 
-	cryptoUc := NewCryptoMajor(ctx, s, conn, myConfig)
+	cryptoUc := NewCryptoMajor(ctx, s, nil, conn, myConfig)
 	algorithms, summary, err := cryptoUc.GetCryptoInRange(componentDTOS)
 	if err != nil {
 		t.Fatalf("the error '%v' was not expected when getting cryptography", err)
@@ -0,0 +1,226 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/jmoiron/sqlx"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	"go.uber.org/zap"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+// KBSnapshotUseCase reads the algorithms and hints of each version of a list of purls, so two KBs can be compared.
+type KBSnapshotUseCase struct {
+	ctx         context.Context
+	s           *zap.SugaredLogger
+	allUrls     *models.AllUrlsModel
+	cryptoUsage *models.CryptoUsageModel
+	usage       *models.ECUsageModel
+}
+
+// KBSnapshot holds the algorithms and hints of each version (by name) of a list of purls.
+type KBSnapshot map[models.PurlNameType]map[string]KBVersionData
+
+// KBVersionData holds the algorithms (keyed by name and strength) and hints (crypto library IDs) of a version.
+type KBVersionData struct {
+	Algorithms map[dtos.CryptoUsageItem]bool
+	Hints      map[string]bool
+}
+
+// NewKBSnapshot creates a new instance of the KB Snapshot use case.
+func NewKBSnapshot(ctx context.Context, s *zap.SugaredLogger, conn *sqlx.Conn, config *myconfig.ServerConfig) *KBSnapshotUseCase {
+	return &KBSnapshotUseCase{ctx: ctx, s: s,
+		allUrls:     models.NewAllURLModel(ctx, s, database.NewDBSelectContext(s, nil, conn, config.Database.Trace)),
+		cryptoUsage: models.NewCryptoUsageModel(ctx, s, database.NewDBSelectContext(s, nil, conn, config.Database.Trace)),
+		usage:       models.NewECUsageModel(ctx, s, database.NewDBSelectContext(s, nil, conn, config.Database.Trace)),
+	}
+}
+
+// GetPurlNames returns all the purls of the KB.
+func (d KBSnapshotUseCase) GetPurlNames() ([]models.PurlNameType, error) {
+	return d.allUrls.GetPurlNames()
+}
+
+// GetSnapshot returns the algorithms and hints of every version of the given purls.
+// Purls not in the KB are left out of the snapshot.
+func (d KBSnapshotUseCase) GetSnapshot(purls []models.PurlNameType) (KBSnapshot, error) {
+	urlsByPurl, err := d.allUrls.GetUrlsByPurlNames(purls)
+	if err != nil {
+		return nil, err
+	}
+	var hashes []string
+	for _, urls := range urlsByPurl {
+		for _, u := range urls {
+			hashes = append(hashes, u.URLHash)
+		}
+	}
+	algorithms := make(map[string][]dtos.CryptoUsageItem)
+	hints := make(map[string][]string)
+	if len(hashes) > 0 {
+		usages, err := d.cryptoUsage.GetCryptoUsageByURLHashes(hashes)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range usages {
+			algorithms[u.URLHash] = append(algorithms[u.URLHash],
+				dtos.CryptoUsageItem{Algorithm: strings.ToLower(u.Algorithm), Strength: u.Strength})
+		}
+		libraries, err := d.usage.GetLibraryUsageByURLHashes(hashes)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range libraries {
+			hints[l.URLHash] = append(hints[l.URLHash], l.ID)
+		}
+	}
+	snapshot := make(KBSnapshot, len(urlsByPurl))
+	for purl, urls := range urlsByPurl {
+		versions := make(map[string]KBVersionData)
+		for _, u := range urls {
+			if len(u.Version) == 0 {
+				continue
+			}
+			data, found := versions[u.Version]
+			if !found {
+				data = KBVersionData{Algorithms: map[dtos.CryptoUsageItem]bool{}, Hints: map[string]bool{}}
+				versions[u.Version] = data
+			}
+			for _, a := range algorithms[u.URLHash] {
+				data.Algorithms[a] = true
+			}
+			for _, h := range hints[u.URLHash] {
+				data.Hints[h] = true
+			}
+		}
+		snapshot[purl] = versions
+	}
+	d.s.Debugf("Loaded a snapshot of %d of %d purls.", len(snapshot), len(purls))
+	return snapshot, nil
+}
+
+// DiffKBSnapshots compares the snapshots of an old and a new KB, reporting per purl the versions mined or removed,
+// and the algorithms and hints added or removed from each version in both KBs.
+func DiffKBSnapshots(purls []models.PurlNameType, oldKB, newKB KBSnapshot) dtos.KBDiffOutput {
+	purls = slices.Clone(purls)
+	slices.SortFunc(purls, func(a, b models.PurlNameType) int {
+		return cmp.Or(strings.Compare(a.Type, b.Type), strings.Compare(a.Name, b.Name))
+	})
+	purls = slices.Compact(purls)
+	output := dtos.KBDiffOutput{Purls: []dtos.KBDiffPurl{}, Summary: dtos.KBDiffSummary{Purls: len(purls)}}
+	for _, p := range purls {
+		oldVersions, inOld := oldKB[p]
+		newVersions, inNew := newKB[p]
+		purl := "pkg:" + p.Type + "/" + p.Name
+		if !inOld && !inNew {
+			output.NotFound = append(output.NotFound, purl)
+			continue
+		}
+		item := dtos.KBDiffPurl{Purl: purl}
+		for _, version := range sortedVersions(oldVersions, newVersions) {
+			oldData, inOld := oldVersions[version]
+			newData, inNew := newVersions[version]
+			switch {
+			case !inOld:
+				item.NewVersions = append(item.NewVersions, version)
+			case !inNew:
+				item.RemovedVersions = append(item.RemovedVersions, version)
+			default:
+				if diff, changed := diffVersionData(version, oldData, newData); changed {
+					item.Versions = append(item.Versions, diff)
+				}
+			}
+		}
+		if len(item.NewVersions) == 0 && len(item.RemovedVersions) == 0 && len(item.Versions) == 0 {
+			continue
+		}
+		output.Summary.ChangedPurls++
+		output.Summary.NewVersions += len(item.NewVersions)
+		output.Summary.RemovedVersions += len(item.RemovedVersions)
+		output.Summary.ChangedVersions += len(item.Versions)
+		for _, v := range item.Versions {
+			if v.LostData {
+				output.Summary.LostData++
+			}
+		}
+		output.Purls = append(output.Purls, item)
+	}
+	return output
+}
+
+// diffVersionData returns the algorithms and hints added to and removed from a version, and whether there were any.
+func diffVersionData(version string, oldData, newData KBVersionData) (dtos.KBDiffVersion, bool) {
+	diff := dtos.KBDiffVersion{Version: version,
+		AlgorithmsAdded:   missingKeys(newData.Algorithms, oldData.Algorithms),
+		AlgorithmsRemoved: missingKeys(oldData.Algorithms, newData.Algorithms),
+		HintsAdded:        missingKeys(newData.Hints, oldData.Hints),
+		HintsRemoved:      missingKeys(oldData.Hints, newData.Hints),
+	}
+	sortAlgorithms := func(a, b dtos.CryptoUsageItem) int {
+		return cmp.Or(strings.Compare(a.Algorithm, b.Algorithm), strings.Compare(a.Strength, b.Strength))
+	}
+	slices.SortFunc(diff.AlgorithmsAdded, sortAlgorithms)
+	slices.SortFunc(diff.AlgorithmsRemoved, sortAlgorithms)
+	slices.Sort(diff.HintsAdded)
+	slices.Sort(diff.HintsRemoved)
+	hadData := len(oldData.Algorithms) > 0 || len(oldData.Hints) > 0
+	diff.LostData = hadData && len(newData.Algorithms) == 0 && len(newData.Hints) == 0
+	changed := len(diff.AlgorithmsAdded) > 0 || len(diff.AlgorithmsRemoved) > 0 || len(diff.HintsAdded) > 0 || len(diff.HintsRemoved) > 0
+	return diff, changed
+}
+
+// missingKeys returns the keys of a set missing from another one.
+func missingKeys[K comparable](set, other map[K]bool) []K {
+	var keys []K
+	for k := range set {
+		if !other[k] {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// sortedVersions returns the versions of both snapshots of a purl, sorted by semver. Versions that are not semver go last, sorted by name.
+func sortedVersions(oldVersions, newVersions map[string]KBVersionData) []string {
+	var versions []string
+	for _, m := range []map[string]KBVersionData{oldVersions, newVersions} {
+		for v := range m {
+			versions = append(versions, v)
+		}
+	}
+	slices.SortFunc(versions, func(a, b string) int {
+		versionA, errA := semver.NewVersion(a)
+		versionB, errB := semver.NewVersion(b)
+		switch {
+		case errA == nil && errB == nil:
+			return cmp.Or(versionA.Compare(versionB), strings.Compare(a, b))
+		case errA == nil:
+			return -1
+		case errB == nil:
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+	return slices.Compact(versions)
+}
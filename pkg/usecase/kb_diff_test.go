@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+func TestKBSnapshotDiff(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	conn, err := db.Connx(ctx) // Get a connection from the pool
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseConn(conn)
+	err = models.LoadTestSQLData(db, ctx, conn)
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	snapshotUc := NewKBSnapshot(ctx, s, conn, myConfig)
+	names, err := snapshotUc.GetPurlNames()
+	if err != nil || !slices.Contains(names, models.PurlNameType{Name: "scanoss/engine", Type: "github"}) {
+		t.Errorf("GetPurlNames() = %v, %v", names, err)
+	}
+	purls := []models.PurlNameType{{Name: "scanoss/engine", Type: "github"}, {Name: "pineappleea/pineapple-src", Type: "github"},
+		{Name: "scanoss/none", Type: "github"}}
+	oldKB, err := snapshotUc.GetSnapshot(purls)
+	if err != nil {
+		t.Fatalf("GetSnapshot() error = %v", err)
+	}
+	if len(oldKB) != 2 || len(oldKB[purls[0]]["5.2.4"].Algorithms) != 4 || !oldKB[purls[1]]["v5.4.7"].Hints["protocol/tls"] {
+		t.Errorf("GetSnapshot() = %+v", oldKB)
+	}
+	if diff := DiffKBSnapshots(purls, oldKB, oldKB); diff.Summary.ChangedPurls != 0 || len(diff.Purls) != 0 {
+		t.Errorf("DiffKBSnapshots() of the same snapshot = %+v", diff)
+	}
+
+	err = models.RunTestSQL(db, ctx, conn, "DELETE FROM component_crypto WHERE url_hash = '2c2ae45c192df28dcfd1caab7e2b12db';"+
+		"INSERT INTO component_crypto (url_hash, algorithm_name, strength) VALUES ('541bae26cbf8e2d2f33d20cd22d435dd', 'AES', '256');"+
+		"DELETE FROM component_crypto_library WHERE url_hash = 'c8b5647654826091fb65a97bec820eb9' AND det_id = 'protocol/tls';"+
+		"INSERT INTO all_urls VALUES ('ffff0000000000000000000000000002','','engine',13229156,'scanoss/engine',5,'2024-01-01',true);"+
+		"DELETE FROM all_urls WHERE package_hash = 'ffff0000000000000000000000000001';")
+	if err != nil {
+		t.Fatalf("failed to update the test data: %v", err)
+	}
+	newKB, err := snapshotUc.GetSnapshot(purls)
+	if err != nil {
+		t.Fatalf("GetSnapshot() error = %v", err)
+	}
+	diff := DiffKBSnapshots(purls, oldKB, newKB)
+	expected := dtos.KBDiffOutput{
+		Summary: dtos.KBDiffSummary{Purls: 3, ChangedPurls: 2, NewVersions: 1, RemovedVersions: 1, ChangedVersions: 3, LostData: 1},
+		Purls: []dtos.KBDiffPurl{
+			{Purl: "pkg:github/pineappleea/pineapple-src",
+				Versions: []dtos.KBDiffVersion{{Version: "v5.4.7", HintsRemoved: []string{"protocol/tls"}}}},
+			{Purl: "pkg:github/scanoss/engine", NewVersions: []string{"0.14.6"}, RemovedVersions: []string{"2.73.2"},
+				Versions: []dtos.KBDiffVersion{
+					{Version: "5.2.4", LostData: true, AlgorithmsRemoved: []dtos.CryptoUsageItem{{Algorithm: "crc32", Strength: "32"},
+						{Algorithm: "des", Strength: "168"}, {Algorithm: "md5", Strength: "128"}, {Algorithm: "rsa", Strength: "128"}}},
+					{Version: "v5.4.5", AlgorithmsAdded: []dtos.CryptoUsageItem{{Algorithm: "aes", Strength: "256"}}},
+				}},
+		},
+		NotFound: []string{"pkg:github/scanoss/none"},
+	}
+	if !reflect.DeepEqual(diff, expected) {
+		t.Errorf("DiffKBSnapshots() =\n%+v\nexpected\n%+v", diff, expected)
+	}
+}
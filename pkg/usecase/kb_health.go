@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+// Statuses reported by each KB health check.
+const (
+	HealthCheckOK      = "ok"
+	HealthCheckFailed  = "failed"
+	HealthCheckSkipped = "skipped"
+)
+
+type KBHealthUseCase struct {
+	ctx      context.Context
+	s        *zap.SugaredLogger
+	kbHealth *models.KBHealthModel
+}
+
+// NewKBHealth creates a new instance of the KB Health use case.
+func NewKBHealth(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB) *KBHealthUseCase {
+	return &KBHealthUseCase{ctx: ctx, s: s, kbHealth: models.NewKBHealthModel(ctx, s, db)}
+}
+
+// CheckHealth runs the database connectivity, schema compatibility and KB data checks in turn.
+// Once a check fails, the ones after it are skipped. The KB is only ready if all the checks pass.
+func (d KBHealthUseCase) CheckHealth() dtos.HealthOutput {
+	checks := []struct {
+		name string
+		run  func() error
+	}{
+		{name: "database", run: d.kbHealth.Ping},
+		{name: "schema", run: d.kbHealth.CheckSchema},
+		{name: "data", run: d.checkData},
+	}
+	output := dtos.HealthOutput{Ready: true, CheckedAt: time.Now().UTC().Format(time.RFC3339)}
+	for i, check := range checks {
+		if !output.Ready {
+			output.Checks = append(output.Checks, dtos.HealthCheck{Name: check.name, Status: HealthCheckSkipped})
+			continue
+		}
+		result := dtos.HealthCheck{Name: check.name, Status: HealthCheckOK}
+		if err := check.run(); err != nil {
+			result.Status = HealthCheckFailed
+			result.Message = err.Error()
+			output.Ready = false
+		}
+		if i == 0 {
+			output.Live = output.Ready
+		}
+		output.Checks = append(output.Checks, result)
+	}
+	return output
+}
+
+// checkData checks that none of the required KB tables are empty.
+func (d KBHealthUseCase) checkData() error {
+	empty, err := d.kbHealth.GetEmptyTables()
+	if err != nil {
+		return err
+	}
+	if len(empty) > 0 {
+		return fmt.Errorf("no data in the %v table(s)", strings.Join(empty, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	"go.uber.org/zap"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+type KBStatsUseCase struct {
+	ctx     context.Context
+	s       *zap.SugaredLogger
+	kbStats *models.KBStatsModel
+}
+
+// NewKBStats creates a new instance of the KB Stats use case.
+func NewKBStats(ctx context.Context, s *zap.SugaredLogger, conn *sqlx.Conn, config *myconfig.ServerConfig) *KBStatsUseCase {
+	return &KBStatsUseCase{ctx: ctx, s: s,
+		kbStats: models.NewKBStatsModel(ctx, s, database.NewDBSelectContext(s, nil, conn, config.Database.Trace)),
+	}
+}
+
+// GetKBStats returns the row counts of the KB tables and the date of the latest version loaded.
+func (d KBStatsUseCase) GetKBStats() (dtos.KBStatsOutput, error) {
+	counts, err := d.kbStats.GetTableCounts()
+	if err != nil {
+		return dtos.KBStatsOutput{}, err
+	}
+	latest, err := d.kbStats.GetLatestURLDate()
+	if err != nil {
+		return dtos.KBStatsOutput{}, err
+	}
+	output := dtos.KBStatsOutput{LatestURLDate: latest}
+	for _, c := range counts {
+		output.Tables = append(output.Tables, dtos.KBTableStats{Table: c.Table, Rows: c.Rows})
+	}
+	return output, nil
+}
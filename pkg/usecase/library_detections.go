@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -31,6 +32,7 @@ import (
 	"github.com/Masterminds/semver/v3"
 	"github.com/jmoiron/sqlx"
 	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 	"scanoss.com/cryptography/pkg/dtos"
 	"scanoss.com/cryptography/pkg/models"
@@ -40,51 +42,139 @@ type ECDetectionUseCase struct {
 	ctx     context.Context
 	s       *zap.SugaredLogger
 	conn    *sqlx.Conn
+	config  *myconfig.ServerConfig
+	pool    workerPool
 	allUrls *models.AllUrlsModel
 	usage   *models.ECUsageModel
+	tags    []string // Only report the detections with any of these tags, if set
 }
 
-func NewECDetection(ctx context.Context, s *zap.SugaredLogger, conn *sqlx.Conn, config *myconfig.ServerConfig) *ECDetectionUseCase {
-	return &ECDetectionUseCase{ctx: ctx, s: s, conn: conn,
+// NewECDetection creates a new instance of the Encryption/Crypto library detection use case.
+// The optional db pool is used to process the requested components concurrently, otherwise they are processed on conn.
+func NewECDetection(ctx context.Context, s *zap.SugaredLogger, db *sqlx.DB, conn *sqlx.Conn, config *myconfig.ServerConfig) *ECDetectionUseCase {
+	return &ECDetectionUseCase{ctx: ctx, s: s, conn: conn, config: config,
+		pool:    newWorkerPool(s, db, conn, config.Database.Workers),
 		allUrls: models.NewAllURLModel(ctx, s, database.NewDBSelectContext(s, nil, conn, config.Database.Trace)),
 		usage:   models.NewECUsageModel(ctx, s, database.NewDBSelectContext(s, nil, conn, config.Database.Trace)),
 	}
 }
 
+// FilterByTags restricts the detections reported to those with any of the given tags (i.e. fips, tls or pqc).
+func (d *ECDetectionUseCase) FilterByTags(tags []string) *ECDetectionUseCase {
+	d.tags = models.NormalizeTags(tags)
+	return d
+}
+
+// withConn returns a copy of the use case whose models run on the given connection.
+func (d ECDetectionUseCase) withConn(ctx context.Context, conn *sqlx.Conn) ECDetectionUseCase {
+	d.ctx = ctx
+	d.conn = conn
+	d.allUrls = models.NewAllURLModel(ctx, d.s, database.NewDBSelectContext(d.s, nil, conn, d.config.Database.Trace))
+	d.usage = models.NewECUsageModel(ctx, d.s, database.NewDBSelectContext(d.s, nil, conn, d.config.Database.Trace))
+	return d
+}
+
 // GetDetectionsInRange takes the Crypto Input request, searches for Cryptographic usages and returns a CryptoOutput struct.
 func (d ECDetectionUseCase) GetDetectionsInRange(components []dtos.ComponentDTO) (dtos.ECOutput, models.QuerySummary, error) {
 	if len(components) == 0 {
 		d.s.Info("Empty List of Purls supplied")
 		return dtos.ECOutput{}, models.QuerySummary{}, errors.New("empty list of purls")
 	}
-
-	out := dtos.ECOutput{}
-	summary := models.QuerySummary{}
+	ctx, span := startSpan(d.ctx, "ECDetectionUseCase.GetDetectionsInRange", attribute.Int("purls", len(components)))
+	items, summary, err := processComponents(ctx, d.pool, components,
+		func(ctx context.Context, conn *sqlx.Conn, batch []dtos.ComponentDTO) ([]componentResult[dtos.ECOutputItem], error) {
+			return d.withConn(ctx, conn).processBatch(batch)
+		})
+	endSpan(span, summary, err)
+	if err != nil {
+		return dtos.ECOutput{}, models.QuerySummary{}, err
+	}
 	summary.TotalPurls = len(components)
+	return dtos.ECOutput{Hints: items}, summary, nil
+}
 
-	for _, component := range components {
-		if component.Requirement == "*" || strings.HasPrefix(component.Requirement, "v*") {
-			summary.PurlsFailedToParse = append(summary.PurlsFailedToParse, component.Purl)
-			d.s.Warnf("requirement should include version range or major and wildcard")
+// processBatch searches the detections of all the versions in range of a batch of components.
+// The URLs of every component are fetched in one query, and the library usages of all their hashes in another.
+func (d ECDetectionUseCase) processBatch(components []dtos.ComponentDTO) ([]componentResult[dtos.ECOutputItem], error) {
+	results := make([]componentResult[dtos.ECOutputItem], len(components))
+	queries := make([]*componentInRange, 0, len(components))
+	for i, component := range components {
+		if query := d.prepareComponent(i, component, &results[i].summary); query != nil {
+			queries = append(queries, query)
+		}
+	}
+	if err := fetchUrlsInRange(d.s, d.allUrls, queries); err != nil {
+		return nil, err
+	}
+	usages := make(map[string][]models.ECUsage)
+	usageFailed := false
+	if hashes := collectURLHashesInRange(queries); len(hashes) > 0 {
+		uses, err := d.usage.GetLibraryUsageByURLHashes(hashes)
+		if err != nil {
+			d.s.Errorf("error getting algorithms usage for %d purls: %s", len(queries), err)
+			usageFailed = true
+		}
+		if uses, err = tagLibraryUsage(d.s, d.usage, uses, d.tags); err != nil {
+			return nil, err
+		}
+		usages = groupByURLHash(uses, func(u models.ECUsage) string { return u.URLHash })
+	}
+	for _, q := range queries {
+		r := &results[q.index]
+		if q.err != nil {
+			r.summary.PurlsFailedToParse = append(r.summary.PurlsFailedToParse, q.component.Purl)
 			continue
 		}
-		if component.Requirement != "" {
-			if !utils.IsValidRequirement(component.Requirement) {
-				summary.PurlsFailedToParse = append(summary.PurlsFailedToParse, fmt.Sprintf("purl: %s , requirement: %s", component.Purl, component.Requirement))
-				continue
-			}
+		if len(q.urls) == 0 {
+			r.summary.PurlsNotFound = append(r.summary.PurlsNotFound, q.component.Purl)
+			continue
 		}
-
-		if item, ok := d.processSinglePurl(component, &summary); ok {
-			out.Hints = append(out.Hints, *item)
+		r.item = d.buildItem(q.component, q.urls, usagesOf(q.urls, usages))
+		if len(r.item.Detections) == 0 && !usageFailed {
+			r.summary.PurlsWOInfo = append(r.summary.PurlsWOInfo, q.component.Purl)
 		}
+		r.ok = true
 	}
+	return results, nil
+}
 
-	return out, summary, nil
+// prepareComponent validates a component of the request, returning nil if it cannot be searched.
+func (d ECDetectionUseCase) prepareComponent(index int, component dtos.ComponentDTO, summary *models.QuerySummary) *componentInRange {
+	if component.Requirement == "*" || strings.HasPrefix(component.Requirement, "v*") {
+		summary.PurlsFailedToParse = append(summary.PurlsFailedToParse, component.Purl)
+		d.s.Warnf("requirement should include version range or major and wildcard")
+		return nil
+	}
+	if component.Requirement != "" {
+		if !utils.IsValidRequirement(component.Requirement) {
+			summary.PurlsFailedToParse = append(summary.PurlsFailedToParse, fmt.Sprintf("purl: %s , requirement: %s", component.Purl, component.Requirement))
+			return nil
+		}
+	}
+	purl, err := purlhelper.PurlFromString(component.Purl)
+	if err != nil {
+		summary.PurlsFailedToParse = append(summary.PurlsFailedToParse, component.Purl)
+		return nil
+	}
+	purlName, err := purlhelper.PurlNameFromString(component.Purl)
+	if err != nil {
+		d.s.Errorf("Failed to parse purl '%s': %s", component.Purl, err)
+		summary.PurlsFailedToParse = append(summary.PurlsFailedToParse, component.Purl)
+		return nil
+	}
+	return &componentInRange{index: index, component: component, key: models.PurlNameType{Name: purlName, Type: purl.Type}, summary: summary}
 }
 
 // GetDetections takes the Crypto Input request, searches for Cryptographic Hints and returns a HintsOutput struct.
 func (d ECDetectionUseCase) GetDetections(components []dtos.ComponentDTO) (dtos.HintsOutput, models.QuerySummary, error) {
+	ctx, span := startSpan(d.ctx, "ECDetectionUseCase.GetDetections", attribute.Int("purls", len(components)))
+	output, summary, err := d.withConn(ctx, d.conn).getDetections(components)
+	endSpan(span, summary, err)
+	return output, summary, err
+}
+
+// getDetections runs the hints search of GetDetections.
+func (d ECDetectionUseCase) getDetections(components []dtos.ComponentDTO) (dtos.HintsOutput, models.QuerySummary, error) {
 	if len(components) == 0 {
 		d.s.Info("Empty List of Purls supplied")
 		return dtos.HintsOutput{}, models.QuerySummary{}, errors.New("empty list of purls")
@@ -117,6 +207,9 @@ func (d ECDetectionUseCase) GetDetections(components []dtos.ComponentDTO) (dtos.
 		if err1 != nil {
 			d.s.Errorf("error getting algorithms usage for purl '%s': %s", component.Purl, err)
 		}
+		if uses, err = tagLibraryUsage(d.s, d.usage, uses, d.tags); err != nil {
+			return dtos.HintsOutput{}, models.QuerySummary{}, err
+		}
 		// avoid duplicate detections (if any)
 		// Duplicates should have been removed on mining, but some appended keyword may produce a duplicate entry for an existing url
 		nonDupAlgorithms := make(map[string]bool)
@@ -130,7 +223,8 @@ func (d ECDetectionUseCase) GetDetections(components []dtos.ComponentDTO) (dtos.
 						Description: alg.Description,
 						URL:         alg.URL,
 						Category:    alg.Category,
-						Purl:        alg.Purl})
+						Purl:        alg.Purl,
+						Tags:        alg.Tags})
 			}
 		}
 		if len(uses) == 0 {
@@ -141,34 +235,19 @@ func (d ECDetectionUseCase) GetDetections(components []dtos.ComponentDTO) (dtos.
 	return out, summary, nil
 }
 
-// processURLResults handles the processing of URL results and creates an ECOutputItem.
-func (d ECDetectionUseCase) processURLResults(res []models.AllURL, componentDTO dtos.ComponentDTO) (dtos.ECOutputItem, []string) {
+// buildItem lists the distinct detections of a component and the versions in which they were found.
+func (d ECDetectionUseCase) buildItem(componentDTO dtos.ComponentDTO, urls []models.AllURL, uses []models.ECUsage) dtos.ECOutputItem {
 	item := dtos.ECOutputItem{Purl: componentDTO.Purl, Versions: []string{}}
-	hashes := make([]string, 0)
 	mapVersionHash := make(map[string]string)
-
-	for _, url := range res {
+	for _, url := range urls {
 		if url.URLHash != "" {
-			hashes = append(hashes, url.URLHash)
 			mapVersionHash[url.URLHash] = url.SemVer
 		}
 	}
-
-	return item, d.processUsages(hashes, mapVersionHash, &item)
-}
-
-// processUsages handles library usage processing and returns hashes.
-func (d ECDetectionUseCase) processUsages(hashes []string, mapVersionHash map[string]string, item *dtos.ECOutputItem) []string {
-	uses, err := d.usage.GetLibraryUsageByURLHashes(hashes)
-	if err != nil {
-		d.s.Errorf("error getting algorithms usage for purl '%s': %s", item.Purl, err)
-		return hashes
-	}
-	// If a library has no usages, return empty hashes
+	// If a library has no usages, there is nothing else to report
 	if len(uses) == 0 {
-		return []string{}
+		return item
 	}
-
 	nonDupVersions := make(map[string]bool)
 	nonDupAlgorithms := make(map[string]bool)
 
@@ -183,12 +262,13 @@ func (d ECDetectionUseCase) processUsages(hashes []string, mapVersionHash map[st
 				URL:         alg.URL,
 				Category:    alg.Category,
 				Purl:        alg.Purl,
+				Tags:        alg.Tags,
 			})
 		}
 	}
 
 	item.Versions = d.getSortedVersions(nonDupVersions)
-	return hashes
+	return item
 }
 
 // getSortedVersions returns a sorted slice of versions.
@@ -207,36 +287,30 @@ func (d ECDetectionUseCase) getSortedVersions(versions map[string]bool) []string
 	return result
 }
 
-// processSinglePurl processes a single PURL and returns whether to continue processing.
-func (d ECDetectionUseCase) processSinglePurl(componentDTO dtos.ComponentDTO, summary *models.QuerySummary) (*dtos.ECOutputItem, bool) {
-	purl, err := purlhelper.PurlFromString(componentDTO.Purl)
-	if err != nil {
-		summary.PurlsFailedToParse = append(summary.PurlsFailedToParse, componentDTO.Purl)
-		return nil, false
+// tagLibraryUsage fills in the tags of the crypto library usages, keeping only those with any of the filter tags (if set).
+// If the tags cannot be looked up (i.e. the KB has no tags table), the usages are returned untagged, unless filtering.
+func tagLibraryUsage(s *zap.SugaredLogger, usage *models.ECUsageModel, uses []models.ECUsage, filter []string) ([]models.ECUsage, error) {
+	if len(uses) == 0 {
+		return uses, nil
 	}
-
-	purlName, err := purlhelper.PurlNameFromString(componentDTO.Purl)
-	if err != nil {
-		d.s.Errorf("Failed to parse purl '%s': %s", componentDTO.Purl, err)
-		summary.PurlsFailedToParse = append(summary.PurlsFailedToParse, componentDTO.Purl)
-		return nil, false
+	ids := make([]string, 0, len(uses))
+	for _, u := range uses {
+		ids = append(ids, u.ID)
 	}
-
-	res, err := d.allUrls.GetUrlsByPurlNameTypeInRange(purlName, purl.Type, componentDTO.Requirement, summary)
+	tags, err := usage.GetLibraryTags(ids)
 	if err != nil {
-		summary.PurlsFailedToParse = append(summary.PurlsFailedToParse, componentDTO.Purl)
-		return nil, false
-	}
-
-	if len(res) == 0 {
-		summary.PurlsNotFound = append(summary.PurlsNotFound, componentDTO.Purl)
-		return nil, false
+		if len(filter) > 0 {
+			return nil, errors.New("problem filtering hints by tag")
+		}
+		s.Warnf("Hints reported without tags: %v", err)
+		return uses, nil
 	}
-
-	item, hashes := d.processURLResults(res, componentDTO)
-	if len(hashes) == 0 {
-		summary.PurlsWOInfo = append(summary.PurlsWOInfo, componentDTO.Purl)
+	tagged := make([]models.ECUsage, 0, len(uses))
+	for _, u := range uses {
+		u.Tags = tags[u.ID]
+		if len(filter) == 0 || slices.ContainsFunc(u.Tags, func(t string) bool { return slices.Contains(filter, t) }) {
+			tagged = append(tagged, u)
+		}
 	}
-
-	return &item, true
+	return tagged, nil
 }
@@ -18,6 +18,7 @@ package usecase
 
 import (
 	"context"
+	"slices"
 	"testing"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
@@ -62,7 +63,7 @@ func TestLibrariesDetectionUseCase_InRange(t *testing.T) {
 			Requirement: ">=0.0.0",
 		},
 	}
-	hintsUc := NewECDetection(ctx, s, conn, myConfig)
+	hintsUc := NewECDetection(ctx, s, nil, conn, myConfig)
 	libraries, summary, err := hintsUc.GetDetectionsInRange(componentDTOS)
 	if err != nil {
 		t.Fatalf("the error '%v' was not expected when getting Hints", err)
@@ -217,7 +218,7 @@ func TestLibrariesDetectionUseCase_ExactVersion(t *testing.T) {
 			Requirement: "5.4.7",
 		},
 	}
-	hintsUc := NewECDetection(ctx, s, conn, myConfig)
+	hintsUc := NewECDetection(ctx, s, nil, conn, myConfig)
 	libraries, summary, err := hintsUc.GetDetections(componentDTOS)
 	if err != nil {
 		t.Fatalf("the error '%v' was not expected when getting Hints", err)
@@ -314,7 +315,7 @@ func TestLibrariesDetectionUseCase_MalformedPurl(t *testing.T) {
 			Requirement: ">=1.0",
 		},
 	}
-	hintsUc := NewECDetection(ctx, s, conn, myConfig)
+	hintsUc := NewECDetection(ctx, s, nil, conn, myConfig)
 	libraries, summary, err := hintsUc.GetDetectionsInRange(componentDTO)
 	if err != nil {
 		t.Fatalf("Got an unexpected error: %v", err)
@@ -327,3 +328,68 @@ func TestLibrariesDetectionUseCase_MalformedPurl(t *testing.T) {
 		t.Fatalf("Not expected to get information from an empty request")
 	}
 }
+
+func TestLibrariesDetectionUseCase_Tags(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	conn, err := db.Connx(ctx) // Get a connection from the pool
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseConn(conn)
+	if err = models.LoadTestSQLData(db, ctx, conn); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	components := []dtos.ComponentDTO{{Purl: "pkg:github/pineappleea/pineapple-src", Requirement: "5.4.7"}}
+	hints, _, err := NewECDetection(ctx, s, nil, conn, myConfig).GetDetections(components)
+	if err != nil || len(hints.Hints) != 1 {
+		t.Fatalf("GetDetections() = %v, %v", hints, err)
+	}
+	all := hints.Hints[0].Detections
+	hints, _, err = NewECDetection(ctx, s, nil, conn, myConfig).FilterByTags([]string{" FIPS "}).GetDetections(components)
+	if err != nil || len(hints.Hints) != 1 {
+		t.Fatalf("GetDetections() = %v, %v", hints, err)
+	}
+	fips := hints.Hints[0].Detections
+	if len(fips) == 0 || len(fips) >= len(all) {
+		t.Errorf("GetDetections() expected a subset of the %d hints to be tagged fips, got %d", len(all), len(fips))
+	}
+	for _, d := range fips {
+		if !slices.Contains(d.Tags, "fips") {
+			t.Errorf("GetDetections() hint %v is not tagged fips: %v", d.ID, d.Tags)
+		}
+	}
+	inRange, summary, err := NewECDetection(ctx, s, nil, conn, myConfig).FilterByTags([]string{"fips"}).
+		GetDetectionsInRange([]dtos.ComponentDTO{{Purl: "pkg:github/pineappleea/pineapple-src", Requirement: ">=0.0.0"}})
+	if err != nil || len(inRange.Hints) != 1 || len(inRange.Hints[0].Detections) != 1 || inRange.Hints[0].Detections[0].ID != "library/boringssl" {
+		t.Errorf("GetDetectionsInRange() = %+v, %+v, %v", inRange, summary, err)
+	}
+	if len(inRange.Hints) == 1 && !slices.Equal(inRange.Hints[0].Versions, []string{"5.4.7"}) {
+		t.Errorf("GetDetectionsInRange() expected only the versions with fips hints, got %v", inRange.Hints[0].Versions)
+	}
+
+	if _, err = conn.ExecContext(ctx, "DROP TABLE crypto_library_tags"); err != nil {
+		t.Fatalf("failed to drop the tags table: %v", err)
+	}
+	hints, _, err = NewECDetection(ctx, s, nil, conn, myConfig).GetDetections(components)
+	if err != nil || len(hints.Hints[0].Detections) != len(all) {
+		t.Errorf("GetDetections() expected untagged hints without a tags table, got %v, %v", hints, err)
+	}
+	if _, _, err = NewECDetection(ctx, s, nil, conn, myConfig).FilterByTags([]string{"fips"}).GetDetections(components); err == nil {
+		t.Errorf("GetDetections() expected an error filtering by tag without a tags table")
+	}
+}
@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+// md5Pattern matches a hex encoded MD5 hash.
+var md5Pattern = regexp.MustCompile(`^[0-9a-fA-F]{32}$`)
+
+type PackagesUseCase struct {
+	ctx          context.Context
+	s            *zap.SugaredLogger
+	q            *database.DBQueryContext
+	allUrls      *models.AllUrlsModel
+	cryptoUsage  *models.CryptoUsageModel
+	libraryUsage *models.ECUsageModel
+}
+
+// NewPackages creates a new instance of the Packages use case, which looks up the KB by package hash.
+func NewPackages(ctx context.Context, s *zap.SugaredLogger, conn *sqlx.Conn, config *myconfig.ServerConfig) *PackagesUseCase {
+	q := database.NewDBSelectContext(s, nil, conn, config.Database.Trace)
+	return &PackagesUseCase{ctx: ctx, s: s, q: q,
+		allUrls:      models.NewAllURLModel(ctx, s, q),
+		cryptoUsage:  models.NewCryptoUsageModel(ctx, s, q),
+		libraryUsage: models.NewECUsageModel(ctx, s, q),
+	}
+}
+
+// withContext returns a copy of the use case whose models run with the given context.
+func (d PackagesUseCase) withContext(ctx context.Context) PackagesUseCase {
+	d.ctx = ctx
+	d.allUrls = models.NewAllURLModel(ctx, d.s, d.q)
+	d.cryptoUsage = models.NewCryptoUsageModel(ctx, d.s, d.q)
+	d.libraryUsage = models.NewECUsageModel(ctx, d.s, d.q)
+	return d
+}
+
+// IsPackageHash reports whether the value is a valid package hash (MD5).
+func IsPackageHash(value string) bool {
+	return md5Pattern.MatchString(value)
+}
+
+// GetPackagesCryptography resolves the package hashes (MD5 of the downloaded archives) to their component versions,
+// returning the algorithms and hints found in each of them.
+// The summary reports invalid hashes as failed to parse, unknown hashes as not found and packages without
+// cryptographic information as such.
+func (d PackagesUseCase) GetPackagesCryptography(hashes []string) (dtos.PackagesOutput, models.QuerySummary, error) {
+	ctx, span := startSpan(d.ctx, "PackagesUseCase.GetPackagesCryptography", attribute.Int("hashes", len(hashes)))
+	output, summary, err := d.withContext(ctx).getPackagesCryptography(hashes)
+	endSpan(span, summary, err)
+	return output, summary, err
+}
+
+// getPackagesCryptography runs the package hash lookup of GetPackagesCryptography.
+func (d PackagesUseCase) getPackagesCryptography(hashes []string) (dtos.PackagesOutput, models.QuerySummary, error) {
+	if len(hashes) == 0 {
+		d.s.Info("Empty List of package hashes supplied")
+		return dtos.PackagesOutput{}, models.QuerySummary{}, errors.New("empty list of package hashes")
+	}
+	summary := models.QuerySummary{TotalPurls: len(hashes)}
+	var valid []string
+	seen := make(map[string]bool, len(hashes))
+	for _, h := range hashes {
+		if IsPackageHash(h) {
+			if h = strings.ToLower(h); !seen[h] {
+				seen[h] = true
+				valid = append(valid, h)
+			}
+		} else {
+			summary.PurlsFailedToParse = append(summary.PurlsFailedToParse, h)
+		}
+	}
+	output := dtos.PackagesOutput{Packages: []dtos.PackagesOutputItem{}}
+	if len(valid) == 0 {
+		return output, summary, nil
+	}
+	urls, err := d.allUrls.GetUrlsByPackageHashes(valid)
+	if err != nil {
+		return dtos.PackagesOutput{}, models.QuerySummary{}, err
+	}
+	var found []string
+	for _, h := range valid {
+		if _, ok := urls[h]; ok {
+			found = append(found, h)
+		} else {
+			summary.PurlsNotFound = append(summary.PurlsNotFound, h)
+		}
+	}
+	if len(found) == 0 {
+		return output, summary, nil
+	}
+	algorithms, err := d.cryptoUsage.GetCryptoUsageByURLHashes(found)
+	if err != nil {
+		return dtos.PackagesOutput{}, models.QuerySummary{}, errors.New("error retrieving algorithms usage")
+	}
+	libraries, err := d.libraryUsage.GetLibraryUsageByURLHashes(found)
+	if err != nil {
+		return dtos.PackagesOutput{}, models.QuerySummary{}, errors.New("error retrieving library usage")
+	}
+	if libraries, err = tagLibraryUsage(d.s, d.libraryUsage, libraries, nil); err != nil {
+		return dtos.PackagesOutput{}, models.QuerySummary{}, err
+	}
+	// avoid duplicate algorithms
+	algorithmsByHash := make(map[string][]dtos.CryptoUsageItem)
+	nonDupAlgorithms := make(map[string]map[models.CryptoItem]bool)
+	for _, a := range algorithms {
+		item := models.CryptoItem{Algorithm: a.Algorithm, Strength: a.Strength}
+		if nonDupAlgorithms[a.URLHash] == nil {
+			nonDupAlgorithms[a.URLHash] = make(map[models.CryptoItem]bool)
+		}
+		if !nonDupAlgorithms[a.URLHash][item] {
+			nonDupAlgorithms[a.URLHash][item] = true
+			algorithmsByHash[a.URLHash] = append(algorithmsByHash[a.URLHash], dtos.CryptoUsageItem{Algorithm: a.Algorithm, Strength: a.Strength})
+		}
+	}
+	hintsByHash := make(map[string][]dtos.ECDetectedItem)
+	for _, l := range libraries {
+		hintsByHash[l.URLHash] = append(hintsByHash[l.URLHash], dtos.ECDetectedItem{ID: l.ID, Name: l.Name,
+			Description: l.Description, URL: l.URL, Category: l.Category, Purl: l.Purl, Tags: l.Tags})
+	}
+	for _, h := range found {
+		u := urls[h]
+		item := dtos.PackagesOutputItem{PackageHash: h, Purl: "pkg:" + u.PurlType + "/" + u.PurlName, Version: u.Version,
+			Algorithms: append([]dtos.CryptoUsageItem{}, algorithmsByHash[h]...), Hints: append([]dtos.ECDetectedItem{}, hintsByHash[h]...)}
+		if len(item.Algorithms) == 0 && len(item.Hints) == 0 {
+			summary.PurlsWOInfo = append(summary.PurlsWOInfo, h)
+		}
+		output.Packages = append(output.Packages, item)
+	}
+	return output, summary, nil
+}
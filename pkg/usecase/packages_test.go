@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+func TestPackagesUseCase(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	conn, err := db.Connx(ctx) // Get a connection from the pool
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseConn(conn)
+	err = models.LoadTestSQLData(db, ctx, conn)
+	if err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	err = models.RunTestSQL(db, ctx, conn, "INSERT INTO component_crypto (url_hash, algorithm_name, strength) "+
+		"VALUES ('2c2ae45c192df28dcfd1caab7e2b12db', 'md5', '128');")
+	if err != nil {
+		t.Fatalf("failed to add a duplicated algorithm: %v", err)
+	}
+	packagesUc := NewPackages(ctx, s, conn, myConfig)
+	hashes := []string{"2C2AE45C192DF28DCFD1CAAB7E2B12DB", "c8b5644375cfb4acd72dfc8ff458f7e3", "00000000000000000000000000000000", "not-a-hash",
+		"2c2ae45c192df28dcfd1caab7e2b12db"}
+	output, summary, err := packagesUc.GetPackagesCryptography(hashes)
+	if err != nil {
+		t.Fatalf("the error '%v' was not expected when getting packages", err)
+	}
+	if len(output.Packages) != 2 {
+		t.Fatalf("expected 2 packages, got %+v", output.Packages)
+	}
+	engine, pineapple := output.Packages[0], output.Packages[1]
+	if engine.Purl != "pkg:github/scanoss/engine" || len(engine.Version) == 0 || len(engine.Algorithms) != 4 {
+		t.Errorf("unexpected package for the engine hash (expected 4 distinct algorithms): %+v", engine)
+	}
+	if pineapple.Purl != "pkg:github/pineappleea/pineapple-src" || len(pineapple.Hints) == 0 {
+		t.Errorf("unexpected package for the pineapple hash: %+v", pineapple)
+	}
+	if len(summary.PurlsNotFound) != 1 || len(summary.PurlsFailedToParse) != 1 || summary.TotalPurls != len(hashes) {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+	if _, _, err = packagesUc.GetPackagesCryptography(nil); err == nil {
+		t.Errorf("expected an error with an empty list of package hashes")
+	}
+}
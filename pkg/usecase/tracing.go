@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+// startSpan starts a span of the request for the named use case method.
+// The returned context carries the span, so the queries run with it are traced as its children.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer("scanoss.com/cryptography").Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan records the outcome of the use case (the purls it could not answer, or the error) on its span and ends it.
+func endSpan(span trace.Span, summary models.QuerySummary, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "use case failed")
+	} else {
+		span.SetAttributes(attribute.Int("purls.not_found", len(summary.PurlsNotFound)),
+			attribute.Int("purls.without_info", len(summary.PurlsWOInfo)),
+			attribute.Int("purls.failed_to_parse", len(summary.PurlsFailedToParse)))
+	}
+	span.End()
+}
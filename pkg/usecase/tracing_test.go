@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	_ "modernc.org/sqlite"
+	myconfig "scanoss.com/cryptography/pkg/config"
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+// spanAttribute returns the value of an integer attribute of the span, or -1 if it is not set.
+func spanAttribute(span sdktrace.ReadOnlySpan, key string) int64 {
+	for _, a := range span.Attributes() {
+		if a.Key == attribute.Key(key) {
+			return a.Value.AsInt64()
+		}
+	}
+	return -1
+}
+
+func TestUseCaseSpans(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+	ctx := ctxzap.ToContext(context.Background(), zlog.L)
+	s := ctxzap.Extract(ctx).Sugar()
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	conn, err := db.Connx(ctx) // Get a connection from the pool
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseConn(conn)
+	if err = models.LoadTestSQLData(db, ctx, conn); err != nil {
+		t.Fatalf("failed to load SQL test data: %v", err)
+	}
+	myConfig, err := myconfig.NewServerConfig(nil)
+	if err != nil {
+		t.Fatalf("failed to load Config: %v", err)
+	}
+	components := []dtos.ComponentDTO{{Purl: "pkg:github/scanoss/engine", Requirement: "v5.4.5"}, {Purl: "pkg:github/scanoss/missing"}}
+	if _, _, err = NewCrypto(ctx, s, conn, myConfig).GetComponentsAlgorithms(components); err != nil {
+		t.Fatalf("GetComponentsAlgorithms() unexpected error: %v", err)
+	}
+	components[0].Requirement = ">=v5.0.0"
+	if _, _, err = NewCryptoMajor(ctx, s, nil, conn, myConfig).GetCryptoInRange(components); err != nil {
+		t.Fatalf("GetCryptoInRange() unexpected error: %v", err)
+	}
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	// Every query is traced as a child of the use case (or batch) span that ran it
+	parents := map[string]string{
+		"AllUrlsModel.GetUrlsByPurlList":  "CryptoUseCase.GetComponentsAlgorithms",
+		"processBatch":                    "CryptoMajorUseCase.GetCryptoInRange",
+		"AllUrlsModel.GetUrlsByPurlNames": "processBatch",
+	}
+	for name, parent := range parents {
+		span, ok := spans[name]
+		if !ok {
+			t.Errorf("no %v span was recorded", name)
+			continue
+		}
+		if span.Parent().SpanID() != spans[parent].SpanContext().SpanID() {
+			t.Errorf("%v span is not a child of the %v span", name, parent)
+		}
+	}
+	if span := spans["AllUrlsModel.GetUrlsByPurlList"]; spanAttribute(span, "purls") != 2 || spanAttribute(span, "rows") <= 0 {
+		t.Errorf("AllUrlsModel.GetUrlsByPurlList span attributes = %v", span.Attributes())
+	}
+	for _, name := range []string{"CryptoUseCase.GetComponentsAlgorithms", "CryptoMajorUseCase.GetCryptoInRange"} {
+		if span := spans[name]; spanAttribute(span, "purls") != 2 || spanAttribute(span, "purls.not_found") != 1 {
+			t.Errorf("%v span attributes = %v", name, span.Attributes())
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+	gd "github.com/scanoss/go-grpc-helper/pkg/grpc/database"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.uber.org/zap"
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+// maxBatchSize limits the number of components resolved together by a single worker.
+const maxBatchSize = 250
+
+// componentBatchProcessor resolves a batch of components using the supplied connection.
+// It returns one result per component, in the same order as the batch. An error aborts the processing of the whole request.
+type componentBatchProcessor[T any] func(ctx context.Context, conn *sqlx.Conn, components []dtos.ComponentDTO) ([]componentResult[T], error)
+
+// componentResult holds the outcome of processing a single component.
+type componentResult[T any] struct {
+	item    T
+	ok      bool
+	summary models.QuerySummary
+}
+
+// batchResult holds the outcome of processing a batch of components.
+type batchResult[T any] struct {
+	results []componentResult[T]
+	err     error
+	done    bool
+}
+
+// workerPool fans component lookups across a bounded number of pooled database connections.
+type workerPool struct {
+	s       *zap.SugaredLogger
+	db      *sqlx.DB
+	conn    *sqlx.Conn
+	workers int
+}
+
+// newWorkerPool creates a worker pool. If no database pool is supplied, or only one worker is requested,
+// batches are processed sequentially on the supplied connection.
+func newWorkerPool(s *zap.SugaredLogger, db *sqlx.DB, conn *sqlx.Conn, workers int) workerPool {
+	return workerPool{s: s, db: db, conn: conn, workers: workers}
+}
+
+// splitBatches divides the components into batches, so that each worker gets a similar share (up to maxBatchSize).
+func splitBatches(components []dtos.ComponentDTO, workers int) [][]dtos.ComponentDTO {
+	size := len(components)
+	if workers > 1 {
+		size = (len(components) + workers - 1) / workers
+	}
+	size = max(min(size, maxBatchSize), 1)
+	var batches [][]dtos.ComponentDTO
+	for start := 0; start < len(components); start += size {
+		batches = append(batches, components[start:min(start+size, len(components))])
+	}
+	return batches
+}
+
+// processComponents runs the processor against batches of components and returns the items found in input order,
+// along with the merged summary of all components.
+func processComponents[T any](ctx context.Context, p workerPool, components []dtos.ComponentDTO, process componentBatchProcessor[T]) ([]T, models.QuerySummary, error) {
+	batches := splitBatches(components, p.workers)
+	results := make([]batchResult[T], len(batches))
+	workers := min(p.workers, len(batches))
+	if p.db == nil || workers <= 1 {
+		for i, batch := range batches {
+			if err := ctx.Err(); err != nil {
+				return nil, models.QuerySummary{}, err
+			}
+			r := &results[i]
+			r.results, r.err = runBatch(ctx, p.conn, batch, process)
+			if r.err != nil {
+				return nil, models.QuerySummary{}, r.err
+			}
+		}
+		return collectResults(results)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var connErr error
+	var connErrOnce sync.Once
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			conn := p.conn // The first worker reuses the connection already held by the request
+			if w > 0 || conn == nil {
+				var err error
+				conn, err = p.db.Connx(ctx) // Get a dedicated connection from the pool for this worker
+				if err != nil {
+					p.s.Errorf("Failed to get a database connection from the pool: %v", err)
+					connErrOnce.Do(func() { connErr = err })
+					cancel() // Stops the producer, so there is no need to drain the remaining jobs
+					return
+				}
+				defer gd.CloseSQLConnection(conn)
+			}
+			for i := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+				r := &results[i]
+				r.results, r.err = runBatch(ctx, conn, batches[i], process)
+				if r.err != nil {
+					cancel()
+					continue
+				}
+				r.done = true
+			}
+		}(w)
+	}
+feed:
+	for i := range batches {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	for _, r := range results {
+		if r.err != nil {
+			return nil, models.QuerySummary{}, r.err
+		}
+	}
+	if connErr != nil {
+		return nil, models.QuerySummary{}, connErr
+	}
+	for _, r := range results {
+		if !r.done {
+			return nil, models.QuerySummary{}, ctx.Err()
+		}
+	}
+	return collectResults(results)
+}
+
+// runBatch runs the processor against a batch of components, traced as a span of the use case.
+func runBatch[T any](ctx context.Context, conn *sqlx.Conn, batch []dtos.ComponentDTO, process componentBatchProcessor[T]) ([]componentResult[T], error) {
+	ctx, span := startSpan(ctx, "processBatch", attribute.Int("components", len(batch)))
+	defer span.End()
+	results, err := process(ctx, conn, batch)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "batch failed")
+	}
+	return results, err
+}
+
+// collectResults merges the per component results, preserving the input order.
+func collectResults[T any](batches []batchResult[T]) ([]T, models.QuerySummary, error) {
+	var items []T
+	summary := models.QuerySummary{}
+	for _, batch := range batches {
+		for _, r := range batch.results {
+			summary.Merge(r.summary)
+			if r.ok {
+				items = append(items, r.item)
+			}
+		}
+	}
+	return items, summary, nil
+}
@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: GPL-2.0-or-later
+/*
+ * Copyright (C) 2025 SCANOSS.COM
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 2 of the License, or
+ * (at your option) any later version.
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	zlog "github.com/scanoss/zap-logging-helper/pkg/logger"
+	_ "modernc.org/sqlite"
+	"scanoss.com/cryptography/pkg/dtos"
+	"scanoss.com/cryptography/pkg/models"
+)
+
+func TestProcessComponents(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	var components []dtos.ComponentDTO
+	for i := 0; i < 20; i++ {
+		components = append(components, dtos.ComponentDTO{Purl: fmt.Sprintf("pkg:github/scanoss/test-%02d", i)})
+	}
+	// Later batches finish first, so the output order can only be kept by the pool
+	process := func(ctx context.Context, conn *sqlx.Conn, batch []dtos.ComponentDTO) ([]componentResult[string], error) {
+		if conn == nil {
+			return nil, errors.New("missing connection")
+		}
+		results := make([]componentResult[string], len(batch))
+		for i, c := range batch {
+			var index int
+			_, _ = fmt.Sscanf(c.Purl, "pkg:github/scanoss/test-%02d", &index)
+			time.Sleep(time.Duration(20-index) * time.Millisecond)
+			if index%5 == 0 {
+				results[i].summary.PurlsNotFound = append(results[i].summary.PurlsNotFound, c.Purl)
+				continue
+			}
+			results[i].item, results[i].ok = c.Purl, true
+		}
+		return results, nil
+	}
+	for _, workers := range []int{0, 1, 4, 50} {
+		t.Run(fmt.Sprintf("workers_%d", workers), func(t *testing.T) {
+			pool := newWorkerPool(zlog.S, db, nil, workers)
+			if workers <= 1 {
+				conn, err := db.Connx(context.Background())
+				if err != nil {
+					t.Fatalf("failed to get a connection: %v", err)
+				}
+				defer models.CloseConn(conn)
+				pool.conn = conn
+			}
+			items, summary, err := processComponents(context.Background(), pool, components, process)
+			if err != nil {
+				t.Fatalf("processComponents() unexpected error: %v", err)
+			}
+			var wantItems, wantNotFound []string
+			for i, c := range components {
+				if i%5 == 0 {
+					wantNotFound = append(wantNotFound, c.Purl)
+				} else {
+					wantItems = append(wantItems, c.Purl)
+				}
+			}
+			if !reflect.DeepEqual(items, wantItems) {
+				t.Errorf("processComponents() items = %v, want %v", items, wantItems)
+			}
+			if !reflect.DeepEqual(summary.PurlsNotFound, wantNotFound) {
+				t.Errorf("processComponents() not found = %v, want %v", summary.PurlsNotFound, wantNotFound)
+			}
+		})
+	}
+}
+
+func TestProcessComponentsAbort(t *testing.T) {
+	err := zlog.NewSugaredDevLogger()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sugared logger", err)
+	}
+	defer zlog.SyncZap()
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer models.CloseDB(db)
+	components := make([]dtos.ComponentDTO, 2000) // Enough components to need several batches per worker
+	pool := newWorkerPool(zlog.S, db, nil, 4)
+
+	var processed atomic.Int32
+	wantErr := errors.New("requirement should include version range or major and wildcard")
+	_, _, err = processComponents(context.Background(), pool, components,
+		func(ctx context.Context, conn *sqlx.Conn, batch []dtos.ComponentDTO) ([]componentResult[string], error) {
+			if processed.Add(1) == 3 {
+				return nil, wantErr
+			}
+			time.Sleep(time.Millisecond)
+			return make([]componentResult[string], len(batch)), nil
+		})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("processComponents() error = %v, want %v", err, wantErr)
+	}
+	if processed.Load() == int32(len(splitBatches(components, 4))) {
+		t.Errorf("processComponents() expected processing to stop after an error")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	processed.Store(0)
+	_, _, err = processComponents(ctx, pool, components,
+		func(ctx context.Context, conn *sqlx.Conn, batch []dtos.ComponentDTO) ([]componentResult[string], error) {
+			if processed.Add(1) == 3 {
+				cancel()
+			}
+			return make([]componentResult[string], len(batch)), nil
+		})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("processComponents() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestSplitBatches(t *testing.T) {
+	tests := []struct {
+		name       string
+		components int
+		workers    int
+		want       []int
+	}{
+		{name: "sequential", components: 10, workers: 1, want: []int{10}},
+		{name: "even share", components: 10, workers: 4, want: []int{3, 3, 3, 1}},
+		{name: "more workers than components", components: 2, workers: 8, want: []int{1, 1}},
+		{name: "capped batch size", components: 600, workers: 0, want: []int{250, 250, 100}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			for _, batch := range splitBatches(make([]dtos.ComponentDTO, tt.components), tt.workers) {
+				got = append(got, len(batch))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitBatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}